@@ -0,0 +1,570 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// DeepCopy returns a deep copy of this swagger specification.
+//
+// The copy shares no mutable state with the original: it is safe to expand or
+// otherwise mutate the copy without affecting the original document.
+func (s *Swagger) DeepCopy() *Swagger {
+	if s == nil {
+		return nil
+	}
+
+	return &Swagger{
+		VendorExtensible: s.VendorExtensible.deepCopy(),
+		SwaggerProps:     s.SwaggerProps.deepCopy(),
+	}
+}
+
+func (o SwaggerProps) deepCopy() SwaggerProps {
+	c := o
+	c.Consumes = slices.Clone(o.Consumes)
+	c.Produces = slices.Clone(o.Produces)
+	c.Schemes = slices.Clone(o.Schemes)
+	c.Info = o.Info.DeepCopy()
+	c.Paths = o.Paths.DeepCopy()
+	c.Definitions = o.Definitions.DeepCopy()
+	c.Parameters = deepCopyMap(o.Parameters, func(p Parameter) Parameter { return *p.DeepCopy() })
+	c.Responses = deepCopyMap(o.Responses, func(r Response) Response { return *r.DeepCopy() })
+	c.SecurityDefinitions = o.SecurityDefinitions.DeepCopy()
+	c.Security = deepCopySecurity(o.Security)
+	c.Tags = deepCopySlice(o.Tags, func(t Tag) Tag { return *t.DeepCopy() })
+	c.ExternalDocs = o.ExternalDocs.DeepCopy()
+
+	return c
+}
+
+// DeepCopy returns a deep copy of this info object.
+func (i *Info) DeepCopy() *Info {
+	if i == nil {
+		return nil
+	}
+
+	c := *i
+	c.VendorExtensible = i.VendorExtensible.deepCopy()
+	c.Contact = i.Contact.DeepCopy()
+	c.License = i.License.DeepCopy()
+
+	return &c
+}
+
+// DeepCopy returns a deep copy of this contact info object.
+func (c *ContactInfo) DeepCopy() *ContactInfo {
+	if c == nil {
+		return nil
+	}
+
+	cp := *c
+	cp.VendorExtensible = c.VendorExtensible.deepCopy()
+
+	return &cp
+}
+
+// DeepCopy returns a deep copy of this license object.
+func (l *License) DeepCopy() *License {
+	if l == nil {
+		return nil
+	}
+
+	c := *l
+	c.VendorExtensible = l.VendorExtensible.deepCopy()
+
+	return &c
+}
+
+// DeepCopy returns a deep copy of this external documentation object.
+func (e *ExternalDocumentation) DeepCopy() *ExternalDocumentation {
+	if e == nil {
+		return nil
+	}
+
+	c := *e
+
+	return &c
+}
+
+// DeepCopy returns a deep copy of this XML object.
+func (x *XMLObject) DeepCopy() *XMLObject {
+	if x == nil {
+		return nil
+	}
+
+	c := *x
+
+	return &c
+}
+
+// DeepCopy returns a deep copy of this tag.
+func (t *Tag) DeepCopy() *Tag {
+	if t == nil {
+		return nil
+	}
+
+	c := *t
+	c.VendorExtensible = t.VendorExtensible.deepCopy()
+	c.ExternalDocs = t.ExternalDocs.DeepCopy()
+
+	return &c
+}
+
+// DeepCopy returns a deep copy of this security scheme.
+func (s *SecurityScheme) DeepCopy() *SecurityScheme {
+	if s == nil {
+		return nil
+	}
+
+	c := *s
+	c.VendorExtensible = s.VendorExtensible.deepCopy()
+	c.Scopes = maps.Clone(s.Scopes)
+
+	return &c
+}
+
+// DeepCopy returns a deep copy of these security definitions.
+func (s SecurityDefinitions) DeepCopy() SecurityDefinitions {
+	return deepCopyMap(s, (*SecurityScheme).DeepCopy)
+}
+
+// DeepCopy returns a deep copy of these paths.
+func (p *Paths) DeepCopy() *Paths {
+	if p == nil {
+		return nil
+	}
+
+	return &Paths{
+		VendorExtensible: p.VendorExtensible.deepCopy(),
+		Paths:            deepCopyMap(p.Paths, func(pi PathItem) PathItem { return *pi.DeepCopy() }),
+	}
+}
+
+// DeepCopy returns a deep copy of this path item.
+func (p *PathItem) DeepCopy() *PathItem {
+	if p == nil {
+		return nil
+	}
+
+	return &PathItem{
+		Refable:          p.Refable.deepCopy(),
+		VendorExtensible: p.VendorExtensible.deepCopy(),
+		PathItemProps: PathItemProps{
+			Get:        p.Get.DeepCopy(),
+			Put:        p.Put.DeepCopy(),
+			Post:       p.Post.DeepCopy(),
+			Delete:     p.Delete.DeepCopy(),
+			Options:    p.Options.DeepCopy(),
+			Head:       p.Head.DeepCopy(),
+			Patch:      p.Patch.DeepCopy(),
+			Parameters: deepCopySlice(p.Parameters, func(param Parameter) Parameter { return *param.DeepCopy() }),
+		},
+	}
+}
+
+// DeepCopy returns a deep copy of this operation.
+func (o *Operation) DeepCopy() *Operation {
+	if o == nil {
+		return nil
+	}
+
+	c := *o
+	c.VendorExtensible = o.VendorExtensible.deepCopy()
+	c.Consumes = slices.Clone(o.Consumes)
+	c.Produces = slices.Clone(o.Produces)
+	c.Schemes = slices.Clone(o.Schemes)
+	c.Tags = slices.Clone(o.Tags)
+	c.ExternalDocs = o.ExternalDocs.DeepCopy()
+	c.Security = deepCopySecurity(o.Security)
+	c.Parameters = deepCopySlice(o.Parameters, func(p Parameter) Parameter { return *p.DeepCopy() })
+	c.Responses = o.Responses.DeepCopy()
+
+	return &c
+}
+
+// DeepCopy returns a deep copy of this parameter.
+func (p *Parameter) DeepCopy() *Parameter {
+	if p == nil {
+		return nil
+	}
+
+	c := *p
+	c.Refable = p.Refable.deepCopy()
+	c.CommonValidations = p.CommonValidations.deepCopy()
+	c.SimpleSchema = p.SimpleSchema.deepCopy()
+	c.VendorExtensible = p.VendorExtensible.deepCopy()
+	c.Schema = p.Schema.DeepCopy()
+
+	return &c
+}
+
+// DeepCopy returns a deep copy of this items object.
+func (i *Items) DeepCopy() *Items {
+	if i == nil {
+		return nil
+	}
+
+	return &Items{
+		Refable:           i.Refable.deepCopy(),
+		CommonValidations: i.CommonValidations.deepCopy(),
+		SimpleSchema:      i.SimpleSchema.deepCopy(),
+		VendorExtensible:  i.VendorExtensible.deepCopy(),
+	}
+}
+
+// DeepCopy returns a deep copy of this header.
+func (h *Header) DeepCopy() *Header {
+	if h == nil {
+		return nil
+	}
+
+	return &Header{
+		CommonValidations: h.CommonValidations.deepCopy(),
+		SimpleSchema:      h.SimpleSchema.deepCopy(),
+		VendorExtensible:  h.VendorExtensible.deepCopy(),
+		HeaderProps:       h.HeaderProps,
+	}
+}
+
+// DeepCopy returns a deep copy of these responses.
+func (r *Responses) DeepCopy() *Responses {
+	if r == nil {
+		return nil
+	}
+
+	return &Responses{
+		VendorExtensible: r.VendorExtensible.deepCopy(),
+		ResponsesProps: ResponsesProps{
+			Default:             r.Default.DeepCopy(),
+			StatusCodeResponses: deepCopyMap(r.StatusCodeResponses, func(resp Response) Response { return *resp.DeepCopy() }),
+			StatusCodeRanges:    deepCopyMap(r.StatusCodeRanges, func(resp Response) Response { return *resp.DeepCopy() }),
+		},
+	}
+}
+
+// DeepCopy returns a deep copy of this response.
+func (r *Response) DeepCopy() *Response {
+	if r == nil {
+		return nil
+	}
+
+	return &Response{
+		Refable: r.Refable.deepCopy(),
+		ResponseProps: ResponseProps{
+			Description: r.Description,
+			Schema:      r.Schema.DeepCopy(),
+			Headers:     deepCopyMap(r.Headers, func(h Header) Header { return *h.DeepCopy() }),
+			Examples:    deepCopyAnyMap(r.Examples),
+		},
+		VendorExtensible: r.VendorExtensible.deepCopy(),
+	}
+}
+
+// DeepCopy returns a deep copy of this schema.
+func (s *Schema) DeepCopy() *Schema {
+	if s == nil {
+		return nil
+	}
+
+	return &Schema{
+		VendorExtensible:   s.VendorExtensible.deepCopy(),
+		SchemaProps:        s.SchemaProps.deepCopy(),
+		SwaggerSchemaProps: s.SwaggerSchemaProps.deepCopy(),
+		ExtraProps:         deepCopyAnyMap(s.ExtraProps),
+	}
+}
+
+func (s SchemaProps) deepCopy() SchemaProps {
+	c := s
+	c.Ref = s.Ref.DeepCopy()
+	c.Type = s.Type.DeepCopy()
+	c.Default = deepCopyAny(s.Default)
+	c.Maximum = deepCopyPtr(s.Maximum)
+	c.Minimum = deepCopyPtr(s.Minimum)
+	c.MaxLength = deepCopyPtr(s.MaxLength)
+	c.MinLength = deepCopyPtr(s.MinLength)
+	c.MaxItems = deepCopyPtr(s.MaxItems)
+	c.MinItems = deepCopyPtr(s.MinItems)
+	c.MultipleOf = deepCopyPtr(s.MultipleOf)
+	c.Enum = deepCopyAnySlice(s.Enum)
+	c.MaxProperties = deepCopyPtr(s.MaxProperties)
+	c.MinProperties = deepCopyPtr(s.MinProperties)
+	c.Required = slices.Clone(s.Required)
+	c.Items = s.Items.DeepCopy()
+	c.AllOf = deepCopySchemas(s.AllOf)
+	c.OneOf = deepCopySchemas(s.OneOf)
+	c.AnyOf = deepCopySchemas(s.AnyOf)
+	c.Not = s.Not.DeepCopy()
+	c.Properties = s.Properties.DeepCopy()
+	c.AdditionalProperties = s.AdditionalProperties.DeepCopy()
+	c.PatternProperties = s.PatternProperties.DeepCopy()
+	c.Dependencies = s.Dependencies.DeepCopy()
+	c.AdditionalItems = s.AdditionalItems.DeepCopy()
+	c.Definitions = s.Definitions.DeepCopy()
+
+	return c
+}
+
+func (s SwaggerSchemaProps) deepCopy() SwaggerSchemaProps {
+	c := s
+	c.XML = s.XML.DeepCopy()
+	c.ExternalDocs = s.ExternalDocs.DeepCopy()
+	c.Example = deepCopyAny(s.Example)
+
+	return c
+}
+
+// DeepCopy returns a deep copy of this schema or bool.
+func (s *SchemaOrBool) DeepCopy() *SchemaOrBool {
+	if s == nil {
+		return nil
+	}
+
+	return &SchemaOrBool{
+		Allows: s.Allows,
+		Schema: s.Schema.DeepCopy(),
+	}
+}
+
+// DeepCopy returns a deep copy of this schema or array.
+func (s *SchemaOrArray) DeepCopy() *SchemaOrArray {
+	if s == nil {
+		return nil
+	}
+
+	return &SchemaOrArray{
+		Schema:  s.Schema.DeepCopy(),
+		Schemas: deepCopySchemas(s.Schemas),
+	}
+}
+
+// DeepCopy returns a deep copy of this schema or string array.
+func (s *SchemaOrStringArray) DeepCopy() *SchemaOrStringArray {
+	if s == nil {
+		return nil
+	}
+
+	return &SchemaOrStringArray{
+		Schema:   s.Schema.DeepCopy(),
+		Property: slices.Clone(s.Property),
+	}
+}
+
+// DeepCopy returns a deep copy of these definitions.
+func (d Definitions) DeepCopy() Definitions {
+	return deepCopyMap(d, func(s Schema) Schema { return *s.DeepCopy() })
+}
+
+// DeepCopy returns a deep copy of these schema properties.
+func (properties SchemaProperties) DeepCopy() SchemaProperties {
+	return deepCopyMap(properties, func(s Schema) Schema { return *s.DeepCopy() })
+}
+
+// DeepCopy returns a deep copy of these dependencies.
+func (d Dependencies) DeepCopy() Dependencies {
+	return deepCopyMap(d, func(s SchemaOrStringArray) SchemaOrStringArray { return *s.DeepCopy() })
+}
+
+// DeepCopy returns a deep copy of this string or array.
+func (s StringOrArray) DeepCopy() StringOrArray {
+	return slices.Clone(s)
+}
+
+// DeepCopy returns a deep copy of these extensions.
+//
+// Extension values are copied recursively.
+func (e Extensions) DeepCopy() Extensions {
+	return deepCopyAnyMap(e)
+}
+
+// DeepCopy returns a copy of this reference.
+//
+// The copy does not share its inner URL with the original.
+func (r Ref) DeepCopy() Ref {
+	if r.GetURL() == nil {
+		return r
+	}
+
+	c, err := NewRef(r.String())
+	if err != nil {
+		// should not happen, as the original reference has been parsed already
+		return r
+	}
+
+	return c
+}
+
+func (r Refable) deepCopy() Refable {
+	return Refable{Ref: r.Ref.DeepCopy()}
+}
+
+func (v VendorExtensible) deepCopy() VendorExtensible {
+	return VendorExtensible{Extensions: v.Extensions.DeepCopy()}
+}
+
+func (v CommonValidations) deepCopy() CommonValidations {
+	c := v
+	c.Maximum = deepCopyPtr(v.Maximum)
+	c.Minimum = deepCopyPtr(v.Minimum)
+	c.MaxLength = deepCopyPtr(v.MaxLength)
+	c.MinLength = deepCopyPtr(v.MinLength)
+	c.MaxItems = deepCopyPtr(v.MaxItems)
+	c.MinItems = deepCopyPtr(v.MinItems)
+	c.MultipleOf = deepCopyPtr(v.MultipleOf)
+	c.Enum = deepCopyAnySlice(v.Enum)
+
+	return c
+}
+
+func (s SimpleSchema) deepCopy() SimpleSchema {
+	c := s
+	c.Items = s.Items.DeepCopy()
+	c.Default = deepCopyAny(s.Default)
+	c.Example = deepCopyAny(s.Example)
+
+	return c
+}
+
+func deepCopySchemas(in []Schema) []Schema {
+	return deepCopySlice(in, func(s Schema) Schema { return *s.DeepCopy() })
+}
+
+func deepCopySecurity(in []map[string][]string) []map[string][]string {
+	return deepCopySlice(in, func(req map[string][]string) map[string][]string {
+		return deepCopyMap(req, slices.Clone[[]string])
+	})
+}
+
+func deepCopyPtr[T any](in *T) *T {
+	if in == nil {
+		return nil
+	}
+
+	c := *in
+
+	return &c
+}
+
+// deepCopySlice copies a slice, preserving the distinction between nil and empty slices.
+func deepCopySlice[T any](in []T, copier func(T) T) []T {
+	if in == nil {
+		return nil
+	}
+
+	c := make([]T, len(in))
+	for i, v := range in {
+		c[i] = copier(v)
+	}
+
+	return c
+}
+
+// deepCopyMap copies a map, preserving the distinction between nil and empty maps.
+func deepCopyMap[M ~map[K]V, K comparable, V any](in M, copier func(V) V) M {
+	if in == nil {
+		return nil
+	}
+
+	c := make(M, len(in))
+	for k, v := range in {
+		c[k] = copier(v)
+	}
+
+	return c
+}
+
+func deepCopyAnyMap[M ~map[string]any](in M) M {
+	return deepCopyMap(in, deepCopyAny)
+}
+
+func deepCopyAnySlice(in []any) []any {
+	return deepCopySlice(in, deepCopyAny)
+}
+
+// deepCopyAny copies a value held by an interface, such as default values, examples, enums or extensions.
+//
+// Values produced by the JSON decoder are copied directly. Any other value is copied with reflection.
+func deepCopyAny(in any) any {
+	switch v := in.(type) {
+	case nil, bool, string, float64, float32, json.Number,
+		int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return v
+	case map[string]any:
+		return deepCopyAnyMap(v)
+	case []any:
+		return deepCopyAnySlice(v)
+	default:
+		return deepCopyReflect(reflect.ValueOf(in)).Interface()
+	}
+}
+
+// deepCopyReflect copies values of arbitrary types.
+//
+// Unexported struct fields are copied shallowly.
+func deepCopyReflect(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Elem().Type())
+		c.Elem().Set(deepCopyReflect(v.Elem()))
+
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopyReflect(v.Elem()))
+
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopyReflect(iter.Value()))
+		}
+
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			c.Index(i).Set(deepCopyReflect(v.Index(i)))
+		}
+
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			c.Index(i).Set(deepCopyReflect(v.Index(i)))
+		}
+
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := range v.NumField() {
+			if !v.Type().Field(i).IsExported() {
+				continue
+			}
+			c.Field(i).Set(deepCopyReflect(v.Field(i)))
+		}
+
+		return c
+	default:
+		return v
+	}
+}
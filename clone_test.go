@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestDeepCopy_Swagger(t *testing.T) {
+	var sp Swagger
+	require.NoError(t, json.Unmarshal(specJSON, &sp))
+
+	original, err := json.Marshal(sp)
+	require.NoError(t, err)
+
+	cp := sp.DeepCopy()
+	require.NotNil(t, cp)
+	assert.EqualValues(t, sp, *cp)
+
+	t.Run("copy should serialize like the original", func(t *testing.T) {
+		copied, err := json.Marshal(cp)
+		require.NoError(t, err)
+		assert.JSONEqBytes(t, original, copied)
+	})
+
+	t.Run("mutating the copy should not affect the original", func(t *testing.T) {
+		cp.Info.Title = "changed"
+		cp.Extensions["x-framework"] = "changed"
+		cp.Consumes[0] = "changed"
+
+		for path, pi := range cp.Paths.Paths {
+			for _, op := range []*Operation{pi.Get, pi.Put, pi.Post, pi.Delete, pi.Options, pi.Head, pi.Patch} {
+				if op == nil {
+					continue
+				}
+				op.Summary = "changed"
+				op.Tags = append(op.Tags[:0], "changed")
+				for i := range op.Parameters {
+					op.Parameters[i].Name = "changed"
+				}
+			}
+			delete(cp.Paths.Paths, path)
+		}
+
+		for k, def := range cp.Definitions {
+			def.Properties = nil
+			cp.Definitions[k] = def
+		}
+
+		unchanged, err := json.Marshal(sp)
+		require.NoError(t, err)
+		assert.JSONEqBytes(t, original, unchanged)
+	})
+}
+
+func TestDeepCopy_ExpandCopy(t *testing.T) {
+	var sp Swagger
+	require.NoError(t, json.Unmarshal(PetStore20, &sp))
+
+	original, err := json.Marshal(sp)
+	require.NoError(t, err)
+
+	cp := sp.DeepCopy()
+	require.NoError(t, ExpandSpec(cp, nil))
+
+	unchanged, err := json.Marshal(sp)
+	require.NoError(t, err)
+	assert.JSONEqBytes(t, original, unchanged)
+}
+
+func TestDeepCopy_Schema(t *testing.T) {
+	maximum := 10.0
+	minLength := int64(2)
+	sch := &Schema{
+		VendorExtensible: VendorExtensible{Extensions: Extensions{
+			"x-nested": map[string]any{"a": []any{"b", map[string]any{"c": 1.0}}},
+		}},
+		SchemaProps: SchemaProps{
+			Ref:       MustCreateRef("#/definitions/pet"),
+			Type:      StringOrArray{"object"},
+			Maximum:   &maximum,
+			MinLength: &minLength,
+			Default:   map[string]any{"name": "fido"},
+			Enum:      []any{[]any{"x"}, "y"},
+			Required:  []string{"name"},
+			Properties: SchemaProperties{
+				"name": *StringProperty(),
+			},
+			AdditionalProperties: &SchemaOrBool{Allows: true, Schema: StringProperty()},
+			Items:                &SchemaOrArray{Schemas: []Schema{*StringProperty()}},
+			Dependencies: Dependencies{
+				"name": {Property: []string{"id"}},
+			},
+		},
+		SwaggerSchemaProps: SwaggerSchemaProps{
+			Example: []any{map[string]any{"name": "rex"}},
+			XML:     &XMLObject{Name: "pet"},
+		},
+		ExtraProps: map[string]any{"extra": []any{1.0}},
+	}
+
+	cp := sch.DeepCopy()
+	require.NotNil(t, cp)
+	assert.EqualValues(t, *sch, *cp)
+	assert.EqualT(t, sch.Ref.String(), cp.Ref.String())
+
+	*cp.Maximum = 20
+	*cp.MinLength = 5
+	cp.Default.(map[string]any)["name"] = "changed"
+	cp.Enum[0].([]any)[0] = "changed"
+	cp.Required[0] = "changed"
+	cp.Properties["name"] = *Int64Property()
+	cp.AdditionalProperties.Schema.Type[0] = "changed"
+	cp.Items.Schemas[0].Type[0] = "changed"
+	cp.Dependencies["name"].Property[0] = "changed"
+	cp.Example.([]any)[0].(map[string]any)["name"] = "changed"
+	cp.XML.Name = "changed"
+	cp.ExtraProps["extra"].([]any)[0] = 2.0
+	cp.Extensions["x-nested"].(map[string]any)["a"].([]any)[1].(map[string]any)["c"] = 2.0
+
+	assert.InDelta(t, 10.0, *sch.Maximum, 1e-9)
+	assert.EqualT(t, int64(2), *sch.MinLength)
+	assert.Equal(t, map[string]any{"name": "fido"}, sch.Default)
+	assert.Equal(t, []any{[]any{"x"}, "y"}, sch.Enum)
+	assert.Equal(t, []string{"name"}, sch.Required)
+	assert.Equal(t, *StringProperty(), sch.Properties["name"])
+	assert.Equal(t, StringOrArray{"string"}, sch.AdditionalProperties.Schema.Type)
+	assert.Equal(t, StringOrArray{"string"}, sch.Items.Schemas[0].Type)
+	assert.Equal(t, []string{"id"}, sch.Dependencies["name"].Property)
+	assert.Equal(t, []any{map[string]any{"name": "rex"}}, sch.Example)
+	assert.EqualT(t, "pet", sch.XML.Name)
+	assert.Equal(t, []any{1.0}, sch.ExtraProps["extra"])
+	assert.Equal(t, map[string]any{"a": []any{"b", map[string]any{"c": 1.0}}}, sch.Extensions["x-nested"])
+}
+
+func TestDeepCopy_Parameter(t *testing.T) {
+	param := QueryParam("limit").Typed("integer", "int32").WithMaximum(100, false).WithEnum(1, 10, 100)
+	param.Items = NewItems().Typed("string", "").WithMaxLength(5)
+	param.AddExtension("x-values", []any{"a"})
+
+	cp := param.DeepCopy()
+	assert.EqualValues(t, *param, *cp)
+
+	*cp.Maximum = 0
+	cp.Enum[0] = 0
+	*cp.Items.MaxLength = 0
+	cp.Extensions["x-values"].([]any)[0] = "b"
+
+	assert.InDelta(t, 100.0, *param.Maximum, 1e-9)
+	assert.Equal(t, []any{1, 10, 100}, param.Enum)
+	assert.EqualT(t, int64(5), *param.Items.MaxLength)
+	assert.Equal(t, []any{"a"}, param.Extensions["x-values"])
+}
+
+func TestDeepCopy_PreservesNilAndEmpty(t *testing.T) {
+	op := NewOperation("op")
+	op.Security = []map[string][]string{}
+
+	cp := op.DeepCopy()
+	require.NotNil(t, cp.Security)
+	assert.Empty(t, cp.Security)
+	assert.Nil(t, cp.Parameters)
+	assert.Nil(t, cp.Responses)
+
+	var nilSchema *Schema
+	assert.Nil(t, nilSchema.DeepCopy())
+
+	var nilSwagger *Swagger
+	assert.Nil(t, nilSwagger.DeepCopy())
+}
+
+func TestDeepCopy_AnyValues(t *testing.T) {
+	type custom struct {
+		Values []int
+		Next   *custom
+	}
+
+	original := &custom{Values: []int{1}, Next: &custom{Values: []int{2}}}
+	cp, ok := deepCopyAny(original).(*custom)
+	require.True(t, ok)
+	assert.Equal(t, original, cp)
+
+	cp.Values[0] = 10
+	cp.Next.Values[0] = 20
+	assert.Equal(t, []int{1}, original.Values)
+	assert.Equal(t, []int{2}, original.Next.Values)
+
+	assert.Equal(t, json.Number("12"), deepCopyAny(json.Number("12")))
+	assert.Nil(t, deepCopyAny(nil))
+}
@@ -0,0 +1,377 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"strings"
+)
+
+// Compatibility tells if a change breaks existing consumers of an API.
+type Compatibility string
+
+// Compatibility levels of a change.
+const (
+	CompatibilityNonBreaking Compatibility = "non-breaking"
+	CompatibilityBreaking    Compatibility = "breaking"
+	CompatibilityUnknown     Compatibility = "unknown"
+)
+
+// ClassifiedChange is a [Change] with its compatibility for clients and for servers.
+//
+// Client compatibility tells if an existing client, built against the old specification,
+// keeps working with a server implementing the new one.
+//
+// Server compatibility tells if an existing server, implementing the old specification,
+// keeps working with clients built against the new one.
+type ClassifiedChange struct {
+	Change
+
+	Client Compatibility `json:"client"`
+	Server Compatibility `json:"server"`
+
+	// Reason explains the classification
+	Reason string `json:"reason,omitempty"`
+}
+
+// CompatibilityReport is the result of a compatibility check between two specifications.
+type CompatibilityReport struct {
+	// ClientBreaking is true when at least one change breaks existing clients
+	ClientBreaking bool `json:"clientBreaking"`
+
+	// ServerBreaking is true when at least one change breaks existing servers
+	ServerBreaking bool `json:"serverBreaking"`
+
+	// Changes holds all classified changes, in the order reported by [Diff]
+	Changes []ClassifiedChange `json:"changes"`
+}
+
+// HasBreakingChanges tells if some change breaks existing clients or servers.
+func (r *CompatibilityReport) HasBreakingChanges() bool {
+	return r.ClientBreaking || r.ServerBreaking
+}
+
+// Breaking returns the changes which break existing clients or servers.
+func (r *CompatibilityReport) Breaking() []ClassifiedChange {
+	var breaking []ClassifiedChange
+	for _, c := range r.Changes {
+		if c.Client == CompatibilityBreaking || c.Server == CompatibilityBreaking {
+			breaking = append(breaking, c)
+		}
+	}
+
+	return breaking
+}
+
+// CheckCompatibility compares two specifications and classifies each change as breaking,
+// non-breaking or unknown, for clients and for servers.
+//
+// $ref's are resolved like with [Diff].
+func CheckCompatibility(oldSpec, newSpec *Swagger) (*CompatibilityReport, error) {
+	return CheckCompatibilityWithOptions(oldSpec, newSpec, nil)
+}
+
+// CheckCompatibilityWithOptions compares two specifications and classifies their changes,
+// with options to resolve $ref's.
+func CheckCompatibilityWithOptions(oldSpec, newSpec *Swagger, options *DiffOptions) (*CompatibilityReport, error) {
+	changes, err := DiffWithOptions(oldSpec, newSpec, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return ClassifyChanges(changes), nil
+}
+
+// ClassifyChanges classifies a list of changes produced by [Diff].
+func ClassifyChanges(changes Changes) *CompatibilityReport {
+	report := &CompatibilityReport{
+		Changes: make([]ClassifiedChange, 0, len(changes)),
+	}
+
+	for _, change := range changes {
+		c := ClassifyChange(change)
+		report.ClientBreaking = report.ClientBreaking || c.Client == CompatibilityBreaking
+		report.ServerBreaking = report.ServerBreaking || c.Server == CompatibilityBreaking
+		report.Changes = append(report.Changes, c)
+	}
+
+	return report
+}
+
+// direction tells how a change affects the set of values accepted or produced at some location.
+type direction uint8
+
+const (
+	directionUnknown direction = iota
+	directionNarrowed
+	directionWidened
+)
+
+// ClassifyChange classifies a single change produced by [Diff].
+//
+// The classification follows these rules:
+//
+//   - removing a capability (path, operation, scheme, media type, response property or header)
+//     breaks clients, adding one breaks servers which do not provide it;
+//   - narrowing the values accepted in a request (e.g. a new required parameter, a removed enum value,
+//     a lower maximum) breaks clients, widening them breaks servers;
+//   - widening the values produced in a response breaks clients, narrowing them breaks servers;
+//   - changing the type or format of a value breaks both;
+//   - changes in shared definitions, parameters and responses are non-breaking on their own:
+//     their effects are reported on the operations using them.
+func ClassifyChange(change Change) ClassifiedChange {
+	c := ClassifiedChange{Change: change}
+
+	if isSharedPointer(change.Pointer) {
+		return c.classify(CompatibilityNonBreaking, CompatibilityNonBreaking, "shared definition: effects are reported on the operations using it")
+	}
+
+	switch change.Kind {
+	case ChangePathAdded, ChangeOperationAdded, ChangeSchemeAdded, ChangeMediaTypeAdded, ChangeHeaderAdded:
+		return c.added()
+	case ChangePathRemoved, ChangeOperationRemoved, ChangeSchemeRemoved, ChangeMediaTypeRemoved, ChangeHeaderRemoved:
+		return c.removed()
+
+	case ChangePropertyAdded:
+		if change.Location == LocationResponse {
+			return c.added()
+		}
+
+		return c.byDirection(directionWidened, "property added")
+	case ChangePropertyRemoved:
+		if change.Location == LocationResponse {
+			return c.removed()
+		}
+
+		return c.classify(CompatibilityNonBreaking, CompatibilityUnknown, "property removed: an existing server may still expect it")
+
+	case ChangeParameterAdded:
+		if change.Field == "required" {
+			return c.byDirection(directionNarrowed, "required parameter added")
+		}
+
+		return c.byDirection(directionWidened, "optional parameter added")
+	case ChangeParameterRemoved:
+		if change.Field == "required" {
+			return c.classify(CompatibilityNonBreaking, CompatibilityBreaking, "required parameter removed: existing servers still expect it")
+		}
+
+		return c.classify(CompatibilityNonBreaking, CompatibilityNonBreaking, "optional parameter removed")
+	case ChangeParameterChanged:
+		return c.parameterChanged()
+
+	case ChangeResponseAdded:
+		return c.byDirection(directionWidened, "response added")
+	case ChangeResponseRemoved:
+		return c.byDirection(directionNarrowed, "response removed")
+
+	case ChangeRequiredAdded:
+		return c.byDirection(directionNarrowed, "property made required")
+	case ChangeRequiredRemoved:
+		return c.byDirection(directionWidened, "property made optional")
+	case ChangeEnumValueAdded:
+		return c.byDirection(directionWidened, "enum value added")
+	case ChangeEnumValueRemoved:
+		return c.byDirection(directionNarrowed, "enum value removed")
+	case ChangeConstraintChanged:
+		return c.byDirection(constraintDirection(change.Field, change.Old, change.New), "constraint "+change.Field+" changed")
+
+	case ChangeTypeChanged:
+		return c.classify(CompatibilityBreaking, CompatibilityBreaking, "type changed")
+	case ChangeFormatChanged:
+		return c.classify(CompatibilityBreaking, CompatibilityBreaking, "format changed")
+
+	case ChangeSecurityRequirementAdded:
+		if change.Field == "required" {
+			return c.byDirection(directionNarrowed, "security requirement added where none was required")
+		}
+
+		return c.byDirection(directionWidened, "alternative security requirement added")
+	case ChangeSecurityRequirementRemoved:
+		if change.Field == "required" {
+			return c.byDirection(directionWidened, "last security requirement removed")
+		}
+
+		return c.byDirection(directionNarrowed, "security requirement removed")
+	case ChangeSecuritySchemeAdded, ChangeSecuritySchemeRemoved:
+		return c.classify(CompatibilityNonBreaking, CompatibilityNonBreaking, "security scheme definitions only matter when required by operations")
+	case ChangeSecuritySchemeChanged:
+		return c.classify(CompatibilityBreaking, CompatibilityBreaking, "security scheme changed")
+	case ChangeScopeAdded:
+		return c.classify(CompatibilityNonBreaking, CompatibilityNonBreaking, "scope added")
+	case ChangeScopeRemoved:
+		return c.removed()
+
+	case ChangeValueChanged:
+		return c.valueChanged()
+
+	case ChangeDefinitionAdded, ChangeDefinitionRemoved:
+		return c.classify(CompatibilityNonBreaking, CompatibilityNonBreaking, "shared definition: effects are reported on the operations using it")
+
+	default:
+		// ref-changed, schema-added, schema-removed and extensions
+		return c.classify(CompatibilityUnknown, CompatibilityUnknown, "")
+	}
+}
+
+func (c ClassifiedChange) classify(client, server Compatibility, reason string) ClassifiedChange {
+	c.Client, c.Server, c.Reason = client, server, reason
+
+	return c
+}
+
+// added classifies a new capability: servers implementing the old spec do not provide it.
+func (c ClassifiedChange) added() ClassifiedChange {
+	return c.classify(CompatibilityNonBreaking, CompatibilityBreaking, string(c.Kind)+": existing servers do not provide it")
+}
+
+// removed classifies a removed capability: existing clients may still use it.
+func (c ClassifiedChange) removed() ClassifiedChange {
+	return c.classify(CompatibilityBreaking, CompatibilityNonBreaking, string(c.Kind)+": existing clients may still use it")
+}
+
+// byDirection classifies a change which narrows or widens the values exchanged at the location of the change.
+func (c ClassifiedChange) byDirection(dir direction, reason string) ClassifiedChange {
+	switch {
+	case dir == directionUnknown || c.Location == LocationNone:
+		return c.classify(CompatibilityUnknown, CompatibilityUnknown, reason)
+	case (dir == directionNarrowed) == (c.Location == LocationRequest):
+		// narrowed request or widened response
+		return c.classify(CompatibilityBreaking, CompatibilityNonBreaking, reason+" in "+string(c.Location))
+	default:
+		// widened request or narrowed response
+		return c.classify(CompatibilityNonBreaking, CompatibilityBreaking, reason+" in "+string(c.Location))
+	}
+}
+
+func (c ClassifiedChange) parameterChanged() ClassifiedChange {
+	switch c.Field {
+	case "required":
+		if isTrue(c.New) {
+			return c.byDirection(directionNarrowed, "parameter made required")
+		}
+
+		return c.byDirection(directionWidened, "parameter made optional")
+	case "allowEmptyValue":
+		if isTrue(c.New) {
+			return c.byDirection(directionWidened, "empty value allowed")
+		}
+
+		return c.byDirection(directionNarrowed, "empty value disallowed")
+	default:
+		// in, collectionFormat
+		return c.classify(CompatibilityBreaking, CompatibilityBreaking, "parameter "+c.Field+" changed")
+	}
+}
+
+func (c ClassifiedChange) valueChanged() ClassifiedChange {
+	switch {
+	case c.Pointer == "/info" || strings.HasPrefix(c.Pointer, "/info/"):
+		return c.classify(CompatibilityNonBreaking, CompatibilityNonBreaking, "documentation changed")
+	case c.Field == "host" || c.Field == "basePath":
+		return c.classify(CompatibilityBreaking, CompatibilityBreaking, c.Field+" changed")
+	case c.Field == "id":
+		return c.classify(CompatibilityNonBreaking, CompatibilityNonBreaking, "id changed")
+	case c.Field == "nullable":
+		if isTrue(c.New) {
+			return c.byDirection(directionWidened, "value made nullable")
+		}
+
+		return c.byDirection(directionNarrowed, "value made non-nullable")
+	case c.Field == "discriminator":
+		return c.classify(CompatibilityBreaking, CompatibilityBreaking, "discriminator changed")
+	case c.Field == "security":
+		// a nil requirement inherits the global one, an empty one disables security
+		if c.New != nil {
+			return c.byDirection(directionWidened, "security disabled")
+		}
+
+		return c.byDirection(directionNarrowed, "security enabled")
+	default:
+		// swagger, readOnly, default
+		return c.classify(CompatibilityUnknown, CompatibilityUnknown, c.Field+" changed")
+	}
+}
+
+// isSharedPointer tells if a pointer lies in the shared sections of a spec,
+// which only affect operations through $ref's.
+func isSharedPointer(ptr string) bool {
+	for _, section := range []string{"/definitions/", "/parameters/", "/responses/"} {
+		if strings.HasPrefix(ptr, section) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// constraintDirection tells if a changed validation constraint narrows or widens the set of valid values.
+//
+// Unset constraints are represented by nil values, as reported by [Diff].
+func constraintDirection(field string, o, nw any) direction {
+	switch {
+	case o == nil && nw == nil:
+		return directionUnknown
+	case o == nil:
+		if field == "additionalProperties" {
+			return directionUnknown
+		}
+
+		return directionNarrowed
+	case nw == nil:
+		if field == "additionalProperties" {
+			return directionUnknown
+		}
+
+		return directionWidened
+	}
+
+	switch field {
+	case "maximum", "maxLength", "maxItems", "maxProperties":
+		return compareBounds(o, nw, directionNarrowed, directionWidened)
+	case "minimum", "minLength", "minItems", "minProperties":
+		return compareBounds(o, nw, directionWidened, directionNarrowed)
+	case "additionalProperties":
+		if isTrue(nw) {
+			return directionWidened
+		}
+
+		return directionNarrowed
+	default:
+		// pattern, multipleOf
+		return directionUnknown
+	}
+}
+
+// compareBounds returns whenLower if the new bound is lower than the old one, whenHigher if it is higher.
+func compareBounds(o, nw any, whenLower, whenHigher direction) direction {
+	oldBound, okOld := constraintFloat(o)
+	newBound, okNew := constraintFloat(nw)
+	switch {
+	case !okOld || !okNew:
+		return directionUnknown
+	case newBound < oldBound:
+		return whenLower
+	case newBound > oldBound:
+		return whenHigher
+	default:
+		return directionUnknown
+	}
+}
+
+func constraintFloat(v any) (float64, bool) {
+	switch tv := v.(type) {
+	case float64:
+		return tv, true
+	case int64:
+		return float64(tv), true
+	default:
+		return 0, false
+	}
+}
+
+func isTrue(v any) bool {
+	b, ok := v.(bool)
+
+	return ok && b
+}
@@ -0,0 +1,243 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func findClassified(report *CompatibilityReport, kind ChangeKind, ptr, field string) (ClassifiedChange, bool) {
+	for _, c := range report.Changes {
+		if c.Kind == kind && c.Pointer == ptr && c.Field == field {
+			return c, true
+		}
+	}
+
+	return ClassifiedChange{}, false
+}
+
+func assertClassified(t testing.TB, report *CompatibilityReport, kind ChangeKind, ptr, field string, client, server Compatibility) {
+	t.Helper()
+
+	c, ok := findClassified(report, kind, ptr, field)
+	if !assert.TrueTf(t, ok, "expected change %s at %s [%s]", kind, ptr, field) {
+		return
+	}
+	assert.EqualTf(t, client, c.Client, "client compatibility of %v", c.Change)
+	assert.EqualTf(t, server, c.Server, "server compatibility of %v", c.Change)
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	report, err := CheckCompatibility(mustSpec(t, diffOldSpec), mustSpec(t, diffNewSpec))
+	require.NoError(t, err)
+
+	assert.TrueT(t, report.ClientBreaking)
+	assert.TrueT(t, report.ServerBreaking)
+	assert.TrueT(t, report.HasBreakingChanges())
+	assert.NotEmpty(t, report.Breaking())
+
+	const (
+		breaking    = CompatibilityBreaking
+		nonBreaking = CompatibilityNonBreaking
+		unknown     = CompatibilityUnknown
+	)
+
+	t.Run("capabilities", func(t *testing.T) {
+		assertClassified(t, report, ChangePathAdded, "/paths/~1owners", "", nonBreaking, breaking)
+		assertClassified(t, report, ChangeOperationRemoved, "/paths/~1pets/post", "", breaking, nonBreaking)
+		assertClassified(t, report, ChangeSchemeRemoved, "/schemes", "", breaking, nonBreaking)
+		assertClassified(t, report, ChangeValueChanged, "", "basePath", breaking, breaking)
+		assertClassified(t, report, ChangeValueChanged, "/info", "version", nonBreaking, nonBreaking)
+	})
+
+	t.Run("request parameters", func(t *testing.T) {
+		const ptr = "/paths/~1pets/get/parameters/"
+		assertClassified(t, report, ChangeParameterChanged, ptr+"0", "required", breaking, nonBreaking)
+		assertClassified(t, report, ChangeConstraintChanged, ptr+"0", "maximum", breaking, nonBreaking)
+		assertClassified(t, report, ChangeFormatChanged, ptr+"0", "format", breaking, breaking)
+		assertClassified(t, report, ChangeParameterChanged, ptr+"1", "in", breaking, breaking)
+		assertClassified(t, report, ChangeEnumValueRemoved, ptr+"1/enum", "enum", breaking, nonBreaking)
+		assertClassified(t, report, ChangeEnumValueAdded, ptr+"1/enum", "enum", nonBreaking, breaking)
+	})
+
+	t.Run("response schemas", func(t *testing.T) {
+		const ptr = "/paths/~1pets/get/responses/200/schema/items"
+		assertClassified(t, report, ChangePropertyRemoved, ptr+"/properties/tag", "tag", breaking, nonBreaking)
+		assertClassified(t, report, ChangePropertyAdded, ptr+"/properties/owner", "owner", nonBreaking, breaking)
+		assertClassified(t, report, ChangeRequiredAdded, ptr+"/required", "age", nonBreaking, breaking)
+		assertClassified(t, report, ChangeTypeChanged, ptr+"/properties/age", "type", breaking, breaking)
+		assertClassified(t, report, ChangeConstraintChanged, ptr+"/properties/name", "maxLength", nonBreaking, breaking)
+		assertClassified(t, report, ChangeResponseAdded, "/paths/~1pets/get/responses/404", "", breaking, nonBreaking)
+	})
+
+	t.Run("shared definitions", func(t *testing.T) {
+		assertClassified(t, report, ChangePropertyRemoved, "/definitions/Pet/properties/tag", "tag", nonBreaking, nonBreaking)
+		assertClassified(t, report, ChangeDefinitionRemoved, "/definitions/Error", "", nonBreaking, nonBreaking)
+	})
+
+	t.Run("security", func(t *testing.T) {
+		assertClassified(t, report, ChangeValueChanged, "/paths/~1pets~1{id}/get/security", "security", nonBreaking, breaking)
+		assertClassified(t, report, ChangeScopeRemoved, "/securityDefinitions/oauth/scopes", "", breaking, nonBreaking)
+	})
+
+	t.Run("extensions", func(t *testing.T) {
+		assertClassified(t, report, ChangeExtensionChanged, "/x-team", "x-team", unknown, unknown)
+	})
+
+	t.Run("report should be machine-readable", func(t *testing.T) {
+		buf, err := json.Marshal(report)
+		require.NoError(t, err)
+
+		var decoded struct {
+			ClientBreaking bool `json:"clientBreaking"`
+			Changes        []struct {
+				Kind    string `json:"kind"`
+				Pointer string `json:"pointer"`
+				Client  string `json:"client"`
+				Server  string `json:"server"`
+			} `json:"changes"`
+		}
+		require.NoError(t, json.Unmarshal(buf, &decoded))
+		assert.TrueT(t, decoded.ClientBreaking)
+		require.Len(t, decoded.Changes, len(report.Changes))
+		assert.EqualT(t, string(report.Changes[0].Kind), decoded.Changes[0].Kind)
+		assert.EqualT(t, string(report.Changes[0].Client), decoded.Changes[0].Client)
+	})
+}
+
+func TestCheckCompatibility_Parameters(t *testing.T) {
+	oldSpec := mustSpec(t, `{"paths":{"/":{"get":{
+		"parameters":[{"name":"a","in":"query","type":"string","required":true}],
+		"responses":{"200":{"description":"ok"}}
+	}}}}`)
+	newSpec := mustSpec(t, `{"paths":{"/":{"get":{
+		"parameters":[
+			{"name":"b","in":"query","type":"string","required":true},
+			{"name":"c","in":"query","type":"string"}
+		],
+		"responses":{"200":{"description":"ok"}}
+	}}}}`)
+
+	report, err := CheckCompatibility(oldSpec, newSpec)
+	require.NoError(t, err)
+
+	const ptr = "/paths/~1/get/parameters/"
+	assertClassified(t, report, ChangeParameterAdded, ptr+"0", "required", CompatibilityBreaking, CompatibilityNonBreaking)
+	assertClassified(t, report, ChangeParameterAdded, ptr+"1", "", CompatibilityNonBreaking, CompatibilityBreaking)
+	assertClassified(t, report, ChangeParameterRemoved, ptr+"0", "required", CompatibilityNonBreaking, CompatibilityBreaking)
+}
+
+func TestCheckCompatibility_Security(t *testing.T) {
+	const (
+		breaking    = CompatibilityBreaking
+		nonBreaking = CompatibilityNonBreaking
+	)
+
+	operation := func(global, security string) *Swagger {
+		doc := `{"paths":{"/":{"get":{` + security + `"responses":{"200":{"description":"ok"}}}}}`
+		if global != "" {
+			doc += `,"security":` + global
+		}
+
+		return mustSpec(t, doc+`}`)
+	}
+
+	for _, tc := range []struct {
+		name           string
+		old, new       *Swagger
+		kind           ChangeKind
+		field          string
+		client, server Compatibility
+	}{
+		{
+			name: "security required on an unsecured operation",
+			old:  operation("", `"security":[],`), new: operation("", `"security":[{"k":[]}],`),
+			kind: ChangeSecurityRequirementAdded, field: "required", client: breaking, server: nonBreaking,
+		},
+		{
+			name: "security required on an operation inheriting no security",
+			old:  operation("[]", ""), new: operation("[]", `"security":[{"k":[]}],`),
+			kind: ChangeSecurityRequirementAdded, field: "required", client: breaking, server: nonBreaking,
+		},
+		{
+			name: "alternative added to inherited security",
+			old:  operation(`[{"k":[]}]`, ""), new: operation(`[{"k":[]}]`, `"security":[{"k":[]},{"basic":[]}],`),
+			kind: ChangeSecurityRequirementAdded, field: "", client: nonBreaking, server: breaking,
+		},
+		{
+			name: "inherited requirement replaced",
+			old:  operation(`[{"k":[]}]`, ""), new: operation(`[{"k":[]}]`, `"security":[{"basic":[]}],`),
+			kind: ChangeSecurityRequirementRemoved, field: "", client: breaking, server: nonBreaking,
+		},
+		{
+			name: "security disabled",
+			old:  operation("", `"security":[{"k":[]}],`), new: operation("", `"security":[],`),
+			kind: ChangeSecurityRequirementRemoved, field: "required", client: nonBreaking, server: breaking,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			report, err := CheckCompatibility(tc.old, tc.new)
+			require.NoError(t, err)
+
+			assertClassified(t, report, tc.kind, "/paths/~1/get/security", tc.field, tc.client, tc.server)
+		})
+	}
+
+	t.Run("global security changes should be reported once", func(t *testing.T) {
+		report, err := CheckCompatibility(operation(`[{"k":[]}]`, ""), operation(`[{"k":[]},{"basic":[]}]`, ""))
+		require.NoError(t, err)
+
+		require.Len(t, report.Changes, 1)
+		assertClassified(t, report, ChangeSecurityRequirementAdded, "/security", "", nonBreaking, breaking)
+	})
+
+	t.Run("inherited security should not be reported as changed", func(t *testing.T) {
+		report, err := CheckCompatibility(operation(`[{"k":[]}]`, ""), operation(`[{"k":[]}]`, `"security":[{"k":[]}],`))
+		require.NoError(t, err)
+
+		assert.Empty(t, report.Changes)
+	})
+}
+
+func TestCheckCompatibility_Identical(t *testing.T) {
+	report, err := CheckCompatibility(mustSpec(t, diffOldSpec), mustSpec(t, diffOldSpec))
+	require.NoError(t, err)
+
+	assert.FalseT(t, report.HasBreakingChanges())
+	assert.Empty(t, report.Changes)
+}
+
+func TestClassifyChange_Constraints(t *testing.T) {
+	for _, tc := range []struct {
+		field          string
+		old, new       any
+		location       ChangeLocation
+		client, server Compatibility
+	}{
+		{"maximum", 10.0, 20.0, LocationRequest, CompatibilityNonBreaking, CompatibilityBreaking},
+		{"maximum", 10.0, 5.0, LocationRequest, CompatibilityBreaking, CompatibilityNonBreaking},
+		{"minLength", int64(1), int64(2), LocationRequest, CompatibilityBreaking, CompatibilityNonBreaking},
+		{"minLength", int64(2), nil, LocationResponse, CompatibilityBreaking, CompatibilityNonBreaking},
+		{"pattern", nil, "^a", LocationRequest, CompatibilityBreaking, CompatibilityNonBreaking},
+		{"pattern", "^a", "^b", LocationRequest, CompatibilityUnknown, CompatibilityUnknown},
+		{"uniqueItems", nil, true, LocationResponse, CompatibilityNonBreaking, CompatibilityBreaking},
+		{"additionalProperties", true, false, LocationRequest, CompatibilityBreaking, CompatibilityNonBreaking},
+		{"maximum", 10.0, 20.0, LocationNone, CompatibilityUnknown, CompatibilityUnknown},
+	} {
+		c := ClassifyChange(Change{
+			Kind:     ChangeConstraintChanged,
+			Pointer:  "/paths/~1/get/parameters/0",
+			Field:    tc.field,
+			Location: tc.location,
+			Old:      tc.old,
+			New:      tc.new,
+		})
+		assert.EqualTf(t, tc.client, c.Client, "client compatibility of %v", c.Change)
+		assert.EqualTf(t, tc.server, c.Server, "server compatibility of %v", c.Change)
+	}
+}
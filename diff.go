@@ -0,0 +1,1125 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+)
+
+// ChangeKind identifies the kind of a change found when comparing two specifications.
+type ChangeKind string
+
+// Kinds of changes reported by [Diff].
+const (
+	ChangeValueChanged ChangeKind = "value-changed"
+	ChangeRefChanged   ChangeKind = "ref-changed"
+
+	ChangeSchemeAdded      ChangeKind = "scheme-added"
+	ChangeSchemeRemoved    ChangeKind = "scheme-removed"
+	ChangeMediaTypeAdded   ChangeKind = "media-type-added"
+	ChangeMediaTypeRemoved ChangeKind = "media-type-removed"
+
+	ChangePathAdded        ChangeKind = "path-added"
+	ChangePathRemoved      ChangeKind = "path-removed"
+	ChangeOperationAdded   ChangeKind = "operation-added"
+	ChangeOperationRemoved ChangeKind = "operation-removed"
+
+	ChangeParameterAdded   ChangeKind = "parameter-added"
+	ChangeParameterRemoved ChangeKind = "parameter-removed"
+	ChangeParameterChanged ChangeKind = "parameter-changed"
+
+	ChangeResponseAdded   ChangeKind = "response-added"
+	ChangeResponseRemoved ChangeKind = "response-removed"
+	ChangeHeaderAdded     ChangeKind = "header-added"
+	ChangeHeaderRemoved   ChangeKind = "header-removed"
+
+	ChangeDefinitionAdded   ChangeKind = "definition-added"
+	ChangeDefinitionRemoved ChangeKind = "definition-removed"
+	ChangeSchemaAdded       ChangeKind = "schema-added"
+	ChangeSchemaRemoved     ChangeKind = "schema-removed"
+	ChangeTypeChanged       ChangeKind = "type-changed"
+	ChangeFormatChanged     ChangeKind = "format-changed"
+	ChangePropertyAdded     ChangeKind = "property-added"
+	ChangePropertyRemoved   ChangeKind = "property-removed"
+	ChangeRequiredAdded     ChangeKind = "required-added"
+	ChangeRequiredRemoved   ChangeKind = "required-removed"
+	ChangeEnumValueAdded    ChangeKind = "enum-value-added"
+	ChangeEnumValueRemoved  ChangeKind = "enum-value-removed"
+	ChangeConstraintChanged ChangeKind = "constraint-changed"
+
+	ChangeSecurityRequirementAdded   ChangeKind = "security-requirement-added"
+	ChangeSecurityRequirementRemoved ChangeKind = "security-requirement-removed"
+	ChangeSecuritySchemeAdded        ChangeKind = "security-scheme-added"
+	ChangeSecuritySchemeRemoved      ChangeKind = "security-scheme-removed"
+	ChangeSecuritySchemeChanged      ChangeKind = "security-scheme-changed"
+	ChangeScopeAdded                 ChangeKind = "scope-added"
+	ChangeScopeRemoved               ChangeKind = "scope-removed"
+
+	ChangeExtensionAdded   ChangeKind = "extension-added"
+	ChangeExtensionRemoved ChangeKind = "extension-removed"
+	ChangeExtensionChanged ChangeKind = "extension-changed"
+)
+
+// ChangeLocation tells if a change affects a request, a response, or neither
+// (e.g. a change in the definitions section of a spec).
+type ChangeLocation string
+
+// Locations of changes.
+const (
+	LocationNone     ChangeLocation = ""
+	LocationRequest  ChangeLocation = "request"
+	LocationResponse ChangeLocation = "response"
+)
+
+// Change describes a single difference between two specifications.
+type Change struct {
+	// Kind tells what kind of change this is
+	Kind ChangeKind `json:"kind"`
+
+	// Pointer is the JSON pointer to the changed node.
+	//
+	// When a $ref has been followed, the pointer goes through the referring node.
+	// Removed nodes are located in the old specification, all others in the new one.
+	Pointer string `json:"pointer"`
+
+	// Field is the name of the changed field or constraint, when relevant (e.g. "type", "maximum", "required").
+	//
+	// For added or removed parameters, Field is "required" when the parameter is required.
+	// For added or removed security requirements, Field is "required" when security is enabled or disabled
+	// by the change, i.e. when no requirement applied before or applies after it.
+	Field string `json:"field,omitempty"`
+
+	// Location tells if this change applies to a request, a response or neither
+	Location ChangeLocation `json:"location,omitempty"`
+
+	// Old is the value in the old specification, if any
+	Old any `json:"old,omitempty"`
+
+	// New is the value in the new specification, if any
+	New any `json:"new,omitempty"`
+}
+
+// String representation of a change.
+func (c Change) String() string {
+	var b strings.Builder
+	b.WriteString(string(c.Kind))
+	if c.Field != "" {
+		b.WriteString(" [")
+		b.WriteString(c.Field)
+		b.WriteString("]")
+	}
+	b.WriteString(" at ")
+	if c.Pointer == "" {
+		b.WriteString("/")
+	} else {
+		b.WriteString(c.Pointer)
+	}
+	if c.Location != LocationNone {
+		b.WriteString(" (")
+		b.WriteString(string(c.Location))
+		b.WriteString(")")
+	}
+	if c.Old != nil || c.New != nil {
+		fmt.Fprintf(&b, ": %v -> %v", c.Old, c.New)
+	}
+
+	return b.String()
+}
+
+// Changes is a list of changes found when comparing two specifications.
+type Changes []Change
+
+// Filter returns the changes of the given kinds.
+func (c Changes) Filter(kinds ...ChangeKind) Changes {
+	var filtered Changes
+	for _, change := range c {
+		if slices.Contains(kinds, change.Kind) {
+			filtered = append(filtered, change)
+		}
+	}
+
+	return filtered
+}
+
+// DiffOptions provides options to compare two specifications.
+type DiffOptions struct {
+	// OldOptions are used to resolve $ref's in the old specification, e.g. to set its location
+	OldOptions *ExpandOptions
+
+	// NewOptions are used to resolve $ref's in the new specification
+	NewOptions *ExpandOptions
+}
+
+// Diff produces the list of structural changes between two swagger specifications.
+//
+// Specifications may be expanded or not: $ref's in paths, operations, parameters and responses are
+// followed and the resolved nodes are compared. $ref's in the definitions section are compared as is.
+//
+// Changes are reported in a deterministic order.
+func Diff(oldSpec, newSpec *Swagger) (Changes, error) {
+	return DiffWithOptions(oldSpec, newSpec, nil)
+}
+
+// DiffWithOptions produces the list of structural changes between two swagger specifications,
+// with options to resolve $ref's.
+func DiffWithOptions(oldSpec, newSpec *Swagger, options *DiffOptions) (Changes, error) {
+	if options == nil {
+		options = &DiffOptions{}
+	}
+	if oldSpec == nil {
+		oldSpec = &Swagger{}
+	}
+	if newSpec == nil {
+		newSpec = &Swagger{}
+	}
+
+	d := &differ{
+		oldResolver: newRefResolver(oldSpec, options.OldOptions),
+		newResolver: newRefResolver(newSpec, options.NewOptions),
+	}
+
+	if err := d.swagger(oldSpec, newSpec); err != nil {
+		return nil, err
+	}
+
+	return d.changes, nil
+}
+
+// differ accumulates changes while comparing two specifications.
+type differ struct {
+	changes     Changes
+	oldResolver *refResolver
+	newResolver *refResolver
+
+	// oldSecurity and newSecurity are the global security requirements, inherited by operations
+	oldSecurity []map[string][]string
+	newSecurity []map[string][]string
+}
+
+// diffNode is the context of a node being compared.
+type diffNode struct {
+	ptr        string
+	location   ChangeLocation
+	followRefs bool
+	oldBase    string
+	newBase    string
+
+	// visited holds the pairs of $ref's followed to reach this node, to protect against cycles
+	visited *visitedRef
+}
+
+type visitedRef struct {
+	key    string
+	parent *visitedRef
+}
+
+func (v *visitedRef) contains(key string) bool {
+	for ; v != nil; v = v.parent {
+		if v.key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (n diffNode) child(tokens ...string) diffNode {
+	c := n
+	c.ptr = joinPointer(n.ptr, tokens...)
+
+	return c
+}
+
+func (d *differ) add(n diffNode, kind ChangeKind, field string, oldValue, newValue any) {
+	d.changes = append(d.changes, Change{
+		Kind:     kind,
+		Pointer:  n.ptr,
+		Field:    field,
+		Location: n.location,
+		Old:      oldValue,
+		New:      newValue,
+	})
+}
+
+func (d *differ) value(n diffNode, field string, oldValue, newValue any) {
+	if !equalValues(oldValue, newValue) {
+		d.add(n, ChangeValueChanged, field, oldValue, newValue)
+	}
+}
+
+func (d *differ) swagger(o, n *Swagger) error {
+	root := diffNode{followRefs: true}
+
+	d.value(root, "swagger", o.Swagger, n.Swagger)
+	d.value(root, "id", o.ID, n.ID)
+	d.value(root, "host", o.Host, n.Host)
+	d.value(root, "basePath", o.BasePath, n.BasePath)
+	d.stringSet(root.child("schemes"), o.Schemes, n.Schemes, ChangeSchemeAdded, ChangeSchemeRemoved)
+	d.stringSet(root.child("consumes"), o.Consumes, n.Consumes, ChangeMediaTypeAdded, ChangeMediaTypeRemoved)
+	d.stringSet(root.child("produces"), o.Produces, n.Produces, ChangeMediaTypeAdded, ChangeMediaTypeRemoved)
+	d.info(root.child("info"), o.Info, n.Info)
+	d.extensions(root, o.Extensions, n.Extensions)
+	d.securityDefinitions(root.child("securityDefinitions"), o.SecurityDefinitions, n.SecurityDefinitions)
+	d.security(root.child("security"), o.Security, n.Security)
+	d.oldSecurity, d.newSecurity = o.Security, n.Security
+
+	if err := d.paths(root.child("paths"), o.Paths, n.Paths); err != nil {
+		return err
+	}
+
+	// shared definitions are compared without following $ref's,
+	// since all referred definitions are compared on their own.
+	shared := diffNode{}
+	if err := d.definitions(shared.child("definitions"), o.Definitions, n.Definitions); err != nil {
+		return err
+	}
+
+	params := shared.child("parameters")
+	params.location = LocationRequest
+	for _, key := range mapKeysUnion(o.Parameters, n.Parameters) {
+		oldParam, inOld := o.Parameters[key]
+		newParam, inNew := n.Parameters[key]
+		node := params.child(key)
+		switch {
+		case !inOld:
+			d.add(node, ChangeParameterAdded, requiredField(newParam.Required), nil, newParam.Name)
+		case !inNew:
+			d.add(node, ChangeParameterRemoved, requiredField(oldParam.Required), oldParam.Name, nil)
+		default:
+			if err := d.parameter(node, &oldParam, &newParam); err != nil {
+				return err
+			}
+		}
+	}
+
+	responses := shared.child("responses")
+	responses.location = LocationResponse
+	for _, key := range mapKeysUnion(o.Responses, n.Responses) {
+		oldResponse, inOld := o.Responses[key]
+		newResponse, inNew := n.Responses[key]
+		node := responses.child(key)
+		switch {
+		case !inOld:
+			d.add(node, ChangeResponseAdded, "", nil, key)
+		case !inNew:
+			d.add(node, ChangeResponseRemoved, "", key, nil)
+		default:
+			if err := d.response(node, &oldResponse, &newResponse); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *differ) info(n diffNode, o, nw *Info) {
+	if o == nil {
+		o = &Info{}
+	}
+	if nw == nil {
+		nw = &Info{}
+	}
+
+	d.value(n, "title", o.Title, nw.Title)
+	d.value(n, "version", o.Version, nw.Version)
+	d.extensions(n, o.Extensions, nw.Extensions)
+}
+
+func (d *differ) stringSet(n diffNode, o, nw []string, added, removed ChangeKind) {
+	for _, v := range o {
+		if !slices.Contains(nw, v) {
+			d.add(n, removed, "", v, nil)
+		}
+	}
+	for _, v := range nw {
+		if !slices.Contains(o, v) {
+			d.add(n, added, "", nil, v)
+		}
+	}
+}
+
+func (d *differ) extensions(n diffNode, o, nw Extensions) {
+	oldExt, newExt := lowerKeys(o), lowerKeys(nw)
+	for _, key := range mapKeysUnion(oldExt, newExt) {
+		oldValue, inOld := oldExt[key]
+		newValue, inNew := newExt[key]
+		node := n.child(key)
+		switch {
+		case !inOld:
+			d.add(node, ChangeExtensionAdded, key, nil, newValue)
+		case !inNew:
+			d.add(node, ChangeExtensionRemoved, key, oldValue, nil)
+		case !equalValues(oldValue, newValue):
+			d.add(node, ChangeExtensionChanged, key, oldValue, newValue)
+		}
+	}
+}
+
+func (d *differ) securityDefinitions(n diffNode, o, nw SecurityDefinitions) {
+	for _, key := range mapKeysUnion(o, nw) {
+		oldScheme, newScheme := o[key], nw[key]
+		node := n.child(key)
+		switch {
+		case oldScheme == nil:
+			d.add(node, ChangeSecuritySchemeAdded, "", nil, key)
+		case newScheme == nil:
+			d.add(node, ChangeSecuritySchemeRemoved, "", key, nil)
+		default:
+			for _, field := range []struct {
+				name       string
+				old, value string
+			}{
+				{"type", oldScheme.Type, newScheme.Type},
+				{"name", oldScheme.Name, newScheme.Name},
+				{"in", oldScheme.In, newScheme.In},
+				{"flow", oldScheme.Flow, newScheme.Flow},
+				{"authorizationUrl", oldScheme.AuthorizationURL, newScheme.AuthorizationURL},
+				{"tokenUrl", oldScheme.TokenURL, newScheme.TokenURL},
+			} {
+				if field.old != field.value {
+					d.add(node, ChangeSecuritySchemeChanged, field.name, field.old, field.value)
+				}
+			}
+
+			scopes := node.child("scopes")
+			for _, scope := range mapKeysUnion(oldScheme.Scopes, newScheme.Scopes) {
+				_, inOld := oldScheme.Scopes[scope]
+				_, inNew := newScheme.Scopes[scope]
+				switch {
+				case !inOld:
+					d.add(scopes, ChangeScopeAdded, "", nil, scope)
+				case !inNew:
+					d.add(scopes, ChangeScopeRemoved, "", scope, nil)
+				}
+			}
+
+			d.extensions(node, oldScheme.Extensions, newScheme.Extensions)
+		}
+	}
+}
+
+// security compares security requirements.
+//
+// Changes between a nil (inherited) requirement and an empty one (no security) are reported as a changed value.
+// Nil requirements are then resolved against the global ones and the effective requirements are compared as sets.
+// Requirements inherited on both sides are not compared: changes to the global requirements are reported once,
+// at "/security", rather than on every operation inheriting them.
+//
+// Requirements added to an empty set, or removed from the set leaving it empty, are reported with
+// the "required" field: they enable or disable security rather than add or remove an alternative.
+func (d *differ) security(n diffNode, o, nw []map[string][]string) {
+	n.location = LocationRequest
+	if (o == nil && nw != nil && len(nw) == 0) || (nw == nil && o != nil && len(o) == 0) {
+		d.add(n, ChangeValueChanged, "security", securityRequirementsString(o), securityRequirementsString(nw))
+	}
+	if o == nil && nw == nil {
+		return
+	}
+
+	if o == nil {
+		o = d.oldSecurity
+	}
+	if nw == nil {
+		nw = d.newSecurity
+	}
+
+	oldReqs := make([]string, 0, len(o))
+	for _, req := range o {
+		oldReqs = append(oldReqs, securityRequirementString(req))
+	}
+	newReqs := make([]string, 0, len(nw))
+	for _, req := range nw {
+		newReqs = append(newReqs, securityRequirementString(req))
+	}
+
+	for _, req := range oldReqs {
+		if !slices.Contains(newReqs, req) {
+			d.add(n, ChangeSecurityRequirementRemoved, requiredField(len(newReqs) == 0), req, nil)
+		}
+	}
+	for _, req := range newReqs {
+		if !slices.Contains(oldReqs, req) {
+			d.add(n, ChangeSecurityRequirementAdded, requiredField(len(oldReqs) == 0), nil, req)
+		}
+	}
+}
+
+// securityRequirementString renders a security requirement in a canonical form, e.g. "api_key && oauth[read,write]".
+func securityRequirementString(req map[string][]string) string {
+	names := mapKeysSorted(req)
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		scopes := slices.Clone(req[name])
+		sort.Strings(scopes)
+		if len(scopes) == 0 {
+			parts = append(parts, name)
+
+			continue
+		}
+		parts = append(parts, name+"["+strings.Join(scopes, ",")+"]")
+	}
+
+	return strings.Join(parts, " && ")
+}
+
+func securityRequirementsString(reqs []map[string][]string) any {
+	if reqs == nil {
+		return nil
+	}
+
+	rendered := make([]string, 0, len(reqs))
+	for _, req := range reqs {
+		rendered = append(rendered, securityRequirementString(req))
+	}
+
+	return rendered
+}
+
+func (d *differ) paths(n diffNode, o, nw *Paths) error {
+	if o == nil {
+		o = &Paths{}
+	}
+	if nw == nil {
+		nw = &Paths{}
+	}
+
+	d.extensions(n, o.Extensions, nw.Extensions)
+
+	for _, key := range mapKeysUnion(o.Paths, nw.Paths) {
+		oldItem, inOld := o.Paths[key]
+		newItem, inNew := nw.Paths[key]
+		node := n.child(key)
+		switch {
+		case !inOld:
+			d.add(node, ChangePathAdded, "", nil, key)
+		case !inNew:
+			d.add(node, ChangePathRemoved, "", key, nil)
+		default:
+			if err := d.pathItem(node, &oldItem, &newItem); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *differ) pathItem(n diffNode, o, nw *PathItem) error {
+	o, nw, n, done, err := followRefs(d, n, o, nw, func(p *PathItem) Ref { return p.Ref })
+	if err != nil || done {
+		return err
+	}
+
+	d.extensions(n, o.Extensions, nw.Extensions)
+
+	if err := d.parameters(n.child("parameters"), o.Parameters, nw.Parameters); err != nil {
+		return err
+	}
+
+	for _, method := range pathItemMethods {
+		oldOp, newOp := *o.operationField(method), *nw.operationField(method)
+		node := n.child(method)
+		switch {
+		case oldOp == nil && newOp == nil:
+			continue
+		case oldOp == nil:
+			d.add(node, ChangeOperationAdded, "", nil, strings.ToUpper(method))
+		case newOp == nil:
+			d.add(node, ChangeOperationRemoved, "", strings.ToUpper(method), nil)
+		default:
+			if err := d.operation(node, oldOp, newOp); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *differ) operation(n diffNode, o, nw *Operation) error {
+	d.value(n, "operationId", o.ID, nw.ID)
+	d.value(n, "deprecated", o.Deprecated, nw.Deprecated)
+	d.stringSet(n.child("consumes"), o.Consumes, nw.Consumes, ChangeMediaTypeAdded, ChangeMediaTypeRemoved)
+	d.stringSet(n.child("produces"), o.Produces, nw.Produces, ChangeMediaTypeAdded, ChangeMediaTypeRemoved)
+	d.stringSet(n.child("schemes"), o.Schemes, nw.Schemes, ChangeSchemeAdded, ChangeSchemeRemoved)
+	d.security(n.child("security"), o.Security, nw.Security)
+	d.extensions(n, o.Extensions, nw.Extensions)
+
+	if err := d.parameters(n.child("parameters"), o.Parameters, nw.Parameters); err != nil {
+		return err
+	}
+
+	return d.responses(n.child("responses"), o.Responses, nw.Responses)
+}
+
+// parameters compares lists of parameters.
+//
+// Parameters are matched by name and location. Parameters with the same name which moved to another location
+// are reported as changed. Parameters are reported with their index in the new (or old, if removed) list.
+func (d *differ) parameters(n diffNode, o, nw []Parameter) error {
+	n.location = LocationRequest
+
+	oldParams, err := d.resolveParameters(o, n.oldBase, d.oldResolver)
+	if err != nil {
+		return err
+	}
+	newParams, err := d.resolveParameters(nw, n.newBase, d.newResolver)
+	if err != nil {
+		return err
+	}
+
+	matchedOld := make([]bool, len(oldParams))
+	matchedNew := make([]bool, len(newParams))
+	type pair struct{ oldIndex, newIndex int }
+	pairs := make([]pair, 0, len(newParams))
+
+	matchParams := func(match func(a, b *Parameter) bool) {
+		for j := range newParams {
+			if matchedNew[j] {
+				continue
+			}
+			for i := range oldParams {
+				if matchedOld[i] || !match(&oldParams[i].Parameter, &newParams[j].Parameter) {
+					continue
+				}
+				matchedOld[i], matchedNew[j] = true, true
+				pairs = append(pairs, pair{oldIndex: i, newIndex: j})
+
+				break
+			}
+		}
+	}
+	matchParams(func(a, b *Parameter) bool { return a.Name == b.Name && a.In == b.In })
+	matchParams(func(a, b *Parameter) bool { return a.Name == b.Name })
+
+	for i, param := range oldParams {
+		if !matchedOld[i] {
+			d.add(n.child(strconv.Itoa(i)), ChangeParameterRemoved, requiredField(param.Required), param.Name, nil)
+		}
+	}
+
+	sort.Slice(pairs, func(a, b int) bool { return pairs[a].newIndex < pairs[b].newIndex })
+	for _, p := range pairs {
+		node := n.child(strconv.Itoa(p.newIndex))
+		node.oldBase, node.newBase = oldParams[p.oldIndex].base, newParams[p.newIndex].base
+		if err := d.parameter(node, &oldParams[p.oldIndex].Parameter, &newParams[p.newIndex].Parameter); err != nil {
+			return err
+		}
+	}
+
+	for j, param := range newParams {
+		if !matchedNew[j] {
+			d.add(n.child(strconv.Itoa(j)), ChangeParameterAdded, requiredField(param.Required), nil, param.Name)
+		}
+	}
+
+	return nil
+}
+
+// requiredField is the field reported for an added or removed parameter or security requirement.
+func requiredField(required bool) string {
+	if required {
+		return "required"
+	}
+
+	return ""
+}
+
+type resolvedParameter struct {
+	Parameter
+
+	base string
+}
+
+// resolveParameters resolves $ref's in a list of parameters, so they can be matched by name and location.
+func (d *differ) resolveParameters(params []Parameter, base string, resolver *refResolver) ([]resolvedParameter, error) {
+	resolved := make([]resolvedParameter, 0, len(params))
+	for _, param := range params {
+		current := resolvedParameter{Parameter: param, base: base}
+		for seen := map[string]bool{}; current.Ref.String() != ""; {
+			key := resolver.key(current.Ref, current.base)
+			if seen[key] {
+				break
+			}
+			seen[key] = true
+
+			var target Parameter
+			targetBase, err := resolver.resolve(current.Ref, current.base, &target)
+			if err != nil {
+				return nil, err
+			}
+			current = resolvedParameter{Parameter: target, base: targetBase}
+		}
+		resolved = append(resolved, current)
+	}
+
+	return resolved, nil
+}
+
+func (d *differ) parameter(n diffNode, o, nw *Parameter) error {
+	o, nw, n, done, err := followRefs(d, n, o, nw, func(p *Parameter) Ref { return p.Ref })
+	if err != nil || done {
+		return err
+	}
+
+	if o.In != nw.In {
+		d.add(n, ChangeParameterChanged, "in", o.In, nw.In)
+	}
+	if o.Required != nw.Required {
+		d.add(n, ChangeParameterChanged, "required", o.Required, nw.Required)
+	}
+	if o.AllowEmptyValue != nw.AllowEmptyValue {
+		d.add(n, ChangeParameterChanged, "allowEmptyValue", o.AllowEmptyValue, nw.AllowEmptyValue)
+	}
+	d.extensions(n, o.Extensions, nw.Extensions)
+
+	if o.In == "body" || nw.In == "body" {
+		return d.schema(n.child("schema"), o.Schema, nw.Schema)
+	}
+
+	d.simpleSchema(n, o.SimpleSchema, nw.SimpleSchema, o.CommonValidations, nw.CommonValidations)
+
+	return nil
+}
+
+func (d *differ) simpleSchema(n diffNode, o, nw SimpleSchema, oldValidations, newValidations CommonValidations) {
+	if o.Type != nw.Type {
+		d.add(n, ChangeTypeChanged, "type", o.Type, nw.Type)
+	}
+	if o.Format != nw.Format {
+		d.add(n, ChangeFormatChanged, "format", o.Format, nw.Format)
+	}
+	if o.CollectionFormat != nw.CollectionFormat {
+		d.add(n, ChangeParameterChanged, "collectionFormat", o.CollectionFormat, nw.CollectionFormat)
+	}
+	if o.Nullable != nw.Nullable {
+		d.add(n, ChangeValueChanged, "nullable", o.Nullable, nw.Nullable)
+	}
+	d.value(n, "default", o.Default, nw.Default)
+	d.validations(n, oldValidations, newValidations)
+
+	switch {
+	case o.Items == nil && nw.Items == nil:
+	case o.Items == nil:
+		d.add(n.child("items"), ChangeSchemaAdded, "items", nil, nw.Items.Type)
+	case nw.Items == nil:
+		d.add(n.child("items"), ChangeSchemaRemoved, "items", o.Items.Type, nil)
+	default:
+		d.simpleSchema(n.child("items"), o.Items.SimpleSchema, nw.Items.SimpleSchema, o.Items.CommonValidations, nw.Items.CommonValidations)
+	}
+}
+
+// validations compares validation constraints and enums.
+func (d *differ) validations(n diffNode, o, nw CommonValidations) {
+	d.constraint(n, "maximum", o.Maximum, nw.Maximum)
+	d.constraint(n, "exclusiveMaximum", o.ExclusiveMaximum, nw.ExclusiveMaximum)
+	d.constraint(n, "minimum", o.Minimum, nw.Minimum)
+	d.constraint(n, "exclusiveMinimum", o.ExclusiveMinimum, nw.ExclusiveMinimum)
+	d.constraint(n, "maxLength", o.MaxLength, nw.MaxLength)
+	d.constraint(n, "minLength", o.MinLength, nw.MinLength)
+	d.constraint(n, "pattern", o.Pattern, nw.Pattern)
+	d.constraint(n, "maxItems", o.MaxItems, nw.MaxItems)
+	d.constraint(n, "minItems", o.MinItems, nw.MinItems)
+	d.constraint(n, "uniqueItems", o.UniqueItems, nw.UniqueItems)
+	d.constraint(n, "multipleOf", o.MultipleOf, nw.MultipleOf)
+	d.enum(n, o.Enum, nw.Enum)
+}
+
+// constraint reports a changed validation constraint. Unset constraints are reported as nil values.
+func (d *differ) constraint(n diffNode, field string, o, nw any) {
+	o, nw = constraintValue(o), constraintValue(nw)
+	if !equalValues(o, nw) {
+		d.add(n, ChangeConstraintChanged, field, o, nw)
+	}
+}
+
+func constraintValue(v any) any {
+	switch tv := v.(type) {
+	case *float64:
+		if tv == nil {
+			return nil
+		}
+
+		return *tv
+	case *int64:
+		if tv == nil {
+			return nil
+		}
+
+		return *tv
+	case bool:
+		if !tv {
+			return nil
+		}
+
+		return tv
+	case string:
+		if tv == "" {
+			return nil
+		}
+
+		return tv
+	default:
+		return tv
+	}
+}
+
+func (d *differ) enum(n diffNode, o, nw []any) {
+	for _, v := range o {
+		if !slices.ContainsFunc(nw, func(e any) bool { return equalValues(v, e) }) {
+			d.add(n.child("enum"), ChangeEnumValueRemoved, "enum", v, nil)
+		}
+	}
+	for _, v := range nw {
+		if !slices.ContainsFunc(o, func(e any) bool { return equalValues(v, e) }) {
+			d.add(n.child("enum"), ChangeEnumValueAdded, "enum", nil, v)
+		}
+	}
+}
+
+func (d *differ) responses(n diffNode, o, nw *Responses) error {
+	n.location = LocationResponse
+	if o == nil {
+		o = &Responses{}
+	}
+	if nw == nil {
+		nw = &Responses{}
+	}
+
+	d.extensions(n, o.Extensions, nw.Extensions)
+
+	node := n.child("default")
+	switch {
+	case o.Default == nil && nw.Default == nil:
+	case o.Default == nil:
+		d.add(node, ChangeResponseAdded, "", nil, "default")
+	case nw.Default == nil:
+		d.add(node, ChangeResponseRemoved, "", "default", nil)
+	default:
+		if err := d.response(node, o.Default, nw.Default); err != nil {
+			return err
+		}
+	}
+
+	codes := mapKeysUnion(o.StatusCodeResponses, nw.StatusCodeResponses)
+	for _, code := range codes {
+		oldResponse, inOld := o.StatusCodeResponses[code]
+		newResponse, inNew := nw.StatusCodeResponses[code]
+		node := n.child(strconv.Itoa(code))
+		switch {
+		case !inOld:
+			d.add(node, ChangeResponseAdded, "", nil, code)
+		case !inNew:
+			d.add(node, ChangeResponseRemoved, "", code, nil)
+		default:
+			if err := d.response(node, &oldResponse, &newResponse); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, rng := range mapKeysUnion(o.StatusCodeRanges, nw.StatusCodeRanges) {
+		oldResponse, inOld := o.StatusCodeRanges[rng]
+		newResponse, inNew := nw.StatusCodeRanges[rng]
+		node := n.child(rng)
+		switch {
+		case !inOld:
+			d.add(node, ChangeResponseAdded, "", nil, rng)
+		case !inNew:
+			d.add(node, ChangeResponseRemoved, "", rng, nil)
+		default:
+			if err := d.response(node, &oldResponse, &newResponse); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *differ) response(n diffNode, o, nw *Response) error {
+	o, nw, n, done, err := followRefs(d, n, o, nw, func(r *Response) Ref { return r.Ref })
+	if err != nil || done {
+		return err
+	}
+
+	d.extensions(n, o.Extensions, nw.Extensions)
+
+	headers := n.child("headers")
+	for _, name := range mapKeysUnion(o.Headers, nw.Headers) {
+		oldHeader, inOld := o.Headers[name]
+		newHeader, inNew := nw.Headers[name]
+		node := headers.child(name)
+		switch {
+		case !inOld:
+			d.add(node, ChangeHeaderAdded, "", nil, name)
+		case !inNew:
+			d.add(node, ChangeHeaderRemoved, "", name, nil)
+		default:
+			d.simpleSchema(node, oldHeader.SimpleSchema, newHeader.SimpleSchema, oldHeader.CommonValidations, newHeader.CommonValidations)
+			d.extensions(node, oldHeader.Extensions, newHeader.Extensions)
+		}
+	}
+
+	return d.schema(n.child("schema"), o.Schema, nw.Schema)
+}
+
+func (d *differ) definitions(n diffNode, o, nw Definitions) error {
+	for _, key := range mapKeysUnion(o, nw) {
+		oldSchema, inOld := o[key]
+		newSchema, inNew := nw[key]
+		node := n.child(key)
+		switch {
+		case !inOld:
+			d.add(node, ChangeDefinitionAdded, "", nil, key)
+		case !inNew:
+			d.add(node, ChangeDefinitionRemoved, "", key, nil)
+		default:
+			if err := d.schema(node, &oldSchema, &newSchema); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *differ) schema(n diffNode, o, nw *Schema) error {
+	switch {
+	case o == nil && nw == nil:
+		return nil
+	case o == nil:
+		d.add(n, ChangeSchemaAdded, "", nil, schemaSummary(nw))
+
+		return nil
+	case nw == nil:
+		d.add(n, ChangeSchemaRemoved, "", schemaSummary(o), nil)
+
+		return nil
+	}
+
+	o, nw, n, done, err := followRefs(d, n, o, nw, func(s *Schema) Ref { return s.Ref })
+	if err != nil || done {
+		return err
+	}
+
+	if !equalStringSets(o.Type, nw.Type) {
+		d.add(n, ChangeTypeChanged, "type", []string(o.Type), []string(nw.Type))
+	}
+	if o.Format != nw.Format {
+		d.add(n, ChangeFormatChanged, "format", o.Format, nw.Format)
+	}
+	d.value(n, "nullable", o.Nullable || isExtensionTrue(o.Extensions, "x-nullable"), nw.Nullable || isExtensionTrue(nw.Extensions, "x-nullable"))
+	d.value(n, "readOnly", o.ReadOnly, nw.ReadOnly)
+	d.value(n, "discriminator", o.Discriminator, nw.Discriminator)
+	d.value(n, "default", o.Default, nw.Default)
+	d.validations(n, o.Validations().CommonValidations, nw.Validations().CommonValidations)
+	d.constraint(n, "maxProperties", o.MaxProperties, nw.MaxProperties)
+	d.constraint(n, "minProperties", o.MinProperties, nw.MinProperties)
+	d.extensions(n, o.Extensions, nw.Extensions)
+
+	for _, name := range o.Required {
+		if !slices.Contains(nw.Required, name) {
+			d.add(n.child("required"), ChangeRequiredRemoved, name, name, nil)
+		}
+	}
+	for _, name := range nw.Required {
+		if !slices.Contains(o.Required, name) {
+			d.add(n.child("required"), ChangeRequiredAdded, name, nil, name)
+		}
+	}
+
+	if err := d.properties(n, o, nw); err != nil {
+		return err
+	}
+
+	return d.subSchemas(n, o, nw)
+}
+
+func (d *differ) properties(n diffNode, o, nw *Schema) error {
+	properties := n.child("properties")
+	for _, name := range mapKeysUnion(o.Properties, nw.Properties) {
+		oldProp, inOld := o.Properties[name]
+		newProp, inNew := nw.Properties[name]
+		node := properties.child(name)
+		switch {
+		case !inOld:
+			d.add(node, ChangePropertyAdded, name, nil, schemaSummary(&newProp))
+		case !inNew:
+			d.add(node, ChangePropertyRemoved, name, schemaSummary(&oldProp), nil)
+		default:
+			if err := d.schema(node, &oldProp, &newProp); err != nil {
+				return err
+			}
+		}
+	}
+
+	patternProperties := n.child("patternProperties")
+	for _, pattern := range mapKeysUnion(o.PatternProperties, nw.PatternProperties) {
+		oldProp, inOld := o.PatternProperties[pattern]
+		newProp, inNew := nw.PatternProperties[pattern]
+		var oldPtr, newPtr *Schema
+		if inOld {
+			oldPtr = &oldProp
+		}
+		if inNew {
+			newPtr = &newProp
+		}
+		if err := d.schema(patternProperties.child(pattern), oldPtr, newPtr); err != nil {
+			return err
+		}
+	}
+
+	oldAllows, oldAdditional := o.AdditionalProperties.normalized()
+	newAllows, newAdditional := nw.AdditionalProperties.normalized()
+	if oldAllows != newAllows {
+		d.add(n, ChangeConstraintChanged, "additionalProperties", oldAllows, newAllows)
+	}
+
+	return d.schema(n.child("additionalProperties"), oldAdditional, newAdditional)
+}
+
+func (d *differ) subSchemas(n diffNode, o, nw *Schema) error {
+	oldItems, newItems := o.Items, nw.Items
+	if oldItems == nil {
+		oldItems = &SchemaOrArray{}
+	}
+	if newItems == nil {
+		newItems = &SchemaOrArray{}
+	}
+	if err := d.schema(n.child("items"), oldItems.Schema, newItems.Schema); err != nil {
+		return err
+	}
+	if err := d.schemaList(n.child("items"), oldItems.Schemas, newItems.Schemas); err != nil {
+		return err
+	}
+
+	if err := d.schemaList(n.child("allOf"), o.AllOf, nw.AllOf); err != nil {
+		return err
+	}
+	if err := d.schemaList(n.child("anyOf"), o.AnyOf, nw.AnyOf); err != nil {
+		return err
+	}
+	if err := d.schemaList(n.child("oneOf"), o.OneOf, nw.OneOf); err != nil {
+		return err
+	}
+
+	return d.schema(n.child("not"), o.Not, nw.Not)
+}
+
+func (d *differ) schemaList(n diffNode, o, nw []Schema) error {
+	for i := range max(len(o), len(nw)) {
+		var oldSchema, newSchema *Schema
+		if i < len(o) {
+			oldSchema = &o[i]
+		}
+		if i < len(nw) {
+			newSchema = &nw[i]
+		}
+		if err := d.schema(n.child(strconv.Itoa(i)), oldSchema, newSchema); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// followRefs resolves the $ref's on both sides of a comparison, when the context allows it.
+//
+// When $ref's are not followed, only the $ref's themselves are compared.
+// It reports done when nothing else needs to be compared, e.g. when a cycle has been detected.
+func followRefs[T any, PT interface{ *T }](
+	d *differ, n diffNode, o, nw PT, refOf func(PT) Ref,
+) (PT, PT, diffNode, bool, error) {
+	oldRef, newRef := refOf(o), refOf(nw)
+	if oldRef.String() == "" && newRef.String() == "" {
+		return o, nw, n, false, nil
+	}
+
+	if !n.followRefs {
+		if oldRef.String() != newRef.String() {
+			d.add(n, ChangeRefChanged, jsonRef, oldRef.String(), newRef.String())
+
+			return o, nw, n, true, nil
+		}
+
+		return o, nw, n, oldRef.String() != "", nil
+	}
+
+	var oldKey, newKey string
+	if oldRef.String() != "" {
+		oldKey = d.oldResolver.key(oldRef, n.oldBase)
+	}
+	if newRef.String() != "" {
+		newKey = d.newResolver.key(newRef, n.newBase)
+	}
+	visitKey := oldKey + " " + newKey
+	if n.visited.contains(visitKey) {
+		// cycle: this pair of nodes is already being compared
+		return o, nw, n, true, nil
+	}
+	n.visited = &visitedRef{key: visitKey, parent: n.visited}
+
+	var err error
+	if oldRef.String() != "" {
+		target := PT(new(T))
+		if n.oldBase, err = d.oldResolver.resolve(oldRef, n.oldBase, target); err != nil {
+			return o, nw, n, true, fmt.Errorf("resolving %s in old spec at %s: %w", oldRef.String(), n.ptr, err)
+		}
+		o = target
+	}
+	if newRef.String() != "" {
+		target := PT(new(T))
+		if n.newBase, err = d.newResolver.resolve(newRef, n.newBase, target); err != nil {
+			return o, nw, n, true, fmt.Errorf("resolving %s in new spec at %s: %w", newRef.String(), n.ptr, err)
+		}
+		nw = target
+	}
+
+	// resolved targets may hold $ref's themselves
+	return followRefs(d, n, o, nw, refOf)
+}
+
+// schemaSummary describes a schema in a short form, e.g. to report an added or removed property.
+func schemaSummary(s *Schema) any {
+	if s == nil {
+		return nil
+	}
+	if s.Ref.String() != "" {
+		return s.Ref.String()
+	}
+	if len(s.Type) == 1 {
+		return s.Type[0]
+	}
+	if len(s.Type) > 1 {
+		return []string(s.Type)
+	}
+
+	return "schema"
+}
+
+func isExtensionTrue(ext Extensions, key string) bool {
+	v, ok := ext.GetBool(key)
+
+	return ok && v
+}
+
+// joinPointer appends tokens to a JSON pointer, escaping them.
+func joinPointer(ptr string, tokens ...string) string {
+	var b strings.Builder
+	b.WriteString(ptr)
+	for _, token := range tokens {
+		b.WriteByte('/')
+		b.WriteString(jsonpointer.Escape(token))
+	}
+
+	return b.String()
+}
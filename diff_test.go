@@ -0,0 +1,327 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+const diffOldSpec = `{
+  "swagger": "2.0",
+  "info": { "title": "pets", "version": "1.0" },
+  "host": "api.example.com",
+  "basePath": "/v1",
+  "schemes": ["http", "https"],
+  "x-team": "pets",
+  "securityDefinitions": {
+    "oauth": { "type": "oauth2", "flow": "implicit", "authorizationUrl": "http://auth", "scopes": { "read": "", "write": "" } }
+  },
+  "security": [ { "oauth": ["read"] } ],
+  "paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        "parameters": [
+          { "name": "limit", "in": "query", "type": "integer", "format": "int32", "maximum": 100 },
+          { "name": "tag", "in": "query", "type": "string", "enum": ["dog", "cat"] }
+        ],
+        "responses": {
+          "200": { "description": "ok", "schema": { "type": "array", "items": { "$ref": "#/definitions/Pet" } } },
+          "default": { "description": "error" }
+        }
+      },
+      "post": {
+        "parameters": [ { "name": "body", "in": "body", "schema": { "$ref": "#/definitions/Pet" } } ],
+        "responses": { "201": { "description": "created" } }
+      }
+    },
+    "/pets/{id}": {
+      "get": {
+        "parameters": [ { "name": "id", "in": "path", "required": true, "type": "string" } ],
+        "responses": { "200": { "description": "ok" } }
+      }
+    }
+  },
+  "definitions": {
+    "Pet": {
+      "type": "object",
+      "required": ["name"],
+      "properties": {
+        "name": { "type": "string", "maxLength": 50 },
+        "tag": { "type": "string" },
+        "age": { "type": "integer" }
+      }
+    },
+    "Error": { "type": "object" }
+  }
+}`
+
+const diffNewSpec = `{
+  "swagger": "2.0",
+  "info": { "title": "pets", "version": "2.0" },
+  "host": "api.example.com",
+  "basePath": "/v2",
+  "schemes": ["https"],
+  "x-team": "animals",
+  "securityDefinitions": {
+    "oauth": { "type": "oauth2", "flow": "implicit", "authorizationUrl": "http://auth", "scopes": { "read": "" } }
+  },
+  "security": [ { "oauth": ["read"] } ],
+  "paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        "parameters": [
+          { "name": "limit", "in": "query", "type": "integer", "format": "int64", "maximum": 50, "required": true },
+          { "name": "tag", "in": "header", "type": "string", "enum": ["dog", "bird"] }
+        ],
+        "responses": {
+          "200": { "description": "ok", "schema": { "type": "array", "items": { "$ref": "#/definitions/Pet" } } },
+          "404": { "description": "not found" }
+        }
+      }
+    },
+    "/owners": {
+      "get": { "responses": { "200": { "description": "ok" } } }
+    },
+    "/pets/{id}": {
+      "get": {
+        "security": [],
+        "parameters": [ { "name": "id", "in": "path", "required": true, "type": "string" } ],
+        "responses": { "200": { "description": "ok" } }
+      }
+    }
+  },
+  "definitions": {
+    "Pet": {
+      "type": "object",
+      "required": ["name", "age"],
+      "properties": {
+        "name": { "type": "string", "maxLength": 40 },
+        "age": { "type": "number" },
+        "owner": { "type": "string" }
+      }
+    },
+    "Owner": { "type": "object" }
+  }
+}`
+
+func mustSpec(t testing.TB, doc string) *Swagger {
+	t.Helper()
+
+	sp := new(Swagger)
+	require.NoError(t, json.Unmarshal([]byte(doc), sp))
+
+	return sp
+}
+
+func findChange(changes Changes, kind ChangeKind, ptr, field string) (Change, bool) {
+	for _, c := range changes {
+		if c.Kind == kind && c.Pointer == ptr && c.Field == field {
+			return c, true
+		}
+	}
+
+	return Change{}, false
+}
+
+func assertChange(t testing.TB, changes Changes, kind ChangeKind, ptr, field string) Change {
+	t.Helper()
+
+	c, ok := findChange(changes, kind, ptr, field)
+	assert.TrueTf(t, ok, "expected change %s at %s [%s] in:\n%v", kind, ptr, field, changes)
+
+	return c
+}
+
+func TestDiff(t *testing.T) {
+	oldSpec, newSpec := mustSpec(t, diffOldSpec), mustSpec(t, diffNewSpec)
+
+	changes, err := Diff(oldSpec, newSpec)
+	require.NoError(t, err)
+
+	t.Run("top-level changes", func(t *testing.T) {
+		c := assertChange(t, changes, ChangeValueChanged, "", "basePath")
+		assert.Equal(t, "/v1", c.Old)
+		assert.Equal(t, "/v2", c.New)
+		assertChange(t, changes, ChangeValueChanged, "/info", "version")
+		assertChange(t, changes, ChangeSchemeRemoved, "/schemes", "")
+		assertChange(t, changes, ChangeExtensionChanged, "/x-team", "x-team")
+		assertChange(t, changes, ChangeScopeRemoved, "/securityDefinitions/oauth/scopes", "")
+	})
+
+	t.Run("paths and operations", func(t *testing.T) {
+		assertChange(t, changes, ChangePathAdded, "/paths/~1owners", "")
+		assertChange(t, changes, ChangeOperationRemoved, "/paths/~1pets/post", "")
+		_, found := findChange(changes, ChangePathRemoved, "/paths/~1pets~1{id}", "")
+		assert.FalseT(t, found)
+	})
+
+	t.Run("parameters", func(t *testing.T) {
+		assertChange(t, changes, ChangeFormatChanged, "/paths/~1pets/get/parameters/0", "format")
+		assertChange(t, changes, ChangeParameterChanged, "/paths/~1pets/get/parameters/0", "required")
+		c := assertChange(t, changes, ChangeConstraintChanged, "/paths/~1pets/get/parameters/0", "maximum")
+		assert.Equal(t, 100.0, c.Old)
+		assert.Equal(t, 50.0, c.New)
+		assert.Equal(t, LocationRequest, c.Location)
+
+		c = assertChange(t, changes, ChangeParameterChanged, "/paths/~1pets/get/parameters/1", "in")
+		assert.Equal(t, "query", c.Old)
+		assert.Equal(t, "header", c.New)
+		c = assertChange(t, changes, ChangeEnumValueRemoved, "/paths/~1pets/get/parameters/1/enum", "enum")
+		assert.Equal(t, "cat", c.Old)
+		c = assertChange(t, changes, ChangeEnumValueAdded, "/paths/~1pets/get/parameters/1/enum", "enum")
+		assert.Equal(t, "bird", c.New)
+	})
+
+	t.Run("responses", func(t *testing.T) {
+		assertChange(t, changes, ChangeResponseRemoved, "/paths/~1pets/get/responses/default", "")
+		c := assertChange(t, changes, ChangeResponseAdded, "/paths/~1pets/get/responses/404", "")
+		assert.Equal(t, LocationResponse, c.Location)
+	})
+
+	t.Run("schemas should be compared through $ref", func(t *testing.T) {
+		const ptr = "/paths/~1pets/get/responses/200/schema/items"
+		c := assertChange(t, changes, ChangePropertyRemoved, ptr+"/properties/tag", "tag")
+		assert.Equal(t, LocationResponse, c.Location)
+		assertChange(t, changes, ChangePropertyAdded, ptr+"/properties/owner", "owner")
+		assertChange(t, changes, ChangeRequiredAdded, ptr+"/required", "age")
+		c = assertChange(t, changes, ChangeTypeChanged, ptr+"/properties/age", "type")
+		assert.Equal(t, []string{"integer"}, c.Old)
+		assert.Equal(t, []string{"number"}, c.New)
+		assertChange(t, changes, ChangeConstraintChanged, ptr+"/properties/name", "maxLength")
+	})
+
+	t.Run("definitions", func(t *testing.T) {
+		assertChange(t, changes, ChangeDefinitionAdded, "/definitions/Owner", "")
+		assertChange(t, changes, ChangeDefinitionRemoved, "/definitions/Error", "")
+		c := assertChange(t, changes, ChangePropertyRemoved, "/definitions/Pet/properties/tag", "tag")
+		assert.Equal(t, LocationNone, c.Location)
+	})
+
+	t.Run("security", func(t *testing.T) {
+		c := assertChange(t, changes, ChangeValueChanged, "/paths/~1pets~1{id}/get/security", "security")
+		assert.Nil(t, c.Old)
+		assert.Equal(t, []string{}, c.New)
+	})
+
+	t.Run("diff should be deterministic", func(t *testing.T) {
+		again, err := Diff(oldSpec, newSpec)
+		require.NoError(t, err)
+		assert.Equal(t, changes, again)
+	})
+
+	t.Run("changes should render as strings", func(t *testing.T) {
+		c := assertChange(t, changes, ChangeConstraintChanged, "/paths/~1pets/get/parameters/0", "maximum")
+		assert.EqualT(t, "constraint-changed [maximum] at /paths/~1pets/get/parameters/0 (request): 100 -> 50", c.String())
+	})
+}
+
+func TestDiff_Identical(t *testing.T) {
+	sp := mustSpec(t, diffOldSpec)
+
+	changes, err := Diff(sp, sp.DeepCopy())
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	changes, err = Diff(nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestDiff_ExpandedVersusUnexpanded(t *testing.T) {
+	unexpanded := mustSpec(t, diffOldSpec)
+	expanded := unexpanded.DeepCopy()
+	require.NoError(t, ExpandSpec(expanded, nil))
+
+	changes, err := Diff(unexpanded, expanded)
+	require.NoError(t, err)
+
+	// the only differences are in the definitions section, where $ref's are not followed
+	for _, c := range changes {
+		assert.Equal(t, ChangeRefChanged, c.Kind)
+	}
+	assert.Empty(t, changes.Filter(ChangePropertyAdded, ChangePropertyRemoved, ChangeTypeChanged))
+}
+
+func TestDiff_CircularRefs(t *testing.T) {
+	const doc = `{
+  "swagger": "2.0",
+  "info": { "title": "nodes", "version": "1.0" },
+  "paths": {
+    "/nodes": {
+      "get": {
+        "responses": { "200": { "description": "ok", "schema": { "$ref": "#/definitions/Node" } } }
+      }
+    }
+  },
+  "definitions": {
+    "Node": {
+      "type": "object",
+      "properties": {
+        "value": { "type": "string" },
+        "children": { "type": "array", "items": { "$ref": "#/definitions/Node" } }
+      }
+    }
+  }
+}`
+
+	oldSpec := mustSpec(t, doc)
+	newSpec := oldSpec.DeepCopy()
+	node := newSpec.Definitions["Node"]
+	node.Properties["value"] = *Int64Property()
+	newSpec.Definitions["Node"] = node
+
+	changes, err := Diff(oldSpec, newSpec)
+	require.NoError(t, err)
+
+	assertChange(t, changes, ChangeTypeChanged, "/paths/~1nodes/get/responses/200/schema/properties/value", "type")
+	assertChange(t, changes, ChangeTypeChanged, "/definitions/Node/properties/value", "type")
+	assert.Len(t, changes.Filter(ChangeTypeChanged), 2)
+}
+
+func TestDiff_RemoteRefs(t *testing.T) {
+	path := filepath.Join("fixtures", "expansion", "crossFileRef.json")
+	doc, err := fixtureAssets.ReadFile(path)
+	require.NoError(t, err)
+
+	oldSpec := mustSpec(t, string(doc))
+	newSpec := mustSpec(t, string(doc))
+
+	changes, err := DiffWithOptions(oldSpec, newSpec, &DiffOptions{
+		OldOptions: &ExpandOptions{RelativeBase: path},
+		NewOptions: &ExpandOptions{RelativeBase: path},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	t.Run("nested $ref in remote documents should be followed", func(t *testing.T) {
+		inline := mustSpec(t, `{"paths":{"/":{"get":{"responses":{"200":{
+			"description": "pet response",
+			"schema": {
+				"required": ["id", "name"],
+				"properties": { "id": { "type": "integer", "format": "int64" }, "name": { "type": "string" } }
+			}
+		}}}}}}`)
+
+		changes, err := DiffWithOptions(oldSpec, inline, &DiffOptions{
+			OldOptions: &ExpandOptions{RelativeBase: path},
+		})
+		require.NoError(t, err)
+
+		assertChange(t, changes, ChangePropertyRemoved, "/paths/~1/get/responses/200/schema/properties/tag", "tag")
+		assertChange(t, changes, ChangePathRemoved, "/paths/~1pets", "")
+		assert.Len(t, changes, 2)
+	})
+
+	t.Run("unresolved $ref should be reported", func(t *testing.T) {
+		_, err := Diff(oldSpec, mustSpec(t, `{"paths":{"/":{"get":{"responses":{"200":{"$ref":"#/responses/missing"}}}}}}`))
+		require.Error(t, err)
+	})
+}
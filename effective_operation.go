@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// OperationView is the effective view of an operation, with the settings it inherits from
+// its path item and from the spec applied.
+//
+// It is built by [EffectiveOperation].
+type OperationView struct {
+	// Path is the path template of the operation
+	Path string
+
+	// Method is the method of the operation, in lower case as in a swagger document
+	Method string
+
+	// Operation is the operation, as declared
+	Operation *Operation
+
+	// Parameters are the parameters of the path item and of the operation, resolved.
+	// Parameters of the path item come first, unless the operation overrides them by name and location
+	Parameters []EffectiveParameter
+
+	// Consumes are the media types consumed by the operation, or by the spec
+	Consumes Inherited[[]string]
+
+	// Produces are the media types produced by the operation, or by the spec
+	Produces Inherited[[]string]
+
+	// Schemes are the transfer protocols of the operation, or of the spec
+	Schemes Inherited[[]string]
+
+	// Security are the security requirements of the operation, or of the spec.
+	// An empty list means that the operation requires no security
+	Security Inherited[[]map[string][]string]
+}
+
+// Inherited is a setting of an effective operation, with the location it comes from.
+type Inherited[T any] struct {
+	Value T
+
+	// Source is the JSON pointer of the setting in the spec, e.g. "/consumes" or "/paths/~1pets/get/consumes".
+	// It is empty when the setting is defined nowhere
+	Source string
+}
+
+// EffectiveParameter is a resolved parameter of an effective operation.
+type EffectiveParameter struct {
+	Parameter
+
+	// Source is the JSON pointer of the parameter in the spec, at the path item or at the operation level
+	Source string
+
+	// Ref is the $ref declared at the source, if any, which has been followed to resolve the parameter
+	Ref Ref
+}
+
+// EffectiveOperation builds the effective view of the operation for a path template and a method.
+//
+// The view applies the inheritance rules of the Swagger 2.0 specification:
+//   - parameters are inherited from the path item, and overridden by name and location by the operation
+//   - consumes, produces and schemes are inherited from the spec, unless the operation defines them,
+//     even as an empty list
+//   - security is inherited from the spec, unless the operation defines it. An empty list disables security
+//
+// Parameters defined by a $ref are resolved against the spec.
+// It fails when the spec is nil, when the operation does not exist, or when a parameter cannot be resolved.
+func EffectiveOperation(spec *Swagger, path, method string) (*OperationView, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("cannot build an operation view of a nil spec: %w", ErrSpec)
+	}
+	if spec.Paths == nil {
+		return nil, fmt.Errorf("path %q not found: %w", path, ErrSpec)
+	}
+	item, ok := spec.Paths.Paths[path]
+	if !ok {
+		return nil, fmt.Errorf("path %q not found: %w", path, ErrSpec)
+	}
+
+	field := item.operationField(method)
+	if field == nil || *field == nil {
+		return nil, fmt.Errorf("operation %s %q not found: %w", strings.ToUpper(method), path, ErrSpec)
+	}
+
+	op := *field
+	itemPtr := joinPointer("/paths", path)
+	method = strings.ToLower(method)
+	opPtr := joinPointer(itemPtr, method)
+
+	view := &OperationView{
+		Path:      path,
+		Method:    method,
+		Operation: op,
+		Consumes:  inherit(op.Consumes, opPtr, spec.Consumes, "/consumes"),
+		Produces:  inherit(op.Produces, opPtr, spec.Produces, "/produces"),
+		Schemes:   inherit(op.Schemes, opPtr, spec.Schemes, "/schemes"),
+		Security:  inherit(op.Security, opPtr, spec.Security, "/security"),
+	}
+
+	resolver := newRefResolver(spec, nil)
+	pathParams, err := effectiveParameters(resolver, joinPointer(itemPtr, "parameters"), item.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	opParams, err := effectiveParameters(resolver, joinPointer(opPtr, "parameters"), op.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, param := range pathParams {
+		overridden := slices.ContainsFunc(opParams, func(p EffectiveParameter) bool {
+			return p.Name == param.Name && p.In == param.In
+		})
+		if !overridden {
+			view.Parameters = append(view.Parameters, param)
+		}
+	}
+	view.Parameters = append(view.Parameters, opParams...)
+
+	return view, nil
+}
+
+// inherit returns the value defined by an operation, if any, or the value defined by the spec.
+//
+// The pointers locate the operation and the setting in the spec.
+func inherit[T any, S ~[]T](opValue S, opPtr string, specValue S, specPtr string) Inherited[S] {
+	switch {
+	case opValue != nil:
+		return Inherited[S]{Value: opValue, Source: joinPointer(opPtr, strings.TrimPrefix(specPtr, "/"))}
+	case specValue != nil:
+		return Inherited[S]{Value: specValue, Source: specPtr}
+	default:
+		return Inherited[S]{}
+	}
+}
+
+// effectiveParameters resolves a list of parameters located at ptr.
+func effectiveParameters(resolver *refResolver, ptr string, params []Parameter) ([]EffectiveParameter, error) {
+	resolved := make([]EffectiveParameter, 0, len(params))
+	for i, param := range params {
+		source := joinPointer(ptr, strconv.Itoa(i))
+		current, base := param, ""
+		for seen := map[string]bool{}; current.Ref.String() != ""; {
+			key := resolver.key(current.Ref, base)
+			if seen[key] {
+				return nil, fmt.Errorf("circular $ref for parameter %s: %s: %w", source, key, ErrSpec)
+			}
+			seen[key] = true
+
+			var target Parameter
+			targetBase, err := resolver.resolve(current.Ref, base, &target)
+			if err != nil {
+				return nil, fmt.Errorf("cannot resolve parameter %s: %w", source, err)
+			}
+			current, base = target, targetBase
+		}
+
+		resolved = append(resolved, EffectiveParameter{Parameter: current, Source: source, Ref: param.Ref})
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+const effectiveSpec = `{
+  "swagger": "2.0",
+  "info": { "title": "pets", "version": "1.0" },
+  "consumes": [ "application/json" ],
+  "produces": [ "application/json" ],
+  "schemes": [ "https" ],
+  "security": [ { "key": [] } ],
+  "securityDefinitions": { "key": { "type": "apiKey", "name": "X-Key", "in": "header" } },
+  "parameters": {
+    "petId": { "name": "id", "in": "path", "type": "string", "required": true },
+    "alias": { "$ref": "#/parameters/limit" },
+    "limit": { "name": "limit", "in": "query", "type": "integer" },
+    "loop": { "$ref": "#/parameters/loop" }
+  },
+  "paths": {
+    "/pets/{id}": {
+      "parameters": [
+        { "$ref": "#/parameters/petId" },
+        { "name": "limit", "in": "query", "type": "string" },
+        { "name": "X-Trace", "in": "header", "type": "string" }
+      ],
+      "get": {
+        "produces": [ "application/xml" ],
+        "security": [],
+        "parameters": [ { "$ref": "#/parameters/alias" } ],
+        "responses": { "200": { "description": "ok" } }
+      },
+      "put": {
+        "consumes": [],
+        "responses": { "204": { "description": "ok" } }
+      },
+      "delete": {
+        "parameters": [ { "$ref": "#/parameters/loop" } ],
+        "responses": { "204": { "description": "ok" } }
+      }
+    }
+  }
+}`
+
+func TestEffectiveOperation(t *testing.T) {
+	sp := mustSpec(t, effectiveSpec)
+
+	t.Run("settings should be inherited or overridden", func(t *testing.T) {
+		view, err := EffectiveOperation(sp, "/pets/{id}", "GET")
+		require.NoError(t, err)
+		assert.EqualT(t, "get", view.Method)
+		assert.TrueT(t, view.Operation == sp.Paths.Paths["/pets/{id}"].Get)
+
+		assert.Equal(t, Inherited[[]string]{Value: []string{"application/json"}, Source: "/consumes"}, view.Consumes)
+		assert.Equal(t, Inherited[[]string]{Value: []string{"application/xml"}, Source: "/paths/~1pets~1{id}/get/produces"}, view.Produces)
+		assert.Equal(t, Inherited[[]string]{Value: []string{"https"}, Source: "/schemes"}, view.Schemes)
+		assert.Empty(t, view.Security.Value)
+		assert.EqualT(t, "/paths/~1pets~1{id}/get/security", view.Security.Source)
+
+		require.Len(t, view.Parameters, 3)
+		assert.EqualT(t, "id", view.Parameters[0].Name)
+		assert.TrueT(t, view.Parameters[0].Required)
+		assert.EqualT(t, "/paths/~1pets~1{id}/parameters/0", view.Parameters[0].Source)
+		assert.EqualT(t, "#/parameters/petId", view.Parameters[0].Ref.String())
+
+		assert.EqualT(t, "X-Trace", view.Parameters[1].Name)
+		assert.EqualT(t, "/paths/~1pets~1{id}/parameters/2", view.Parameters[1].Source)
+
+		assert.EqualT(t, "limit", view.Parameters[2].Name)
+		assert.EqualT(t, "integer", view.Parameters[2].Type)
+		assert.EqualT(t, "/paths/~1pets~1{id}/get/parameters/0", view.Parameters[2].Source)
+		assert.EqualT(t, "#/parameters/alias", view.Parameters[2].Ref.String())
+	})
+
+	t.Run("empty lists should override inherited ones", func(t *testing.T) {
+		view, err := EffectiveOperation(sp, "/pets/{id}", "put")
+		require.NoError(t, err)
+		assert.Empty(t, view.Consumes.Value)
+		assert.EqualT(t, "/paths/~1pets~1{id}/put/consumes", view.Consumes.Source)
+		assert.Equal(t, []map[string][]string{{"key": {}}}, view.Security.Value)
+		assert.EqualT(t, "/security", view.Security.Source)
+		require.Len(t, view.Parameters, 3)
+		assert.EqualT(t, "string", view.Parameters[1].Type)
+	})
+
+	t.Run("settings defined nowhere should have no source", func(t *testing.T) {
+		sp := mustSpec(t, `{"paths":{"/pets":{"get":{}}}}`)
+		view, err := EffectiveOperation(sp, "/pets", "get")
+		require.NoError(t, err)
+		assert.Equal(t, Inherited[[]string]{}, view.Consumes)
+		assert.Empty(t, view.Parameters)
+	})
+
+	t.Run("missing operations and unresolvable parameters should fail", func(t *testing.T) {
+		for _, tc := range []struct{ path, method string }{
+			{"/pets", "get"},
+			{"/pets/{id}", "post"},
+			{"/pets/{id}", "trace"},
+			{"/pets/{id}", "delete"},
+		} {
+			_, err := EffectiveOperation(sp, tc.path, tc.method)
+			require.ErrorIs(t, err, ErrSpec, tc.method+" "+tc.path)
+		}
+
+		_, err := EffectiveOperation(nil, "/pets", "get")
+		require.ErrorIs(t, err, ErrSpec)
+	})
+}
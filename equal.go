@@ -0,0 +1,673 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Equal reports whether two swagger specifications are semantically equal.
+//
+// Unlike [reflect.DeepEqual], this comparison considers that:
+//   - nil and empty maps or slices of the object model are equal (except for security requirements, for which an
+//     empty list is meaningful). Within values such as defaults, examples or extensions, an empty object or array
+//     is not null
+//   - numbers are compared by value, regardless of their go type (e.g. float64 or [json.Number])
+//   - a single type expressed as a string or as an array with one element is the same type
+//   - lists with set semantics, such as required properties, enum values, types, schemes or media types, are order-insensitive
+//   - parameters are order-insensitive
+func (s *Swagger) Equal(other *Swagger) bool {
+	return comparator{}.swagger(s, other)
+}
+
+// Equivalent reports whether two swagger specifications are semantically equal,
+// ignoring documentation-only fields such as descriptions, summaries, titles, examples and external docs.
+func (s *Swagger) Equivalent(other *Swagger) bool {
+	return comparator{ignoreDocs: true}.swagger(s, other)
+}
+
+// Equal reports whether two schemas are semantically equal.
+//
+// See [Swagger.Equal] for the rules applied.
+func (s *Schema) Equal(other *Schema) bool {
+	return comparator{}.schemaPtr(s, other)
+}
+
+// Equivalent reports whether two schemas are semantically equal, ignoring documentation-only fields.
+//
+// See [Swagger.Equivalent] for the rules applied.
+func (s *Schema) Equivalent(other *Schema) bool {
+	return comparator{ignoreDocs: true}.schemaPtr(s, other)
+}
+
+// Equal reports whether two operations are semantically equal.
+//
+// See [Swagger.Equal] for the rules applied.
+func (o *Operation) Equal(other *Operation) bool {
+	return comparator{}.operation(o, other)
+}
+
+// Equivalent reports whether two operations are semantically equal, ignoring documentation-only fields.
+//
+// See [Swagger.Equivalent] for the rules applied.
+func (o *Operation) Equivalent(other *Operation) bool {
+	return comparator{ignoreDocs: true}.operation(o, other)
+}
+
+// Equal reports whether two parameters are semantically equal.
+//
+// See [Swagger.Equal] for the rules applied.
+func (p *Parameter) Equal(other *Parameter) bool {
+	return comparator{}.parameterPtr(p, other)
+}
+
+// Equivalent reports whether two parameters are semantically equal, ignoring documentation-only fields.
+//
+// See [Swagger.Equivalent] for the rules applied.
+func (p *Parameter) Equivalent(other *Parameter) bool {
+	return comparator{ignoreDocs: true}.parameterPtr(p, other)
+}
+
+// Equal reports whether two responses are semantically equal.
+//
+// See [Swagger.Equal] for the rules applied.
+func (r *Response) Equal(other *Response) bool {
+	return comparator{}.responsePtr(r, other)
+}
+
+// Equivalent reports whether two responses are semantically equal, ignoring documentation-only fields.
+//
+// See [Swagger.Equivalent] for the rules applied.
+func (r *Response) Equivalent(other *Response) bool {
+	return comparator{ignoreDocs: true}.responsePtr(r, other)
+}
+
+// comparator knows how to compare the spec object model semantically.
+type comparator struct {
+	ignoreDocs bool
+}
+
+// docs compares documentation-only strings.
+func (c comparator) docs(a, b string) bool {
+	return c.ignoreDocs || a == b
+}
+
+// docValues compares documentation-only values, such as examples.
+func (c comparator) docValues(a, b any) bool {
+	return c.ignoreDocs || equalValues(a, b)
+}
+
+func (c comparator) swagger(a, b *Swagger) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return c.extensions(a.Extensions, b.Extensions) &&
+		a.ID == b.ID &&
+		a.Swagger == b.Swagger &&
+		a.Host == b.Host &&
+		a.BasePath == b.BasePath &&
+		equalStringSets(a.Consumes, b.Consumes) &&
+		equalStringSets(a.Produces, b.Produces) &&
+		equalStringSets(a.Schemes, b.Schemes) &&
+		c.info(a.Info, b.Info) &&
+		c.paths(a.Paths, b.Paths) &&
+		c.definitions(a.Definitions, b.Definitions) &&
+		equalMaps(a.Parameters, b.Parameters, c.parameter) &&
+		equalMaps(a.Responses, b.Responses, c.response) &&
+		equalMaps(a.SecurityDefinitions, b.SecurityDefinitions, c.securityScheme) &&
+		equalSecurity(a.Security, b.Security) &&
+		c.tags(a.Tags, b.Tags) &&
+		c.externalDocs(a.ExternalDocs, b.ExternalDocs)
+}
+
+func (c comparator) info(a, b *Info) bool {
+	if c.ignoreDocs {
+		// only the version of the API is not documentation
+		return a.version() == b.version() && c.extensions(a.extensions(), b.extensions())
+	}
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return c.extensions(a.Extensions, b.Extensions) &&
+		a.Description == b.Description &&
+		a.Title == b.Title &&
+		a.TermsOfService == b.TermsOfService &&
+		a.Version == b.Version &&
+		c.contact(a.Contact, b.Contact) &&
+		c.license(a.License, b.License)
+}
+
+func (i *Info) version() string {
+	if i == nil {
+		return ""
+	}
+
+	return i.Version
+}
+
+func (i *Info) extensions() Extensions {
+	if i == nil {
+		return nil
+	}
+
+	return i.Extensions
+}
+
+func (c comparator) contact(a, b *ContactInfo) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.ContactInfoProps == b.ContactInfoProps && c.extensions(a.Extensions, b.Extensions)
+}
+
+func (c comparator) license(a, b *License) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.LicenseProps == b.LicenseProps && c.extensions(a.Extensions, b.Extensions)
+}
+
+func (c comparator) externalDocs(a, b *ExternalDocumentation) bool {
+	if c.ignoreDocs {
+		return true
+	}
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func (c comparator) tags(a, b []Tag) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	byName := make(map[string]Tag, len(b))
+	for _, tag := range b {
+		byName[tag.Name] = tag
+	}
+
+	for _, tag := range a {
+		other, ok := byName[tag.Name]
+		if !ok {
+			return false
+		}
+		if !c.extensions(tag.Extensions, other.Extensions) ||
+			!c.docs(tag.Description, other.Description) ||
+			!c.externalDocs(tag.ExternalDocs, other.ExternalDocs) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c comparator) securityScheme(a, b *SecurityScheme) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return c.extensions(a.Extensions, b.Extensions) &&
+		c.docs(a.Description, b.Description) &&
+		a.Type == b.Type &&
+		a.Name == b.Name &&
+		a.In == b.In &&
+		a.Flow == b.Flow &&
+		a.AuthorizationURL == b.AuthorizationURL &&
+		a.TokenURL == b.TokenURL &&
+		equalMaps(a.Scopes, b.Scopes, func(x, y string) bool { return c.docs(x, y) })
+}
+
+func (c comparator) paths(a, b *Paths) bool {
+	if a == nil && b == nil {
+		return true
+	}
+	if a == nil {
+		a = &Paths{}
+	}
+	if b == nil {
+		b = &Paths{}
+	}
+
+	return c.extensions(a.Extensions, b.Extensions) && equalMaps(a.Paths, b.Paths, c.pathItem)
+}
+
+func (c comparator) pathItem(a, b PathItem) bool {
+	return a.Ref.String() == b.Ref.String() &&
+		c.extensions(a.Extensions, b.Extensions) &&
+		c.operation(a.Get, b.Get) &&
+		c.operation(a.Put, b.Put) &&
+		c.operation(a.Post, b.Post) &&
+		c.operation(a.Delete, b.Delete) &&
+		c.operation(a.Options, b.Options) &&
+		c.operation(a.Head, b.Head) &&
+		c.operation(a.Patch, b.Patch) &&
+		c.parameters(a.Parameters, b.Parameters)
+}
+
+func (c comparator) operation(a, b *Operation) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return c.extensions(a.Extensions, b.Extensions) &&
+		c.docs(a.Description, b.Description) &&
+		c.docs(a.Summary, b.Summary) &&
+		c.externalDocs(a.ExternalDocs, b.ExternalDocs) &&
+		a.ID == b.ID &&
+		a.Deprecated == b.Deprecated &&
+		equalStringSets(a.Consumes, b.Consumes) &&
+		equalStringSets(a.Produces, b.Produces) &&
+		equalStringSets(a.Schemes, b.Schemes) &&
+		equalStringSets(a.Tags, b.Tags) &&
+		equalSecurity(a.Security, b.Security) &&
+		c.parameters(a.Parameters, b.Parameters) &&
+		c.responses(a.Responses, b.Responses)
+}
+
+// parameters compares lists of parameters, regardless of their order.
+func (c comparator) parameters(a, b []Parameter) bool {
+	return equalUnordered(a, b, c.parameter)
+}
+
+func (c comparator) parameterPtr(a, b *Parameter) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return c.parameter(*a, *b)
+}
+
+func (c comparator) parameter(a, b Parameter) bool {
+	return a.Ref.String() == b.Ref.String() &&
+		c.extensions(a.Extensions, b.Extensions) &&
+		c.docs(a.Description, b.Description) &&
+		a.Name == b.Name &&
+		a.In == b.In &&
+		a.Required == b.Required &&
+		a.AllowEmptyValue == b.AllowEmptyValue &&
+		c.schemaPtr(a.Schema, b.Schema) &&
+		c.simpleSchema(a.SimpleSchema, b.SimpleSchema) &&
+		equalCommonValidations(a.CommonValidations, b.CommonValidations)
+}
+
+func (c comparator) simpleSchema(a, b SimpleSchema) bool {
+	return a.Type == b.Type &&
+		a.Nullable == b.Nullable &&
+		a.Format == b.Format &&
+		a.CollectionFormat == b.CollectionFormat &&
+		equalValues(a.Default, b.Default) &&
+		c.docValues(a.Example, b.Example) &&
+		c.items(a.Items, b.Items)
+}
+
+func (c comparator) items(a, b *Items) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Ref.String() == b.Ref.String() &&
+		c.extensions(a.Extensions, b.Extensions) &&
+		c.simpleSchema(a.SimpleSchema, b.SimpleSchema) &&
+		equalCommonValidations(a.CommonValidations, b.CommonValidations)
+}
+
+func (c comparator) header(a, b Header) bool {
+	return c.extensions(a.Extensions, b.Extensions) &&
+		c.docs(a.Description, b.Description) &&
+		c.simpleSchema(a.SimpleSchema, b.SimpleSchema) &&
+		equalCommonValidations(a.CommonValidations, b.CommonValidations)
+}
+
+func (c comparator) responses(a, b *Responses) bool {
+	if a == nil && b == nil {
+		return true
+	}
+	if a == nil {
+		a = &Responses{}
+	}
+	if b == nil {
+		b = &Responses{}
+	}
+
+	return c.extensions(a.Extensions, b.Extensions) &&
+		c.responsePtr(a.Default, b.Default) &&
+		equalMaps(a.StatusCodeResponses, b.StatusCodeResponses, c.response) &&
+		equalMaps(a.StatusCodeRanges, b.StatusCodeRanges, c.response)
+}
+
+func (c comparator) responsePtr(a, b *Response) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return c.response(*a, *b)
+}
+
+func (c comparator) response(a, b Response) bool {
+	return a.Ref.String() == b.Ref.String() &&
+		c.extensions(a.Extensions, b.Extensions) &&
+		c.docs(a.Description, b.Description) &&
+		c.schemaPtr(a.Schema, b.Schema) &&
+		equalMaps(a.Headers, b.Headers, c.header) &&
+		(c.ignoreDocs || equalMaps(a.Examples, b.Examples, equalValues))
+}
+
+func (c comparator) definitions(a, b map[string]Schema) bool {
+	return equalMaps(a, b, c.schema)
+}
+
+func (c comparator) schemaPtr(a, b *Schema) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return c.schema(*a, *b)
+}
+
+func (c comparator) schema(a, b Schema) bool {
+	return c.extensions(a.Extensions, b.Extensions) &&
+		equalMaps(a.ExtraProps, b.ExtraProps, equalValues) &&
+		c.schemaProps(a.SchemaProps, b.SchemaProps) &&
+		c.swaggerSchemaProps(a.SwaggerSchemaProps, b.SwaggerSchemaProps)
+}
+
+func (c comparator) schemaProps(a, b SchemaProps) bool {
+	return a.ID == b.ID &&
+		a.Ref.String() == b.Ref.String() &&
+		a.Schema == b.Schema &&
+		c.docs(a.Description, b.Description) &&
+		c.docs(a.Title, b.Title) &&
+		equalStringSets(a.Type, b.Type) &&
+		a.Nullable == b.Nullable &&
+		a.Format == b.Format &&
+		equalValues(a.Default, b.Default) &&
+		equalNumberPtr(a.Maximum, b.Maximum) &&
+		a.ExclusiveMaximum == b.ExclusiveMaximum &&
+		equalNumberPtr(a.Minimum, b.Minimum) &&
+		a.ExclusiveMinimum == b.ExclusiveMinimum &&
+		equalNumberPtr(a.MaxLength, b.MaxLength) &&
+		equalNumberPtr(a.MinLength, b.MinLength) &&
+		a.Pattern == b.Pattern &&
+		equalNumberPtr(a.MaxItems, b.MaxItems) &&
+		equalNumberPtr(a.MinItems, b.MinItems) &&
+		a.UniqueItems == b.UniqueItems &&
+		equalNumberPtr(a.MultipleOf, b.MultipleOf) &&
+		equalEnum(a.Enum, b.Enum) &&
+		equalNumberPtr(a.MaxProperties, b.MaxProperties) &&
+		equalNumberPtr(a.MinProperties, b.MinProperties) &&
+		equalStringSets(a.Required, b.Required) &&
+		c.schemaOrArray(a.Items, b.Items) &&
+		equalSlices(a.AllOf, b.AllOf, c.schema) &&
+		equalUnordered(a.OneOf, b.OneOf, c.schema) &&
+		equalUnordered(a.AnyOf, b.AnyOf, c.schema) &&
+		c.schemaPtr(a.Not, b.Not) &&
+		equalMaps(a.Properties, b.Properties, c.schema) &&
+		c.schemaOrBool(a.AdditionalProperties, b.AdditionalProperties) &&
+		equalMaps(a.PatternProperties, b.PatternProperties, c.schema) &&
+		equalMaps(a.Dependencies, b.Dependencies, c.schemaOrStringArray) &&
+		c.schemaOrBool(a.AdditionalItems, b.AdditionalItems) &&
+		equalMaps(a.Definitions, b.Definitions, c.schema)
+}
+
+func (c comparator) swaggerSchemaProps(a, b SwaggerSchemaProps) bool {
+	return a.Discriminator == b.Discriminator &&
+		a.ReadOnly == b.ReadOnly &&
+		equalPtr(a.XML, b.XML) &&
+		c.externalDocs(a.ExternalDocs, b.ExternalDocs) &&
+		c.docValues(a.Example, b.Example)
+}
+
+func (c comparator) schemaOrArray(a, b *SchemaOrArray) bool {
+	if a == nil {
+		a = &SchemaOrArray{}
+	}
+	if b == nil {
+		b = &SchemaOrArray{}
+	}
+	if (a.Schema == nil) != (b.Schema == nil) {
+		// a single schema for all items is not the same as a tuple of schemas
+		return false
+	}
+	if a.Schema != nil {
+		return c.schema(*a.Schema, *b.Schema)
+	}
+
+	return equalSlices(a.Schemas, b.Schemas, c.schema)
+}
+
+// schemaOrBool compares schemas or booleans: an unset value or an empty schema are equivalent to true.
+func (c comparator) schemaOrBool(a, b *SchemaOrBool) bool {
+	allowsA, schemaA := a.normalized()
+	allowsB, schemaB := b.normalized()
+
+	return allowsA == allowsB && c.schemaPtr(schemaA, schemaB)
+}
+
+func (s *SchemaOrBool) normalized() (bool, *Schema) {
+	if s == nil {
+		return true, nil
+	}
+	if s.Schema == nil {
+		return s.Allows, nil
+	}
+	if (comparator{}).schema(*s.Schema, Schema{}) {
+		return true, nil
+	}
+
+	return true, s.Schema
+}
+
+func (c comparator) schemaOrStringArray(a, b SchemaOrStringArray) bool {
+	return c.schemaPtr(a.Schema, b.Schema) && equalStringSets(a.Property, b.Property)
+}
+
+// extensions compares vendor extensions. Extension keys are case-insensitive.
+func (c comparator) extensions(a, b Extensions) bool {
+	return equalMaps(lowerKeys(a), lowerKeys(b), equalValues)
+}
+
+func lowerKeys(e Extensions) map[string]any {
+	if len(e) == 0 {
+		return nil
+	}
+
+	m := make(map[string]any, len(e))
+	for k, v := range e {
+		m[strings.ToLower(k)] = v
+	}
+
+	return m
+}
+
+func equalCommonValidations(a, b CommonValidations) bool {
+	return equalNumberPtr(a.Maximum, b.Maximum) &&
+		a.ExclusiveMaximum == b.ExclusiveMaximum &&
+		equalNumberPtr(a.Minimum, b.Minimum) &&
+		a.ExclusiveMinimum == b.ExclusiveMinimum &&
+		equalNumberPtr(a.MaxLength, b.MaxLength) &&
+		equalNumberPtr(a.MinLength, b.MinLength) &&
+		a.Pattern == b.Pattern &&
+		equalNumberPtr(a.MaxItems, b.MaxItems) &&
+		equalNumberPtr(a.MinItems, b.MinItems) &&
+		a.UniqueItems == b.UniqueItems &&
+		equalNumberPtr(a.MultipleOf, b.MultipleOf) &&
+		equalEnum(a.Enum, b.Enum)
+}
+
+// equalSecurity compares security requirements, regardless of their order.
+//
+// Contrary to other lists, a nil requirement (inherited) differs from an empty one (no security).
+func equalSecurity(a, b []map[string][]string) bool {
+	if (a == nil) != (b == nil) {
+		return false
+	}
+
+	return equalUnordered(a, b, func(x, y map[string][]string) bool {
+		return equalMaps(x, y, equalStringSets)
+	})
+}
+
+// equalEnum compares enum values, regardless of their order.
+func equalEnum(a, b []any) bool {
+	return equalUnordered(a, b, equalValues)
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func equalNumberPtr[T int64 | float64](a, b *T) bool {
+	return equalPtr(a, b)
+}
+
+func equalSlices[T any](a, b []T, eq func(T, T) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !eq(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equalUnordered compares two slices as multisets.
+func equalUnordered[T any](a, b []T, eq func(T, T) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	matched := make([]bool, len(b))
+	for _, x := range a {
+		found := false
+		for j, y := range b {
+			if matched[j] || !eq(x, y) {
+				continue
+			}
+			matched[j] = true
+			found = true
+
+			break
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func equalStringSets[S ~[]string](a, b S) bool {
+	return equalUnordered(a, b, func(x, y string) bool { return x == y })
+}
+
+// equalMaps compares two maps, considering nil and empty maps as equal.
+func equalMaps[M ~map[K]V, K comparable, V any](a, b M, eq func(V, V) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, va := range a {
+		vb, ok := b[k]
+		if !ok || !eq(va, vb) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equalValues compares two values held by interfaces, such as default values, examples or extensions.
+//
+// Numbers are compared by value, objects and arrays are compared member by member.
+// Values of other types are compared after being converted to their JSON representation,
+// or with [reflect.DeepEqual] when they have none.
+func equalValues(a, b any) bool {
+	a, b = normalizeValue(a), normalizeValue(b)
+
+	switch va := a.(type) {
+	case nil:
+		return b == nil
+	case *big.Rat:
+		vb, ok := b.(*big.Rat)
+
+		return ok && va.Cmp(vb) == 0
+	case map[string]any:
+		vb, ok := b.(map[string]any)
+
+		return ok && equalMaps(va, vb, equalValues)
+	case []any:
+		vb, ok := b.([]any)
+
+		return ok && equalSlices(va, vb, equalValues)
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+// normalizeValue converts any value to a JSON-like form where numbers are rationals.
+func normalizeValue(v any) any {
+	switch tv := v.(type) {
+	case nil, bool, string:
+		return tv
+	case json.Number:
+		if r, ok := new(big.Rat).SetString(tv.String()); ok {
+			return r
+		}
+
+		return tv.String()
+	case float64:
+		return ratFromFloat(tv)
+	case float32:
+		return ratFromFloat(float64(tv))
+	case int, int8, int16, int32, int64:
+		return new(big.Rat).SetInt64(reflect.ValueOf(tv).Int())
+	case uint, uint8, uint16, uint32, uint64:
+		return new(big.Rat).SetFrac(new(big.Int).SetUint64(reflect.ValueOf(tv).Uint()), big.NewInt(1))
+	case map[string]any, []any:
+		return tv
+	default:
+		// other types, e.g. structs or typed maps: use their JSON representation
+		buf, err := json.Marshal(tv)
+		if err != nil {
+			return tv
+		}
+		var generic any
+		dec := json.NewDecoder(strings.NewReader(string(buf)))
+		dec.UseNumber()
+		if err := dec.Decode(&generic); err != nil {
+			return tv
+		}
+
+		return normalizeValue(generic)
+	}
+}
+
+// ratFromFloat converts a float using its shortest decimal representation,
+// so that 0.1 compares equal to the json.Number "0.1".
+func ratFromFloat(f float64) any {
+	repr := strconv.FormatFloat(f, 'g', -1, 64)
+	r, ok := new(big.Rat).SetString(repr)
+	if !ok {
+		// infinities and NaN: fall back to the string representation
+		return repr
+	}
+
+	return r
+}
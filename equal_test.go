@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestEqual_Swagger(t *testing.T) {
+	var sp Swagger
+	require.NoError(t, json.Unmarshal(specJSON, &sp))
+
+	t.Run("a spec should be equal to its copy", func(t *testing.T) {
+		cp := sp.DeepCopy()
+		assert.TrueT(t, sp.Equal(cp))
+		assert.TrueT(t, sp.Equivalent(cp))
+	})
+
+	t.Run("a spec should be equal to its JSON round trip", func(t *testing.T) {
+		buf, err := json.Marshal(sp)
+		require.NoError(t, err)
+		var rt Swagger
+		require.NoError(t, json.Unmarshal(buf, &rt))
+		assert.TrueT(t, sp.Equal(&rt))
+	})
+
+	t.Run("documentation changes should only be ignored by Equivalent", func(t *testing.T) {
+		cp := sp.DeepCopy()
+		cp.Info.Description = "another description"
+		cp.Info.Title = "another title"
+		for _, pi := range cp.Paths.Paths {
+			if pi.Get != nil {
+				pi.Get.Summary = "another summary"
+			}
+		}
+		assert.FalseT(t, sp.Equal(cp))
+		assert.TrueT(t, sp.Equivalent(cp))
+	})
+
+	t.Run("functional changes should be detected", func(t *testing.T) {
+		cp := sp.DeepCopy()
+		cp.BasePath = "/v2"
+		assert.FalseT(t, sp.Equal(cp))
+		assert.FalseT(t, sp.Equivalent(cp))
+	})
+
+	t.Run("set-like lists should be order-insensitive", func(t *testing.T) {
+		a := &Swagger{SwaggerProps: SwaggerProps{Schemes: []string{"http", "https"}, Produces: []string{"application/json", "application/xml"}}}
+		b := &Swagger{SwaggerProps: SwaggerProps{Schemes: []string{"https", "http"}, Produces: []string{"application/xml", "application/json"}}}
+		assert.TrueT(t, a.Equal(b))
+	})
+
+	t.Run("nil and empty maps should be equal", func(t *testing.T) {
+		a := &Swagger{SwaggerProps: SwaggerProps{Definitions: Definitions{}, Paths: &Paths{}}}
+		b := &Swagger{}
+		assert.TrueT(t, a.Equal(b))
+	})
+
+	t.Run("nil and empty security requirements should differ", func(t *testing.T) {
+		a := &Swagger{SwaggerProps: SwaggerProps{Security: []map[string][]string{}}}
+		b := &Swagger{}
+		assert.FalseT(t, a.Equal(b))
+	})
+
+	t.Run("extension keys should be case-insensitive", func(t *testing.T) {
+		a := &Swagger{VendorExtensible: VendorExtensible{Extensions: Extensions{"X-Foo": "bar"}}}
+		b := &Swagger{VendorExtensible: VendorExtensible{Extensions: Extensions{"x-foo": "bar"}}}
+		assert.TrueT(t, a.Equal(b))
+	})
+
+	t.Run("nil specs", func(t *testing.T) {
+		var a, b *Swagger
+		assert.TrueT(t, a.Equal(b))
+		assert.FalseT(t, a.Equal(&sp))
+	})
+}
+
+func TestEqual_Schema(t *testing.T) {
+	t.Run("type as string or array should be equal", func(t *testing.T) {
+		var a, b Schema
+		require.NoError(t, json.Unmarshal([]byte(`{"type":"string"}`), &a))
+		require.NoError(t, json.Unmarshal([]byte(`{"type":["string"]}`), &b))
+		assert.TrueT(t, a.Equal(&b))
+	})
+
+	t.Run("required and enum should be order-insensitive", func(t *testing.T) {
+		a := new(Schema).WithRequired("a", "b").WithEnum("x", "y", 1)
+		b := new(Schema).WithRequired("b", "a").WithEnum(1.0, "y", "x")
+		assert.TrueT(t, a.Equal(b))
+
+		c := new(Schema).WithRequired("b", "a").WithEnum(2, "y", "x")
+		assert.FalseT(t, a.Equal(c))
+	})
+
+	t.Run("numbers should compare by value", func(t *testing.T) {
+		a := new(Schema).WithDefault(json.Number("0.1")).WithEnum(json.Number("12"))
+		b := new(Schema).WithDefault(0.1).WithEnum(int64(12))
+		assert.TrueT(t, a.Equal(b))
+	})
+
+	t.Run("nested values should compare by value", func(t *testing.T) {
+		a := new(Schema).WithDefault(map[string]any{"a": []any{json.Number("1"), map[string]any{}}})
+		b := new(Schema).WithDefault(map[string]any{"a": []any{1.0, map[string]any{}}})
+		assert.TrueT(t, a.Equal(b))
+
+		c := new(Schema).WithDefault(map[string]any{"a": []any{1.0, nil}})
+		assert.FalseT(t, a.Equal(c))
+	})
+
+	t.Run("empty values should differ from missing ones", func(t *testing.T) {
+		assert.FalseT(t, new(Schema).WithDefault(map[string]any{}).Equal(new(Schema)))
+		assert.FalseT(t, new(Schema).WithDefault([]any{}).Equal(new(Schema)))
+		assert.FalseT(t, new(Schema).WithExample([]any{}).Equivalent(new(Schema).WithDefault([]any{})))
+
+		a := &Schema{SchemaProps: SchemaProps{Properties: SchemaProperties{}, Required: []string{}}}
+		assert.TrueT(t, a.Equal(new(Schema)))
+	})
+
+	t.Run("values without a JSON representation should compare deeply", func(t *testing.T) {
+		a := new(Schema).WithDefault(map[bool]int{true: 1})
+		b := new(Schema).WithDefault(map[bool]int{true: 1})
+		assert.TrueT(t, a.Equal(b))
+
+		b.WithDefault(map[bool]int{true: 2})
+		assert.FalseT(t, a.Equal(b))
+	})
+
+	t.Run("allOf order matters", func(t *testing.T) {
+		a := ComposedSchema(*StringProperty(), *Int64Property())
+		b := ComposedSchema(*Int64Property(), *StringProperty())
+		assert.FalseT(t, a.Equal(b))
+	})
+
+	t.Run("additionalProperties true is the same as unset", func(t *testing.T) {
+		a := &Schema{SchemaProps: SchemaProps{AdditionalProperties: &SchemaOrBool{Allows: true}}}
+		b := &Schema{}
+		assert.TrueT(t, a.Equal(b))
+
+		c := &Schema{SchemaProps: SchemaProps{AdditionalProperties: &SchemaOrBool{Allows: false}}}
+		assert.FalseT(t, c.Equal(b))
+	})
+
+	t.Run("documentation fields", func(t *testing.T) {
+		a := StringProperty().WithDescription("a").WithTitle("A").WithExample("x")
+		b := StringProperty().WithDescription("b").WithTitle("B").WithExample("y")
+		assert.FalseT(t, a.Equal(b))
+		assert.TrueT(t, a.Equivalent(b))
+
+		b.WithMaxLength(10)
+		assert.FalseT(t, a.Equivalent(b))
+	})
+
+	t.Run("properties should compare recursively", func(t *testing.T) {
+		a := new(Schema).SetProperty("name", *StringProperty())
+		b := new(Schema).SetProperty("name", *StringProperty())
+		assert.TrueT(t, a.Equal(b))
+
+		b.SetProperty("name", *StringProperty().WithMinLength(1))
+		assert.FalseT(t, a.Equal(b))
+	})
+}
+
+func TestEqual_Operation(t *testing.T) {
+	a := NewOperation("getPet").
+		AddParam(QueryParam("limit").Typed("integer", "int32")).
+		AddParam(PathParam("id").Typed("string", "")).
+		RespondsWith(200, NewResponse().WithDescription("ok"))
+	b := NewOperation("getPet").
+		AddParam(PathParam("id").Typed("string", "")).
+		AddParam(QueryParam("limit").Typed("integer", "int32")).
+		RespondsWith(200, NewResponse().WithDescription("fine"))
+
+	assert.FalseT(t, a.Equal(b))
+	assert.TrueT(t, a.Equivalent(b))
+
+	b.Responses.StatusCodeResponses[200] = *NewResponse().WithDescription("ok")
+	assert.TrueT(t, a.Equal(b))
+
+	b.RespondsWith(404, NewResponse())
+	assert.FalseT(t, a.Equal(b))
+}
+
+func TestEqual_Parameter(t *testing.T) {
+	a := QueryParam("limit").Typed("integer", "int32").WithMaximum(100, false)
+	b := QueryParam("limit").Typed("integer", "int32").WithMaximum(100, false)
+	assert.TrueT(t, a.Equal(b))
+
+	b.WithMaximum(50, false)
+	assert.FalseT(t, a.Equal(b))
+
+	b.WithMaximum(100, false).WithDescription("the limit")
+	assert.FalseT(t, a.Equal(b))
+	assert.TrueT(t, a.Equivalent(b))
+}
+
+func TestEqual_Response(t *testing.T) {
+	a := NewResponse().WithDescription("ok").WithSchema(StringProperty()).AddExample("application/json", "a")
+	b := NewResponse().WithDescription("ok").WithSchema(StringProperty()).AddExample("application/json", "b")
+	assert.FalseT(t, a.Equal(b))
+	assert.TrueT(t, a.Equivalent(b))
+
+	b.AddHeader("X-Rate-Limit", ResponseHeader().Typed("integer", "int32"))
+	assert.FalseT(t, a.Equivalent(b))
+
+	assert.TrueT(t, ResponseRef("#/responses/ok").Equal(ResponseRef("#/responses/ok")))
+	assert.FalseT(t, ResponseRef("#/responses/ok").Equal(ResponseRef("#/responses/ko")))
+}
@@ -20,6 +20,18 @@ var (
 	// ErrExpandUnsupportedType indicates that $ref expansion is attempted on some invalid type.
 	ErrExpandUnsupportedType = errors.New("expand: unsupported type. Input should be of type *Parameter or *Response")
 
+	// ErrPatch indicates that a JSON patch could not be applied to a spec.
+	ErrPatch = errors.New("json patch")
+
+	// ErrOverlay indicates that an overlay or a merge patch could not be applied to a spec.
+	ErrOverlay = errors.New("overlay")
+
+	// ErrJSONPath indicates that a JSONPath expression is invalid.
+	ErrJSONPath = errors.New("jsonpath")
+
+	// ErrValidation indicates that a spec violates a rule of the Swagger 2.0 specification.
+	ErrValidation = errors.New("validation")
+
 	// ErrSpec is an error raised by the spec package.
 	ErrSpec = errors.New("spec error")
 )
@@ -444,6 +444,14 @@ func expandOperation(op *Operation, resolver *schemaLoader, basePath string) err
 		responses.StatusCodeResponses[code] = response
 	}
 
+	for rng := range responses.StatusCodeRanges {
+		response := responses.StatusCodeRanges[rng]
+		if err := expandParameterOrResponse(&response, resolver, basePath); resolver.shouldStopOnError(err) {
+			return err
+		}
+		responses.StatusCodeRanges[rng] = response
+	}
+
 	return nil
 }
 
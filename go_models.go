@@ -0,0 +1,511 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"cmp"
+	"fmt"
+	"go/format"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-openapi/jsonpointer"
+)
+
+// GoModelOptions configures the Go source generated by [GenerateGoModels].
+type GoModelOptions struct {
+	// Package is the name of the generated package, "models" by default
+	Package string
+
+	// FormatTypes maps string formats to Go types, and take precedence over the default mapping,
+	// e.g. "uuid" to github.com/google/uuid.UUID
+	FormatTypes map[string]GoType
+}
+
+// GoType is a Go type used for a schema format.
+type GoType struct {
+	// Name is the name of the type, qualified by its package if any, e.g. "uuid.UUID"
+	Name string
+
+	// Import is the import path of the package of the type, if any, e.g. "github.com/google/uuid"
+	Import string
+}
+
+// GenerateGoModels generates the Go source of the models of some definitions.
+//
+// Every definition becomes a named type, and types are emitted in alphabetical order:
+//   - objects become structs, with a field per property tagged with its JSON name.
+//     allOf members referring to definitions are embedded, and the properties of other members are merged
+//   - objects with additionalProperties only become maps, arrays become slices, untyped schemas become any
+//   - enums of strings, integers and numbers become types with a constant for each value. Enum types declared
+//     for properties, items or values are named after their definition and property, with a numeric suffix
+//     when this name is already taken, e.g. by a definition
+//   - $refs to definitions become their named type
+//
+// Formats select the Go type of scalars: date-time is a time.Time, int32, int64 and the other sized integers
+// are the integers of this size, float is a float32, byte is a []byte. Other strings, such as uuid, are strings
+// unless configured with [GoModelOptions.FormatTypes]. String properties with a numeric format, such as those
+// generated by [SchemaFor] for fields tagged with the "string" option, are numbers tagged with this option.
+//
+// Optional properties are pointers and omitted when empty. Required properties are values, and are never omitted.
+// Slices, maps and interfaces are never pointers. The x-nullable extension, or the nullable keyword, makes
+// a property a pointer even when required, or a value when set to false. The x-omitempty extension overrides
+// whether the property is omitted when empty.
+//
+// The source is formatted with gofmt. It fails when a $ref is not a local definition, or when the names of
+// definitions collide once turned into Go identifiers.
+func GenerateGoModels(definitions Definitions, options *GoModelOptions) ([]byte, error) {
+	if options == nil {
+		options = &GoModelOptions{}
+	}
+
+	g := &goModels{
+		options:     options,
+		definitions: definitions,
+		names:       make(map[string]string),
+		identifiers: make(map[string]bool),
+		imports:     make(map[string]bool),
+	}
+
+	declared := make(map[string]string)
+	for _, name := range mapKeysSorted(definitions) {
+		goName := goIdentifier(name)
+		if other, exists := declared[goName]; exists {
+			return nil, fmt.Errorf("definitions %q and %q both generate the Go type %s: %w", other, name, goName, ErrSpec)
+		}
+		declared[goName] = name
+		g.names[name] = goName
+		g.identifiers[goName] = true
+	}
+
+	var body strings.Builder
+	for _, goName := range mapKeysSorted(declared) {
+		name := declared[goName]
+		schema := definitions[name]
+		g.extra = nil
+		typ, constants, err := g.declaredType(&schema, g.names[name], joinPointer("/definitions", name))
+		if err != nil {
+			return nil, err
+		}
+
+		body.WriteString("\n")
+		writeGoComment(&body, g.names[name], schema)
+		fmt.Fprintf(&body, "type %s %s\n", g.names[name], typ)
+		if constants != "" {
+			body.WriteString("\n" + constants)
+		}
+		for _, extra := range g.extra {
+			body.WriteString("\n")
+			body.WriteString(extra)
+		}
+	}
+
+	var source strings.Builder
+	source.WriteString("// Code generated from a swagger specification. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&source, "package %s\n", cmp.Or(options.Package, "models"))
+	if len(g.imports) > 0 {
+		source.WriteString("\nimport (\n")
+		for _, path := range mapKeysSorted(g.imports) {
+			fmt.Fprintf(&source, "\t%q\n", path)
+		}
+		source.WriteString(")\n")
+	}
+	source.WriteString(body.String())
+
+	formatted, err := format.Source([]byte(source.String()))
+	if err != nil {
+		return nil, fmt.Errorf("cannot format the generated models: %w: %w", err, ErrSpec)
+	}
+
+	return formatted, nil
+}
+
+// goModels holds the state of the generation of Go models.
+type goModels struct {
+	options     *GoModelOptions
+	definitions Definitions
+
+	// names are the Go names of definitions
+	names map[string]string
+
+	// identifiers are the Go identifiers declared so far: types of definitions, enum types and constants
+	identifiers map[string]bool
+	imports     map[string]bool
+
+	// extra are the declarations of the enum types found while generating a definition
+	extra []string
+}
+
+// declaredType returns the Go type declared for a named schema, and the declaration of its constants
+// when the schema is an enum.
+func (g *goModels) declaredType(schema *Schema, name, ptr string) (string, string, error) {
+	if len(schema.Enum) == 0 || schema.Ref.String() != "" {
+		typ, err := g.goType(schema, name, ptr)
+
+		return typ, "", err
+	}
+
+	scalar := *schema
+	scalar.Enum = nil
+	typ, err := g.goType(&scalar, name, ptr)
+	if err != nil {
+		return "", "", err
+	}
+
+	var b strings.Builder
+	for _, value := range schema.Enum {
+		literal, ok := goLiteral(value, typ)
+		if !ok {
+			continue
+		}
+
+		constant := g.identifier(name + goName(fmt.Sprint(value)))
+		fmt.Fprintf(&b, "\t%s %s = %s\n", constant, name, literal)
+	}
+
+	if b.Len() == 0 {
+		return typ, "", nil
+	}
+
+	return typ, "const (\n" + b.String() + ")\n", nil
+}
+
+// goType returns the Go type of a schema. The name is used for the enums it declares.
+func (g *goModels) goType(schema *Schema, name, ptr string) (string, error) {
+	if ref := schema.Ref.String(); ref != "" {
+		return g.refType(ref, ptr)
+	}
+
+	if len(schema.AllOf) > 0 || len(schema.Properties) > 0 {
+		return g.structType(schema, name, ptr)
+	}
+
+	if len(schema.Type) != 1 {
+		return "any", nil
+	}
+
+	if typ, ok := g.options.FormatTypes[schema.Format]; ok && schema.Format != "" {
+		if typ.Import != "" {
+			g.imports[typ.Import] = true
+		}
+
+		return typ.Name, nil
+	}
+
+	switch schema.Type[0] {
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			g.imports["time"] = true
+
+			return "time.Time", nil
+		case "byte":
+			return "[]byte", nil
+		default:
+			return "string", nil
+		}
+	case "integer":
+		switch schema.Format {
+		case "int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64":
+			return schema.Format, nil
+		default:
+			return "int64", nil
+		}
+	case "number":
+		if schema.Format == "float" {
+			return "float32", nil
+		}
+
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case jsonArray:
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return "[]any", nil
+		}
+		items, err := g.itemType(schema.Items.Schema, name+"Items", joinPointer(ptr, "items"))
+		if err != nil {
+			return "", err
+		}
+
+		return "[]" + items, nil
+	case "object":
+		additional := schema.AdditionalProperties
+		if additional == nil || additional.Schema == nil {
+			return "map[string]any", nil
+		}
+		values, err := g.itemType(additional.Schema, name+"Value", joinPointer(ptr, "additionalProperties"))
+		if err != nil {
+			return "", err
+		}
+
+		return "map[string]" + values, nil
+	default:
+		return "any", nil
+	}
+}
+
+// itemType returns the Go type of the items of an array, the values of a map or a property.
+// Enums are declared as named types.
+func (g *goModels) itemType(schema *Schema, name, ptr string) (string, error) {
+	if len(schema.Enum) == 0 || schema.Ref.String() != "" {
+		return g.goType(schema, name, ptr)
+	}
+
+	name = g.identifier(name)
+	typ, constants, err := g.declaredType(schema, name, ptr)
+	if err != nil {
+		return "", err
+	}
+
+	declaration := fmt.Sprintf("type %s %s\n", name, typ)
+	if constants != "" {
+		declaration += "\n" + constants
+	}
+	g.extra = append(g.extra, declaration)
+
+	return name, nil
+}
+
+// identifier declares a new Go identifier, with a numeric suffix when the name is already declared.
+func (g *goModels) identifier(name string) string {
+	unique := name
+	for n := 2; g.identifiers[unique]; n++ {
+		unique = name + strconv.Itoa(n)
+	}
+	g.identifiers[unique] = true
+
+	return unique
+}
+
+// refType returns the named type of a $ref to a definition.
+func (g *goModels) refType(ref, ptr string) (string, error) {
+	definition, ok := strings.CutPrefix(ref, "#/definitions/")
+	if ok {
+		if goName, exists := g.names[jsonpointer.Unescape(definition)]; exists {
+			return goName, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported $ref %q at %s: only local definitions are supported: %w", ref, ptr, ErrSpec)
+}
+
+// structType returns the struct type of an object, with its allOf members embedded or merged.
+func (g *goModels) structType(schema *Schema, name, ptr string) (string, error) {
+	var b strings.Builder
+	b.WriteString("struct {\n")
+	fields := make(map[string]bool)
+
+	for i, member := range schema.AllOf {
+		memberPtr := joinPointer(ptr, "allOf", strconv.Itoa(i))
+		if member.Ref.String() != "" {
+			embedded, err := g.refType(member.Ref.String(), memberPtr)
+			if err != nil {
+				return "", err
+			}
+			fields[embedded] = true
+			fmt.Fprintf(&b, "\t%s\n", embedded)
+
+			continue
+		}
+
+		if err := g.writeFields(&b, &member, name, memberPtr, fields); err != nil {
+			return "", err
+		}
+	}
+
+	if err := g.writeFields(&b, schema, name, ptr, fields); err != nil {
+		return "", err
+	}
+	b.WriteString("}")
+
+	return b.String(), nil
+}
+
+// writeFields writes the fields of the properties of an object, in alphabetical order.
+//
+// The names of the fields already written are used to keep field names unique.
+func (g *goModels) writeFields(b *strings.Builder, schema *Schema, name, ptr string, fields map[string]bool) error {
+	for property, prop := range mapEntriesSorted(schema.Properties) {
+		field := goIdentifier(property)
+		for n := 2; fields[field]; n++ {
+			field = goIdentifier(property) + strconv.Itoa(n)
+		}
+		fields[field] = true
+
+		typ, quoted := g.quotedType(&prop)
+		if !quoted {
+			var err error
+			if typ, err = g.itemType(&prop, name+field, joinPointer(ptr, "properties", property)); err != nil {
+				return err
+			}
+		}
+
+		required := slices.Contains(schema.Required, property)
+		pointer := !required
+		if nullable, ok := prop.Extensions.GetBool("x-nullable"); ok {
+			pointer = nullable
+		}
+		pointer = (pointer || prop.Nullable) && !g.isNilable(&prop, typ)
+
+		omitEmpty := !required
+		if omit, ok := prop.Extensions.GetBool("x-omitempty"); ok {
+			omitEmpty = omit
+		}
+
+		tag := property
+		if omitEmpty {
+			tag += ",omitempty"
+		}
+		if quoted {
+			tag += ",string"
+		}
+		if pointer {
+			typ = "*" + typ
+		}
+
+		writeGoComment(b, field, prop)
+		fmt.Fprintf(b, "\t%s %s `json:%q`\n", field, typ, tag)
+	}
+
+	return nil
+}
+
+// quotedType returns the Go type of a string property holding a number, such as a string with the int64 format,
+// which is encoded as a JSON string with the "string" option of its tag.
+func (g *goModels) quotedType(schema *Schema) (string, bool) {
+	if len(schema.Type) != 1 || schema.Type[0] != "string" || schema.Ref.String() != "" || len(schema.Enum) > 0 {
+		return "", false
+	}
+	if _, ok := g.options.FormatTypes[schema.Format]; ok {
+		return "", false
+	}
+
+	switch schema.Format {
+	case "int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64":
+		return schema.Format, true
+	case "float":
+		return "float32", true
+	case "double":
+		return "float64", true
+	default:
+		return "", false
+	}
+}
+
+// isNilable tells if the Go type of a schema has a nil value already.
+func (g *goModels) isNilable(schema *Schema, typ string) bool {
+	if strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[") || typ == "any" {
+		return true
+	}
+
+	definition, ok := strings.CutPrefix(schema.Ref.String(), "#/definitions/")
+	if !ok {
+		return false
+	}
+	target, ok := g.definitions[jsonpointer.Unescape(definition)]
+	if !ok || len(target.AllOf) > 0 || len(target.Properties) > 0 || target.Ref.String() != "" {
+		return false
+	}
+
+	return len(target.Type) != 1 || target.Type[0] == jsonArray ||
+		(target.Type[0] == "object" && target.Format == "") ||
+		(target.Type[0] == "string" && target.Format == "byte")
+}
+
+// goLiteral returns the Go literal of an enum value for a type, or false when the value does not fit the type.
+func goLiteral(value any, typ string) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		if typ != "string" {
+			return "", false
+		}
+
+		return strconv.Quote(v), true
+	case float64:
+		switch {
+		case typ == "float32" || typ == "float64":
+			return strconv.FormatFloat(v, 'g', -1, 64), true
+		case strings.HasPrefix(typ, "int") || strings.HasPrefix(typ, "uint"):
+			if v != math.Trunc(v) {
+				return "", false
+			}
+
+			return strconv.FormatFloat(v, 'f', -1, 64), true
+		default:
+			return "", false
+		}
+	case bool:
+		if typ != "bool" {
+			return "", false
+		}
+
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
+
+// writeGoComment writes the title and the description of a schema as the doc comment of a Go declaration.
+func writeGoComment(b *strings.Builder, name string, schema Schema) {
+	text := strings.TrimSpace(strings.Join(slices.DeleteFunc([]string{schema.Title, schema.Description}, func(s string) bool {
+		return s == ""
+	}), "\n\n"))
+	if text == "" {
+		return
+	}
+
+	if !strings.HasPrefix(text, name+" ") {
+		text = name + " " + text
+	}
+
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 && line == "" {
+			b.WriteString("//\n")
+
+			continue
+		}
+		fmt.Fprintf(b, "// %s\n", strings.TrimRightFunc(line, unicode.IsSpace))
+	}
+}
+
+// goIdentifier turns a name into an exported Go identifier, e.g. "pet_id" yields "PetID".
+func goIdentifier(name string) string {
+	identifier := goName(name)
+	switch {
+	case identifier == "":
+		return "Empty"
+	case !unicode.IsLetter([]rune(identifier)[0]):
+		return "X" + identifier
+	default:
+		return identifier
+	}
+}
+
+// goName joins the words of a name in Pascal case, keeping initialisms in upper case.
+func goName(name string) string {
+	var b strings.Builder
+	for _, word := range splitWords(name) {
+		if goInitialisms[strings.ToLower(word)] {
+			b.WriteString(strings.ToUpper(word))
+
+			continue
+		}
+
+		runes := []rune(word)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+
+	return b.String()
+}
+
+// goInitialisms are the words written in upper case in Go identifiers.
+var goInitialisms = map[string]bool{ //nolint:gochecknoglobals // constant-like lookup table
+	"api": true, "html": true, "http": true, "https": true, "id": true, "ip": true, "json": true,
+	"sql": true, "uri": true, "url": true, "uuid": true, "xml": true,
+}
@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+const goModelsDefinitions = `{
+  "Pet": {
+    "description": "Pet is a pet of the store.",
+    "allOf": [
+      { "$ref": "#/definitions/audit" },
+      {
+        "type": "object",
+        "required": [ "id", "name" ],
+        "properties": {
+          "id": { "type": "string", "format": "uuid" },
+          "name": { "type": "string" },
+          "kind": { "type": "string", "enum": [ "cat", "dog" ] },
+          "tags": { "type": "array", "items": { "type": "string" } }
+        }
+      }
+    ]
+  },
+  "audit": {
+    "type": "object",
+    "required": [ "created_at", "owner" ],
+    "properties": {
+      "created_at": { "type": "string", "format": "date-time" },
+      "version": { "type": "integer", "format": "int32", "x-omitempty": false },
+      "owner": { "$ref": "#/definitions/Owner", "x-nullable": true },
+      "labels": { "$ref": "#/definitions/Labels" }
+    }
+  },
+  "Labels": { "type": "object", "additionalProperties": { "type": "string" } },
+  "Owner": {
+    "type": "object",
+    "properties": {
+      "score": { "type": "number", "format": "float" },
+      "count": { "type": "integer" },
+      "photo": { "type": "string", "format": "byte" },
+      "extra": {}
+    }
+  },
+  "Priority": { "type": "integer", "format": "int64", "enum": [ 1, 2, 3 ] }
+}`
+
+func TestGenerateGoModels(t *testing.T) {
+	var definitions Definitions
+	require.NoError(t, json.Unmarshal([]byte(goModelsDefinitions), &definitions))
+
+	t.Run("definitions should become Go types", func(t *testing.T) {
+		source, err := GenerateGoModels(definitions, nil)
+		require.NoError(t, err)
+		assert.EqualT(t, `// Code generated from a swagger specification. DO NOT EDIT.
+
+package models
+
+import (
+	"time"
+)
+
+type Audit struct {
+	CreatedAt time.Time `+"`json:\"created_at\"`"+`
+	Labels    Labels    `+"`json:\"labels,omitempty\"`"+`
+	Owner     *Owner    `+"`json:\"owner\"`"+`
+	Version   *int32    `+"`json:\"version\"`"+`
+}
+
+type Labels map[string]string
+
+type Owner struct {
+	Count *int64   `+"`json:\"count,omitempty\"`"+`
+	Extra any      `+"`json:\"extra,omitempty\"`"+`
+	Photo []byte   `+"`json:\"photo,omitempty\"`"+`
+	Score *float32 `+"`json:\"score,omitempty\"`"+`
+}
+
+// Pet is a pet of the store.
+type Pet struct {
+	Audit
+	ID   string   `+"`json:\"id\"`"+`
+	Kind *PetKind `+"`json:\"kind,omitempty\"`"+`
+	Name string   `+"`json:\"name\"`"+`
+	Tags []string `+"`json:\"tags,omitempty\"`"+`
+}
+
+type PetKind string
+
+const (
+	PetKindCat PetKind = "cat"
+	PetKindDog PetKind = "dog"
+)
+
+type Priority int64
+
+const (
+	Priority1 Priority = 1
+	Priority2 Priority = 2
+	Priority3 Priority = 3
+)
+`, string(source))
+	})
+
+	t.Run("formats should be configurable", func(t *testing.T) {
+		source, err := GenerateGoModels(definitions, &GoModelOptions{
+			Package:     "store",
+			FormatTypes: map[string]GoType{"uuid": {Name: "uuid.UUID", Import: "github.com/google/uuid"}},
+		})
+		require.NoError(t, err)
+		assert.StringContainsT(t, string(source), "package store\n")
+		assert.StringContainsT(t, string(source), "\t\"github.com/google/uuid\"\n")
+		assert.StringContainsT(t, string(source), "\tID   uuid.UUID")
+	})
+
+	t.Run("numbers encoded as strings should round-trip with SchemaFor", func(t *testing.T) {
+		schema, err := SchemaFor(reflect.TypeFor[struct {
+			Count int64    `json:"count,string"`
+			Ratio *float64 `json:"ratio,omitempty,string"`
+		}]())
+		require.NoError(t, err)
+
+		source, err := GenerateGoModels(Definitions{"Counter": *schema}, nil)
+		require.NoError(t, err)
+		assert.StringContainsT(t, string(source), "\tCount int64    `json:\"count,string\"`\n")
+		assert.StringContainsT(t, string(source), "\tRatio *float64 `json:\"ratio,omitempty,string\"`\n")
+	})
+
+	t.Run("enum types should not collide with definitions", func(t *testing.T) {
+		var colliding Definitions
+		require.NoError(t, json.Unmarshal([]byte(`{
+  "Color": { "type": "string", "enum": [ "red" ] },
+  "ColorRed": { "type": "string" },
+  "Pet": { "type": "object", "properties": { "status": { "type": "string", "enum": [ "sold" ] } } },
+  "PetStatus": { "type": "object", "properties": { "code": { "type": "integer" } } },
+  "PetStatusSold": { "type": "string" }
+}`), &colliding))
+
+		source, err := GenerateGoModels(colliding, nil)
+		require.NoError(t, err)
+		assert.EqualT(t, `// Code generated from a swagger specification. DO NOT EDIT.
+
+package models
+
+type Color string
+
+const (
+	ColorRed2 Color = "red"
+)
+
+type ColorRed string
+
+type Pet struct {
+	Status *PetStatus2 `+"`json:\"status,omitempty\"`"+`
+}
+
+type PetStatus2 string
+
+const (
+	PetStatus2Sold PetStatus2 = "sold"
+)
+
+type PetStatus struct {
+	Code *int64 `+"`json:\"code,omitempty\"`"+`
+}
+
+type PetStatusSold string
+`, string(source))
+	})
+
+	t.Run("unsupported definitions should fail", func(t *testing.T) {
+		_, err := GenerateGoModels(Definitions{"pet": *RefSchema("other.json#/definitions/pet")}, nil)
+		require.ErrorIs(t, err, ErrSpec)
+
+		_, err = GenerateGoModels(Definitions{"pet_id": *StringProperty(), "petId": *StringProperty()}, nil)
+		require.ErrorIs(t, err, ErrSpec)
+	})
+}
@@ -190,3 +190,10 @@ func (h Header) JSONLookup(token string) (any, error) {
 	r, _, err = jsonpointer.GetForToken(h.HeaderProps, token)
 	return r, err
 }
+
+// JSONSet sets a value by the json property name.
+//
+// Missing containers, such as extensions, are created.
+func (h *Header) JSONSet(token string, value any) error {
+	return jsonSetToken(h, token, value)
+}
@@ -151,6 +151,13 @@ func (i Info) JSONLookup(token string) (any, error) {
 	return r, err
 }
 
+// JSONSet sets a value by the json property name.
+//
+// Missing containers, such as extensions, are created.
+func (i *Info) JSONSet(token string, value any) error {
+	return jsonSetToken(i, token, value)
+}
+
 // MarshalJSON marshal this to JSON.
 func (i Info) MarshalJSON() ([]byte, error) {
 	b1, err := json.Marshal(i.InfoProps)
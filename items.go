@@ -221,3 +221,10 @@ func (i Items) JSONLookup(token string) (any, error) {
 	r, _, err = jsonpointer.GetForToken(i.SimpleSchema, token)
 	return r, err
 }
+
+// JSONSet sets a value by the json property name.
+//
+// Missing containers, such as extensions, are created.
+func (i *Items) JSONSet(token string, value any) error {
+	return jsonSetToken(i, token, value)
+}
@@ -0,0 +1,1541 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/go-openapi/jsonpointer"
+)
+
+// JSONPath is a compiled JSONPath expression, as specified by RFC 9535.
+//
+// Expressions are evaluated directly against the object model: nodes are resolved with the JSONLookup
+// methods of the types of this package, and members are enumerated in the order of their JSON representation.
+//
+// The syntax is that of RFC 9535, with two relaxations commonly found in linters such as Spectral:
+// member names may be used without quotes in brackets (e.g. "$.paths[*][get,post]"), and shorthand
+// member names may contain '-' and '$' (e.g. "$..$ref").
+//
+// The functions length(), count(), match(), search() and value() are supported in filters.
+type JSONPath struct {
+	expr     string
+	segments []jpSegment
+}
+
+// JSONPathMatch is a node selected by a [JSONPath] query.
+type JSONPathMatch struct {
+	// Pointer is the JSON pointer to the node, e.g. "/paths/~1pets/get"
+	Pointer string
+
+	// Path is the normalized path of the node, e.g. "$['paths']['/pets']['get']"
+	Path string
+
+	// Value is the node.
+	//
+	// Objects of the object model are returned as pointers to their type, e.g. *Operation or *Schema.
+	// Nodes with alternative forms are returned in the form they take in JSON: a $ref is a string,
+	// an additionalProperties node is either a *Schema or a bool, etc. Other nodes are slices, maps or scalars.
+	//
+	// Values must be considered read-only: use [SetByPointer] with the Pointer to modify the spec.
+	Value any
+}
+
+// ParseJSONPath compiles a JSONPath expression.
+func ParseJSONPath(expr string) (*JSONPath, error) {
+	p := &jpParser{expr: expr}
+	if !p.consume('$') {
+		return nil, p.errorf("an expression must start with '$'")
+	}
+
+	segments, err := p.parseSegments()
+	if err != nil {
+		return nil, err
+	}
+	if !p.eof() {
+		return nil, p.errorf("unexpected character %q", p.peek())
+	}
+
+	return &JSONPath{expr: expr, segments: segments}, nil
+}
+
+// QueryJSONPath evaluates a JSONPath expression against a spec.
+func QueryJSONPath(spec *Swagger, expr string) ([]JSONPathMatch, error) {
+	path, err := ParseJSONPath(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return path.Query(spec), nil
+}
+
+// String returns the expression.
+func (p *JSONPath) String() string {
+	return p.expr
+}
+
+// Query evaluates the expression against a document of the object model, such as a *Swagger or a *Schema.
+//
+// Matches are returned in the order defined by RFC 9535.
+func (p *JSONPath) Query(document any) []JSONPathMatch {
+	root := &jpNode{value: jpNormalize(document)}
+	ctx := &jpContext{root: root, regexps: make(map[string]*regexp.Regexp)}
+
+	nodes := ctx.apply(p.segments, []*jpNode{root})
+	matches := make([]JSONPathMatch, 0, len(nodes))
+	for _, n := range nodes {
+		matches = append(matches, JSONPathMatch{Pointer: n.pointer(), Path: n.path(), Value: n.value})
+	}
+
+	return matches
+}
+
+// jpNode is a node of a document, with its location.
+type jpNode struct {
+	value  any
+	parent *jpNode
+	token  string
+	index  int // position in an array, or -1 for object members
+
+	children []*jpNode
+	expanded bool
+	shape    *jpShape // the JSON representation of the node, when known from its parent
+}
+
+func (n *jpNode) isArray() bool {
+	return n.value != nil && reflect.TypeOf(n.value).Kind() == reflect.Slice
+}
+
+func (n *jpNode) isObject() bool {
+	if n.value == nil {
+		return false
+	}
+	t := reflect.TypeOf(n.value)
+
+	return t.Kind() == reflect.Map || (t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct)
+}
+
+// members returns the children of an object or an array.
+func (n *jpNode) members() []*jpNode {
+	if n.expanded {
+		return n.children
+	}
+	n.expanded = true
+
+	if n.value == nil {
+		return nil
+	}
+
+	rv := reflect.ValueOf(n.value)
+	switch rv.Kind() {
+	case reflect.Slice:
+		for i := range rv.Len() {
+			n.children = append(n.children, &jpNode{
+				value: jpNormalize(rv.Index(i).Interface()), parent: n, token: strconv.Itoa(i), index: i, shape: n.shape.item(i),
+			})
+		}
+	case reflect.Map:
+		keys := rv.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			if a.Kind() == reflect.String {
+				return strings.Compare(a.String(), b.String())
+			}
+
+			return cmp.Compare(a.Int(), b.Int())
+		})
+		for _, key := range keys {
+			token := fmt.Sprint(key.Interface())
+			n.children = append(n.children, &jpNode{
+				value: jpNormalize(rv.MapIndex(key).Interface()), parent: n, token: token, index: -1, shape: n.shape.member(token),
+			})
+		}
+	case reflect.Pointer:
+		n.children = jpStructMembers(n)
+	default:
+	}
+
+	return n.children
+}
+
+// member returns the member of an object with a given name.
+func (n *jpNode) member(name string) (*jpNode, bool) {
+	if !n.isObject() {
+		return nil, false
+	}
+
+	for _, child := range n.members() {
+		if child.token == name {
+			return child, true
+		}
+	}
+
+	return nil, false
+}
+
+func (n *jpNode) pointer() string {
+	if n.parent == nil {
+		return ""
+	}
+
+	return n.parent.pointer() + "/" + jsonpointer.Escape(n.token)
+}
+
+func (n *jpNode) path() string {
+	if n.parent == nil {
+		return "$"
+	}
+	if n.index >= 0 {
+		return n.parent.path() + "[" + n.token + "]"
+	}
+
+	return n.parent.path() + "['" + jpEscapeName(n.token) + "']"
+}
+
+// jpStructMembers lists the members of a type of the object model, in the order of its JSON representation.
+//
+// Members are resolved with JSONLookup. Members which cannot be resolved this way are taken from JSON.
+//
+// The JSON representation of a node is marshaled once, when its shape is not known from its parent:
+// its members inherit their shapes from it, so that walking a document marshals it only once.
+func jpStructMembers(n *jpNode) []*jpNode {
+	if n.shape == nil {
+		buf, err := json.Marshal(n.value)
+		if err != nil {
+			return nil
+		}
+		if n.shape, err = jpDecodeShape(json.NewDecoder(bytes.NewReader(buf))); err != nil {
+			return nil
+		}
+	}
+
+	members := make([]*jpNode, 0, len(n.shape.keys))
+	for _, key := range n.shape.keys {
+		shape := n.shape.members[key]
+		value, _, err := jsonpointer.GetForToken(n.value, key)
+		value = jpNormalize(value)
+		if err != nil || (value == nil && shape.value != nil) {
+			value = shape.value
+		}
+
+		members = append(members, &jpNode{value: value, parent: n, token: key, index: -1, shape: shape})
+	}
+
+	return members
+}
+
+// jpShape is the JSON representation of a node: its generic value, with the names of its members in order.
+type jpShape struct {
+	value   any
+	keys    []string
+	members map[string]*jpShape // members of an object
+	items   []*jpShape          // items of an array
+}
+
+// member returns the shape of the member of an object, or nil when it is unknown.
+func (s *jpShape) member(name string) *jpShape {
+	if s == nil {
+		return nil
+	}
+	return s.members[name]
+}
+
+// item returns the shape of the item of an array, or nil when it is unknown.
+func (s *jpShape) item(index int) *jpShape {
+	if s == nil || index >= len(s.items) {
+		return nil
+	}
+
+	return s.items[index]
+}
+
+// jpDecodeShape decodes the next JSON value of a decoder, in a single pass.
+func jpDecodeShape(dec *json.Decoder) (*jpShape, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		shape := &jpShape{members: make(map[string]*jpShape)}
+		object := make(map[string]any)
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := tok.(string)
+			member, err := jpDecodeShape(dec)
+			if err != nil {
+				return nil, err
+			}
+			if _, duplicate := shape.members[key]; !duplicate {
+				shape.keys = append(shape.keys, key)
+			}
+			shape.members[key] = member
+			object[key] = member.value
+		}
+		shape.value = object
+
+		_, err := dec.Token()
+
+		return shape, err
+	case json.Delim('['):
+		shape := &jpShape{}
+		array := make([]any, 0)
+		for dec.More() {
+			item, err := jpDecodeShape(dec)
+			if err != nil {
+				return nil, err
+			}
+			shape.items = append(shape.items, item)
+			array = append(array, item.value)
+		}
+		shape.value = array
+
+		_, err := dec.Token()
+
+		return shape, err
+	default:
+		return &jpShape{value: tok}, nil
+	}
+}
+
+// jpNormalize returns a node in the form it takes in JSON.
+//
+// Objects of the object model are returned as pointers, other pointers are dereferenced.
+func jpNormalize(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case *any:
+		if v == nil {
+			return nil
+		}
+
+		return jpNormalize(*v)
+	case Ref:
+		return v.String()
+	case StringOrArray:
+		if len(v) == 1 {
+			return v[0]
+		}
+
+		return []string(v)
+	case SchemaOrBool:
+		if v.Schema != nil {
+			return v.Schema
+		}
+
+		return v.Allows
+	case SchemaOrArray:
+		if v.Schema != nil {
+			return v.Schema
+		}
+
+		return v.Schemas
+	case SchemaOrStringArray:
+		if v.Schema != nil {
+			return v.Schema
+		}
+
+		return v.Property
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return nil
+		}
+		if rv.Elem().Kind() == reflect.Struct {
+			switch rv.Elem().Type() {
+			case typeOfRef, reflect.TypeFor[SchemaOrBool](), reflect.TypeFor[SchemaOrArray](), reflect.TypeFor[SchemaOrStringArray]():
+				return jpNormalize(rv.Elem().Interface())
+			}
+
+			return value
+		}
+
+		return jpNormalize(rv.Elem().Interface())
+	case reflect.Struct:
+		ptr := reflect.New(rv.Type())
+		ptr.Elem().Set(rv)
+
+		return ptr.Interface()
+	case reflect.Slice, reflect.Map:
+		if rv.IsNil() {
+			return nil
+		}
+
+		return value
+	default:
+		return value
+	}
+}
+
+func jpEscapeName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch r {
+		case '\'':
+			b.WriteString(`\'`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04x`, r)
+
+				continue
+			}
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// jpContext holds the state of an evaluation.
+type jpContext struct {
+	root    *jpNode
+	regexps map[string]*regexp.Regexp
+}
+
+func (ctx *jpContext) apply(segments []jpSegment, nodes []*jpNode) []*jpNode {
+	for _, segment := range segments {
+		var selected []*jpNode
+		for _, n := range nodes {
+			if segment.descendant {
+				selected = ctx.descend(segment, n, selected)
+
+				continue
+			}
+			selected = segment.selectFrom(ctx, n, selected)
+		}
+		nodes = selected
+	}
+
+	return nodes
+}
+
+// descend applies a descendant segment to a node and to all its descendants, in document order.
+func (ctx *jpContext) descend(segment jpSegment, n *jpNode, selected []*jpNode) []*jpNode {
+	selected = segment.selectFrom(ctx, n, selected)
+	if !n.isObject() && !n.isArray() {
+		return selected
+	}
+
+	for _, child := range n.members() {
+		selected = ctx.descend(segment, child, selected)
+	}
+
+	return selected
+}
+
+// regexp compiles an I-Regexp (RFC 9485), or returns nil if the pattern is invalid.
+func (ctx *jpContext) regexp(pattern string, anchored bool) *regexp.Regexp {
+	key := pattern
+	if anchored {
+		key = "^" + pattern
+	}
+	if re, ok := ctx.regexps[key]; ok {
+		return re
+	}
+
+	translated := jpTranslateRegexp(pattern)
+	if anchored {
+		translated = `\A(?:` + translated + `)\z`
+	}
+	re, err := regexp.Compile(translated)
+	if err != nil {
+		re = nil
+	}
+	ctx.regexps[key] = re
+
+	return re
+}
+
+// jpTranslateRegexp adapts an I-Regexp to the syntax of the regexp package:
+// in I-Regexp, '.' matches any character but line terminators.
+func jpTranslateRegexp(pattern string) string {
+	var b strings.Builder
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\\' && i+1 < len(pattern):
+			b.WriteByte(c)
+			i++
+			b.WriteByte(pattern[i])
+		case c == '[':
+			inClass = true
+			b.WriteByte(c)
+		case c == ']':
+			inClass = false
+			b.WriteByte(c)
+		case c == '.' && !inClass:
+			b.WriteString(`[^\n\r]`)
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
+
+// jpSegment is a child segment, or a descendant segment, with its selectors.
+type jpSegment struct {
+	descendant bool
+	selectors  []jpSelector
+}
+
+func (s jpSegment) selectFrom(ctx *jpContext, n *jpNode, selected []*jpNode) []*jpNode {
+	for _, selector := range s.selectors {
+		selected = selector.selectFrom(ctx, n, selected)
+	}
+
+	return selected
+}
+
+// singular tells if a segment selects at most one node.
+func (s jpSegment) singular() bool {
+	if s.descendant || len(s.selectors) != 1 {
+		return false
+	}
+
+	switch s.selectors[0].(type) {
+	case jpName, jpIndex:
+		return true
+	default:
+		return false
+	}
+}
+
+type jpSelector interface {
+	selectFrom(ctx *jpContext, n *jpNode, selected []*jpNode) []*jpNode
+}
+
+type jpName string
+
+func (s jpName) selectFrom(_ *jpContext, n *jpNode, selected []*jpNode) []*jpNode {
+	if child, ok := n.member(string(s)); ok {
+		selected = append(selected, child)
+	}
+
+	return selected
+}
+
+type jpWildcard struct{}
+
+func (jpWildcard) selectFrom(_ *jpContext, n *jpNode, selected []*jpNode) []*jpNode {
+	if !n.isObject() && !n.isArray() {
+		return selected
+	}
+
+	return append(selected, n.members()...)
+}
+
+type jpIndex int
+
+func (s jpIndex) selectFrom(_ *jpContext, n *jpNode, selected []*jpNode) []*jpNode {
+	if !n.isArray() {
+		return selected
+	}
+
+	elements := n.members()
+	i := int(s)
+	if i < 0 {
+		i += len(elements)
+	}
+	if i < 0 || i >= len(elements) {
+		return selected
+	}
+
+	return append(selected, elements[i])
+}
+
+type jpSlice struct {
+	start, end, step *int
+}
+
+func (s jpSlice) selectFrom(_ *jpContext, n *jpNode, selected []*jpNode) []*jpNode {
+	if !n.isArray() {
+		return selected
+	}
+
+	elements := n.members()
+	length := len(elements)
+	step := 1
+	if s.step != nil {
+		step = *s.step
+	}
+	if step == 0 {
+		return selected
+	}
+
+	normalize := func(i int) int {
+		if i < 0 {
+			return length + i
+		}
+
+		return i
+	}
+
+	if step > 0 {
+		start, end := 0, length
+		if s.start != nil {
+			start = min(max(normalize(*s.start), 0), length)
+		}
+		if s.end != nil {
+			end = min(max(normalize(*s.end), 0), length)
+		}
+		for i := start; i < end; i += step {
+			selected = append(selected, elements[i])
+		}
+
+		return selected
+	}
+
+	start, end := length-1, -1
+	if s.start != nil {
+		start = min(max(normalize(*s.start), -1), length-1)
+	}
+	if s.end != nil {
+		end = min(max(normalize(*s.end), -1), length-1)
+	}
+	for i := start; i > end; i += step {
+		selected = append(selected, elements[i])
+	}
+
+	return selected
+}
+
+type jpFilter struct {
+	expr jpExpr
+}
+
+func (s jpFilter) selectFrom(ctx *jpContext, n *jpNode, selected []*jpNode) []*jpNode {
+	if !n.isObject() && !n.isArray() {
+		return selected
+	}
+
+	for _, child := range n.members() {
+		if s.expr.test(ctx, child) {
+			selected = append(selected, child)
+		}
+	}
+
+	return selected
+}
+
+// jpQuery is a query embedded in a filter, relative to the current node (@) or to the root ($).
+type jpQuery struct {
+	relative bool
+	segments []jpSegment
+}
+
+func (q *jpQuery) nodes(ctx *jpContext, current *jpNode) []*jpNode {
+	start := ctx.root
+	if q.relative {
+		start = current
+	}
+
+	return ctx.apply(q.segments, []*jpNode{start})
+}
+
+func (q *jpQuery) singular() bool {
+	for _, segment := range q.segments {
+		if !segment.singular() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// jpExpr is a logical expression of a filter.
+type jpExpr interface {
+	test(ctx *jpContext, current *jpNode) bool
+}
+
+type jpOr []jpExpr
+
+func (e jpOr) test(ctx *jpContext, current *jpNode) bool {
+	for _, operand := range e {
+		if operand.test(ctx, current) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type jpAnd []jpExpr
+
+func (e jpAnd) test(ctx *jpContext, current *jpNode) bool {
+	for _, operand := range e {
+		if !operand.test(ctx, current) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type jpNot struct {
+	expr jpExpr
+}
+
+func (e jpNot) test(ctx *jpContext, current *jpNode) bool {
+	return !e.expr.test(ctx, current)
+}
+
+// jpExists tests that a query selects at least one node.
+type jpExists struct {
+	query *jpQuery
+}
+
+func (e jpExists) test(ctx *jpContext, current *jpNode) bool {
+	return len(e.query.nodes(ctx, current)) > 0
+}
+
+// jpFunctionTest tests the result of a function returning a logical value.
+type jpFunctionTest struct {
+	fn *jpFunction
+}
+
+func (e jpFunctionTest) test(ctx *jpContext, current *jpNode) bool {
+	return e.fn.test(ctx, current)
+}
+
+type jpComparison struct {
+	op          string
+	left, right jpOperand
+}
+
+func (e jpComparison) test(ctx *jpContext, current *jpNode) bool {
+	left, lok := e.left.value(ctx, current)
+	right, rok := e.right.value(ctx, current)
+
+	equal := func() bool {
+		if !lok || !rok {
+			return lok == rok
+		}
+
+		return jpEqual(left, right)
+	}
+	less := func(a, b any) bool {
+		return lok && rok && jpLess(a, b)
+	}
+
+	switch e.op {
+	case "==":
+		return equal()
+	case "!=":
+		return !equal()
+	case "<":
+		return less(left, right)
+	case "<=":
+		return less(left, right) || equal()
+	case ">":
+		return less(right, left)
+	case ">=":
+		return less(right, left) || equal()
+	default:
+		return false
+	}
+}
+
+// jpOperand is a literal, a query or a function call.
+type jpOperand struct {
+	literal   any
+	isLiteral bool
+	query     *jpQuery
+	fn        *jpFunction
+}
+
+// value evaluates an operand to a single value. The boolean is false when the operand has no value.
+func (o jpOperand) value(ctx *jpContext, current *jpNode) (any, bool) {
+	switch {
+	case o.isLiteral:
+		return o.literal, true
+	case o.query != nil:
+		nodes := o.query.nodes(ctx, current)
+		if len(nodes) != 1 {
+			return nil, false
+		}
+
+		return nodes[0].value, true
+	default:
+		return o.fn.value(ctx, current)
+	}
+}
+
+// jpType is the type of a function parameter or result.
+type jpType int
+
+const (
+	jpValueType jpType = iota
+	jpLogicalType
+	jpNodesType
+)
+
+// jpSignature returns the parameters and the result type of a function.
+func jpSignature(name string) ([]jpType, jpType, bool) {
+	switch name {
+	case "length":
+		return []jpType{jpValueType}, jpValueType, true
+	case "count":
+		return []jpType{jpNodesType}, jpValueType, true
+	case "value":
+		return []jpType{jpNodesType}, jpValueType, true
+	case "match", "search":
+		return []jpType{jpValueType, jpValueType}, jpLogicalType, true
+	default:
+		return nil, 0, false
+	}
+}
+
+type jpFunction struct {
+	name   string
+	args   []jpOperand
+	result jpType
+}
+
+func (f *jpFunction) value(ctx *jpContext, current *jpNode) (any, bool) {
+	switch f.name {
+	case "length":
+		arg, ok := f.args[0].value(ctx, current)
+		if !ok {
+			return nil, false
+		}
+
+		return jpLength(arg)
+	case "count":
+		return len(f.args[0].query.nodes(ctx, current)), true
+	case "value":
+		nodes := f.args[0].query.nodes(ctx, current)
+		if len(nodes) != 1 {
+			return nil, false
+		}
+
+		return nodes[0].value, true
+	default:
+		return nil, false
+	}
+}
+
+func (f *jpFunction) test(ctx *jpContext, current *jpNode) bool {
+	subject, ok := f.args[0].value(ctx, current)
+	if !ok {
+		return false
+	}
+	pattern, ok := f.args[1].value(ctx, current)
+	if !ok {
+		return false
+	}
+	str, isString := subject.(string)
+	expr, isPattern := pattern.(string)
+	if !isString || !isPattern {
+		return false
+	}
+
+	re := ctx.regexp(expr, f.name == "match")
+	if re == nil {
+		return false
+	}
+
+	return re.MatchString(str)
+}
+
+func jpLength(value any) (any, bool) {
+	switch v := value.(type) {
+	case string:
+		return utf8.RuneCountInString(v), true
+	case nil:
+		return nil, false
+	}
+
+	n := &jpNode{value: value}
+	if !n.isArray() && !n.isObject() {
+		return nil, false
+	}
+
+	return len(n.members()), true
+}
+
+// jpJSON returns the generic JSON form of a value.
+func jpJSON(value any) any {
+	switch value.(type) {
+	case nil, bool, string, float64, float32, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return value
+	}
+
+	generic, err := toJSONValue(reflect.ValueOf(value))
+	if err != nil {
+		return value
+	}
+
+	return generic
+}
+
+func jpEqual(a, b any) bool {
+	return equalJSON(jpJSON(a), jpJSON(b))
+}
+
+func jpLess(a, b any) bool {
+	if sa, ok := a.(string); ok {
+		sb, ok := b.(string)
+
+		return ok && sa < sb
+	}
+
+	ra, ok := jsonNumber(a)
+	if !ok {
+		return false
+	}
+	rb, ok := jsonNumber(b)
+	if !ok {
+		return false
+	}
+
+	return ra.Cmp(rb) < 0
+}
+
+// jpParser parses JSONPath expressions.
+type jpParser struct {
+	expr string
+	pos  int
+}
+
+func (p *jpParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid JSONPath %q at offset %d: %s: %w", p.expr, p.pos, fmt.Sprintf(format, args...), ErrJSONPath)
+}
+
+func (p *jpParser) eof() bool {
+	return p.pos >= len(p.expr)
+}
+
+func (p *jpParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+
+	return p.expr[p.pos]
+}
+
+func (p *jpParser) consume(c byte) bool {
+	if p.peek() == c && !p.eof() {
+		p.pos++
+
+		return true
+	}
+
+	return false
+}
+
+func (p *jpParser) consumeString(s string) bool {
+	if strings.HasPrefix(p.expr[p.pos:], s) {
+		p.pos += len(s)
+
+		return true
+	}
+
+	return false
+}
+
+func (p *jpParser) skipSpace() {
+	for !p.eof() {
+		switch p.peek() {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *jpParser) parseSegments() ([]jpSegment, error) {
+	var segments []jpSegment
+	for {
+		start := p.pos
+		p.skipSpace()
+		if p.peek() != '.' && p.peek() != '[' {
+			p.pos = start
+
+			return segments, nil
+		}
+
+		segment, err := p.parseSegment()
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, segment)
+	}
+}
+
+func (p *jpParser) parseSegment() (jpSegment, error) {
+	var segment jpSegment
+	if p.consumeString("..") {
+		segment.descendant = true
+		if p.peek() == '[' {
+			selectors, err := p.parseBracket()
+			segment.selectors = selectors
+
+			return segment, err
+		}
+	} else if !p.consume('.') {
+		selectors, err := p.parseBracket()
+		segment.selectors = selectors
+
+		return segment, err
+	}
+
+	if p.consume('*') {
+		segment.selectors = []jpSelector{jpWildcard{}}
+
+		return segment, nil
+	}
+
+	name, ok := p.parseShorthandName()
+	if !ok {
+		return segment, p.errorf("expected a member name or '*'")
+	}
+	segment.selectors = []jpSelector{jpName(name)}
+
+	return segment, nil
+}
+
+func (p *jpParser) parseShorthandName() (string, bool) {
+	start := p.pos
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.expr[p.pos:])
+		first := p.pos == start
+		if !isJSONPathNameChar(r, first) {
+			break
+		}
+		p.pos += size
+	}
+
+	return p.expr[start:p.pos], p.pos > start
+}
+
+func isJSONPathNameChar(r rune, first bool) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_', r == '$', r >= 0x80:
+		return true
+	case r >= '0' && r <= '9', r == '-':
+		return !first
+	default:
+		return false
+	}
+}
+
+func (p *jpParser) parseBracket() ([]jpSelector, error) {
+	if !p.consume('[') {
+		return nil, p.errorf("expected '['")
+	}
+
+	var selectors []jpSelector
+	for {
+		p.skipSpace()
+		selector, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+
+		p.skipSpace()
+		switch {
+		case p.consume(','):
+		case p.consume(']'):
+			return selectors, nil
+		default:
+			return nil, p.errorf("expected ',' or ']'")
+		}
+	}
+}
+
+func (p *jpParser) parseSelector() (jpSelector, error) {
+	c := p.peek()
+	switch {
+	case c == '\'' || c == '"':
+		name, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+
+		return jpName(name), nil
+	case c == '*':
+		p.pos++
+
+		return jpWildcard{}, nil
+	case c == '?':
+		p.pos++
+		p.skipSpace()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		return jpFilter{expr: expr}, nil
+	case c == '-' || c == ':' || (c >= '0' && c <= '9'):
+		return p.parseIndexOrSlice()
+	default:
+		if name, ok := p.parseShorthandName(); ok {
+			return jpName(name), nil
+		}
+
+		return nil, p.errorf("unexpected character %q", c)
+	}
+}
+
+func (p *jpParser) parseIndexOrSlice() (jpSelector, error) {
+	start, err := p.parseOptionalInt()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if !p.consume(':') {
+		if start == nil {
+			return nil, p.errorf("expected an index")
+		}
+
+		return jpIndex(*start), nil
+	}
+
+	slice := jpSlice{start: start}
+	p.skipSpace()
+	if slice.end, err = p.parseOptionalInt(); err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.consume(':') {
+		p.skipSpace()
+		if slice.step, err = p.parseOptionalInt(); err != nil {
+			return nil, err
+		}
+	}
+
+	return slice, nil
+}
+
+const jpMaxInt = 1<<53 - 1 // the range of integers in I-JSON
+
+func (p *jpParser) parseOptionalInt() (*int, error) {
+	start := p.pos
+	p.consume('-')
+	digits := p.pos
+	for p.peek() >= '0' && p.peek() <= '9' {
+		p.pos++
+	}
+
+	literal := p.expr[start:p.pos]
+	switch {
+	case p.pos == digits && p.pos == start:
+		return nil, nil //nolint:nilnil // no integer is not an error
+	case p.pos == digits,
+		literal == "-0",
+		p.pos-digits > 1 && p.expr[digits] == '0':
+		return nil, p.errorf("invalid integer %q", literal)
+	}
+
+	i, err := strconv.Atoi(literal)
+	if err != nil || i > jpMaxInt || i < -jpMaxInt {
+		return nil, p.errorf("integer %q is out of range", literal)
+	}
+
+	return &i, nil
+}
+
+// parseString parses a string literal in single or double quotes.
+func (p *jpParser) parseString() (string, error) {
+	quote := p.peek()
+	p.pos++
+
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated string")
+		}
+
+		c := p.peek()
+		switch {
+		case c == quote:
+			p.pos++
+
+			return b.String(), nil
+		case c == '\\':
+			p.pos++
+			if err := p.parseEscape(&b, quote); err != nil {
+				return "", err
+			}
+		case c < 0x20:
+			return "", p.errorf("control characters must be escaped in strings")
+		default:
+			r, size := utf8.DecodeRuneInString(p.expr[p.pos:])
+			b.WriteRune(r)
+			p.pos += size
+		}
+	}
+}
+
+func (p *jpParser) parseEscape(b *strings.Builder, quote byte) error {
+	c := p.peek()
+	p.pos++
+
+	switch c {
+	case quote, '\\', '/':
+		b.WriteByte(c)
+	case 'b':
+		b.WriteByte('\b')
+	case 'f':
+		b.WriteByte('\f')
+	case 'n':
+		b.WriteByte('\n')
+	case 'r':
+		b.WriteByte('\r')
+	case 't':
+		b.WriteByte('\t')
+	case 'u':
+		r, err := p.parseHex()
+		if err != nil {
+			return err
+		}
+		if utf16.IsSurrogate(r) {
+			if !p.consumeString(`\u`) {
+				return p.errorf("invalid surrogate pair")
+			}
+			low, err := p.parseHex()
+			if err != nil {
+				return err
+			}
+			r = utf16.DecodeRune(r, low)
+			if r == utf8.RuneError {
+				return p.errorf("invalid surrogate pair")
+			}
+		}
+		b.WriteRune(r)
+	default:
+		return p.errorf("invalid escape sequence")
+	}
+
+	return nil
+}
+
+func (p *jpParser) parseHex() (rune, error) {
+	if p.pos+4 > len(p.expr) {
+		return 0, p.errorf("invalid unicode escape")
+	}
+	r, err := strconv.ParseUint(p.expr[p.pos:p.pos+4], 16, 32)
+	if err != nil {
+		return 0, p.errorf("invalid unicode escape")
+	}
+	p.pos += 4
+
+	return rune(r), nil
+}
+
+func (p *jpParser) parseOr() (jpExpr, error) {
+	var operands jpOr
+	for {
+		operand, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+
+		p.skipSpace()
+		if !p.consumeString("||") {
+			break
+		}
+		p.skipSpace()
+	}
+
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+
+	return operands, nil
+}
+
+func (p *jpParser) parseAnd() (jpExpr, error) {
+	var operands jpAnd
+	for {
+		operand, err := p.parseBasic()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+
+		p.skipSpace()
+		if !p.consumeString("&&") {
+			break
+		}
+		p.skipSpace()
+	}
+
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+
+	return operands, nil
+}
+
+func (p *jpParser) parseBasic() (jpExpr, error) {
+	if p.consume('!') {
+		p.skipSpace()
+		if p.peek() != '(' {
+			operand, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			test, err := p.testOf(operand)
+			if err != nil {
+				return nil, err
+			}
+
+			return jpNot{expr: test}, nil
+		}
+
+		expr, err := p.parseParen()
+		if err != nil {
+			return nil, err
+		}
+
+		return jpNot{expr: expr}, nil
+	}
+
+	if p.peek() == '(' {
+		return p.parseParen()
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	op := p.parseComparisonOperator()
+	if op == "" {
+		return p.testOf(left)
+	}
+
+	p.skipSpace()
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkComparable(left); err != nil {
+		return nil, err
+	}
+	if err := p.checkComparable(right); err != nil {
+		return nil, err
+	}
+
+	return jpComparison{op: op, left: left, right: right}, nil
+}
+
+func (p *jpParser) parseParen() (jpExpr, error) {
+	p.pos++ // (
+	p.skipSpace()
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if !p.consume(')') {
+		return nil, p.errorf("expected ')'")
+	}
+
+	return expr, nil
+}
+
+func (p *jpParser) parseComparisonOperator() string {
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consumeString(op) {
+			return op
+		}
+	}
+
+	return ""
+}
+
+// testOf returns the test expression for an operand used without comparison.
+func (p *jpParser) testOf(operand jpOperand) (jpExpr, error) {
+	switch {
+	case operand.query != nil:
+		return jpExists{query: operand.query}, nil
+	case operand.fn != nil && operand.fn.result == jpLogicalType:
+		return jpFunctionTest{fn: operand.fn}, nil
+	case operand.fn != nil:
+		return nil, p.errorf("the result of %s() must be compared", operand.fn.name)
+	default:
+		return nil, p.errorf("a literal must be compared")
+	}
+}
+
+func (p *jpParser) checkComparable(operand jpOperand) error {
+	switch {
+	case operand.query != nil && !operand.query.singular():
+		return p.errorf("only singular queries may be compared")
+	case operand.fn != nil && operand.fn.result != jpValueType:
+		return p.errorf("the result of %s() cannot be compared", operand.fn.name)
+	default:
+		return nil
+	}
+}
+
+// parseOperand parses a literal, a query or a function call.
+func (p *jpParser) parseOperand() (jpOperand, error) {
+	c := p.peek()
+	switch {
+	case c == '@' || c == '$':
+		p.pos++
+		segments, err := p.parseSegments()
+		if err != nil {
+			return jpOperand{}, err
+		}
+
+		return jpOperand{query: &jpQuery{relative: c == '@', segments: segments}}, nil
+	case c == '\'' || c == '"':
+		str, err := p.parseString()
+		if err != nil {
+			return jpOperand{}, err
+		}
+
+		return jpOperand{literal: str, isLiteral: true}, nil
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.parseNumber()
+	case c >= 'a' && c <= 'z':
+		return p.parseKeywordOrFunction()
+	default:
+		return jpOperand{}, p.errorf("unexpected character %q", c)
+	}
+}
+
+func (p *jpParser) parseNumber() (jpOperand, error) {
+	start := p.pos
+	p.consume('-')
+	digits := p.pos
+	for p.peek() >= '0' && p.peek() <= '9' {
+		p.pos++
+	}
+	if p.pos == digits || (p.pos-digits > 1 && p.expr[digits] == '0') {
+		return jpOperand{}, p.errorf("invalid number")
+	}
+	if p.consume('.') {
+		fraction := p.pos
+		for p.peek() >= '0' && p.peek() <= '9' {
+			p.pos++
+		}
+		if p.pos == fraction {
+			return jpOperand{}, p.errorf("invalid number")
+		}
+	}
+	if p.consume('e') || p.consume('E') {
+		if !p.consume('-') {
+			p.consume('+')
+		}
+		exponent := p.pos
+		for p.peek() >= '0' && p.peek() <= '9' {
+			p.pos++
+		}
+		if p.pos == exponent {
+			return jpOperand{}, p.errorf("invalid number")
+		}
+	}
+
+	f, err := strconv.ParseFloat(p.expr[start:p.pos], 64)
+	if err != nil {
+		return jpOperand{}, p.errorf("invalid number")
+	}
+
+	return jpOperand{literal: f, isLiteral: true}, nil
+}
+
+func (p *jpParser) parseKeywordOrFunction() (jpOperand, error) {
+	start := p.pos
+	for (p.peek() >= 'a' && p.peek() <= 'z') || (p.peek() >= '0' && p.peek() <= '9') || p.peek() == '_' {
+		p.pos++
+	}
+	name := p.expr[start:p.pos]
+
+	switch name {
+	case "true":
+		return jpOperand{literal: true, isLiteral: true}, nil
+	case "false":
+		return jpOperand{literal: false, isLiteral: true}, nil
+	case "null":
+		return jpOperand{literal: nil, isLiteral: true}, nil
+	}
+
+	if !p.consume('(') {
+		return jpOperand{}, p.errorf("unexpected name %q", name)
+	}
+
+	params, result, ok := jpSignature(name)
+	if !ok {
+		return jpOperand{}, p.errorf("unknown function %s()", name)
+	}
+
+	fn := &jpFunction{name: name, result: result}
+	for i, param := range params {
+		p.skipSpace()
+		if i > 0 && !p.consume(',') {
+			return jpOperand{}, p.errorf("%s() expects %d arguments", name, len(params))
+		}
+		p.skipSpace()
+
+		arg, err := p.parseOperand()
+		if err != nil {
+			return jpOperand{}, err
+		}
+		if err := p.checkArgument(fn, param, arg); err != nil {
+			return jpOperand{}, err
+		}
+		fn.args = append(fn.args, arg)
+	}
+
+	p.skipSpace()
+	if !p.consume(')') {
+		return jpOperand{}, p.errorf("%s() expects %d arguments", name, len(params))
+	}
+
+	return jpOperand{fn: fn}, nil
+}
+
+func (p *jpParser) checkArgument(fn *jpFunction, param jpType, arg jpOperand) error {
+	switch param {
+	case jpNodesType:
+		if arg.query == nil {
+			return p.errorf("%s() expects a query", fn.name)
+		}
+	default:
+		return p.checkComparable(arg)
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+const jsonPathSpec = `{
+  "swagger": "2.0",
+  "info": { "title": "pets", "version": "1.0", "x-audience": "public" },
+  "tags": [ { "name": "pets" }, { "name": "stores" }, { "name": "admin" } ],
+  "paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        "tags": [ "pets" ],
+        "parameters": [
+          { "name": "limit", "in": "query", "type": "integer", "maximum": 100 },
+          { "name": "tag", "in": "query", "type": "string", "required": true }
+        ],
+        "responses": {
+          "200": { "description": "ok", "schema": { "type": "array", "items": { "$ref": "#/definitions/Pet" } } },
+          "default": { "description": "" }
+        }
+      },
+      "post": {
+        "operationId": "createPet",
+        "responses": { "201": { "description": "" } }
+      },
+      "delete": {
+        "responses": { "204": { "description": "" } }
+      }
+    }
+  },
+  "definitions": {
+    "Pet": {
+      "type": "object",
+      "required": [ "name" ],
+      "additionalProperties": false,
+      "properties": {
+        "name": { "type": "string", "pattern": "^[a-z]+$" },
+        "owner": { "$ref": "#/definitions/Owner" }
+      }
+    },
+    "Owner": { "type": "object", "properties": { "email": { "type": "string", "format": "email" } } }
+  }
+}`
+
+func jsonPathPointers(matches []JSONPathMatch) []string {
+	pointers := make([]string, 0, len(matches))
+	for _, m := range matches {
+		pointers = append(pointers, m.Pointer)
+	}
+
+	return pointers
+}
+
+func TestQueryJSONPath(t *testing.T) {
+	sp := mustSpec(t, jsonPathSpec)
+
+	for _, tc := range []struct {
+		expr     string
+		pointers []string
+	}{
+		{"$", []string{""}},
+		{"$.info.title", []string{"/info/title"}},
+		{"$.info['x-audience']", []string{"/info/x-audience"}},
+		{"$.paths['/pets'].*.operationId", []string{"/paths/~1pets/get/operationId", "/paths/~1pets/post/operationId"}},
+		{
+			"$.paths[*][get,post].responses[?(@.description == '')]",
+			[]string{"/paths/~1pets/get/responses/default", "/paths/~1pets/post/responses/201"},
+		},
+		{"$.tags[-1].name", []string{"/tags/2/name"}},
+		{"$.tags[0:2].name", []string{"/tags/0/name", "/tags/1/name"}},
+		{"$.tags[::-1].name", []string{"/tags/2/name", "/tags/1/name", "/tags/0/name"}},
+		{"$.tags[?@.name == 'admin' || @.name == 'pets']", []string{"/tags/0", "/tags/2"}},
+		{"$..$ref", []string{
+			"/paths/~1pets/get/responses/200/schema/items/$ref",
+			"/definitions/Pet/properties/owner/$ref",
+		}},
+		{"$..parameters[?@.required]", []string{"/paths/~1pets/get/parameters/1"}},
+		{"$..parameters[?!@.required].name", []string{"/paths/~1pets/get/parameters/0/name"}},
+		{"$..parameters[?@.maximum >= 100 && @.type == 'integer']", []string{"/paths/~1pets/get/parameters/0"}},
+		{"$.paths.*[?count(@.responses.*) == 1 && !@.operationId]", []string{"/paths/~1pets/delete"}},
+		{"$.paths.*[?length(@.parameters) > 1]", []string{"/paths/~1pets/get"}},
+		{"$.definitions[?@.additionalProperties == false]", []string{"/definitions/Pet"}},
+		{"$.definitions.*.properties[?match(@.format, 'e.*l')]", []string{"/definitions/Owner/properties/email"}},
+		{"$.definitions.*.properties[?search(@.pattern, 'a-z')]", []string{"/definitions/Pet/properties/name"}},
+		{"$.definitions[?value(@.required[0]) == 'name']", []string{"/definitions/Pet"}},
+		{"$.definitions[?@.type == $.definitions.Owner.type]", []string{"/definitions/Owner", "/definitions/Pet"}},
+		{"$.paths.*.get.responses['200'].schema.items", []string{"/paths/~1pets/get/responses/200/schema/items"}},
+		{"$.missing", []string{}},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			matches, err := QueryJSONPath(sp, tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.pointers, jsonPathPointers(matches))
+		})
+	}
+}
+
+func TestQueryJSONPath_TypedNodes(t *testing.T) {
+	sp := mustSpec(t, jsonPathSpec)
+
+	t.Run("objects should be returned with their type", func(t *testing.T) {
+		matches, err := QueryJSONPath(sp, "$.paths['/pets'].get")
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		op, ok := matches[0].Value.(*Operation)
+		require.TrueT(t, ok)
+		assert.EqualT(t, "listPets", op.ID)
+		assert.EqualT(t, "$['paths']['/pets']['get']", matches[0].Path)
+
+		matches, err = QueryJSONPath(sp, "$..properties.*")
+		require.NoError(t, err)
+		require.Len(t, matches, 3)
+		for _, m := range matches {
+			_, ok := m.Value.(*Schema)
+			assert.TrueT(t, ok)
+		}
+	})
+
+	t.Run("alternative forms should be returned as in JSON", func(t *testing.T) {
+		matches, err := QueryJSONPath(sp, "$.definitions.Pet['additionalProperties','type']")
+		require.NoError(t, err)
+		require.Len(t, matches, 2)
+		assert.Equal(t, false, matches[0].Value)
+		assert.Equal(t, "object", matches[1].Value)
+
+		matches, err = QueryJSONPath(sp, "$.paths.*.get.parameters[0].maximum")
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		assert.Equal(t, float64(100), matches[0].Value)
+		assert.EqualT(t, "$['paths']['/pets']['get']['parameters'][0]['maximum']", matches[0].Path)
+	})
+
+	t.Run("pointers should resolve to the matched nodes", func(t *testing.T) {
+		matches, err := QueryJSONPath(sp, "$..[?@.type == 'string']")
+		require.NoError(t, err)
+		require.NotEmpty(t, matches)
+		for _, m := range matches {
+			require.NoError(t, SetByPointer(sp, m.Pointer+"/minLength", 1))
+		}
+		assert.NotNil(t, sp.Definitions["Owner"].Properties["email"].MinLength)
+		assert.NotNil(t, sp.Paths.Paths["/pets"].Get.Parameters[1].MinLength)
+	})
+}
+
+func TestParseJSONPath_Errors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"paths",
+		"$.",
+		"$[",
+		"$['unterminated",
+		"$[01]",
+		"$[-0]",
+		"$[?@.a == ]",
+		"$[?@.* == 1]",
+		"$[?'literal']",
+		"$[?length(@.a)]",
+		"$[?unknown(@)]",
+		"$[?count(1) == 1]",
+		"$[?match(@.a)]",
+		"$[?(@.a]",
+		"$.a b",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := ParseJSONPath(expr)
+			require.ErrorIs(t, err, ErrJSONPath)
+		})
+	}
+}
+
+func TestJSONPath_NormalizedPaths(t *testing.T) {
+	path, err := ParseJSONPath(`$["a'b"]["é\n"]`)
+	require.NoError(t, err)
+
+	matches := path.Query(map[string]any{"a'b": map[string]any{"é\n": 1}})
+	require.Len(t, matches, 1)
+	assert.EqualT(t, `$['a\'b']['é\n']`, matches[0].Path)
+	assert.EqualT(t, "/a'b/é\n", matches[0].Pointer)
+	assert.Equal(t, 1, matches[0].Value)
+}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"iter"
+	"slices"
+)
+
+// mapKeysUnion returns the sorted union of the keys of two maps.
+func mapKeysUnion[M ~map[K]V, K interface{ ~string | ~int }, V any](a, b M) []K {
+	keys := make([]K, 0, max(len(a), len(b)))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	return keys
+}
+
+// mapKeysSorted returns the sorted keys of a map.
+func mapKeysSorted[M ~map[K]V, K interface{ ~string | ~int }, V any](m M) []K {
+	return mapKeysUnion(m, nil)
+}
+
+// mapEntriesSorted iterates over the entries of a map, by key.
+func mapEntriesSorted[M ~map[K]V, K interface{ ~string | ~int }, V any](m M) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, key := range mapKeysSorted(m) {
+			if !yield(key, m[key]) {
+				return
+			}
+		}
+	}
+}
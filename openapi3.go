@@ -0,0 +1,901 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+)
+
+// OpenAPI3Version is the version of the OpenAPI documents produced by [ConvertToOpenAPI3].
+const OpenAPI3Version = "3.0.3"
+
+// ConversionWarning reports a part of a spec which has no exact equivalent in OpenAPI 3.
+type ConversionWarning struct {
+	// Pointer is the JSON pointer of the part in the Swagger 2.0 spec, e.g. "/paths/~1pets/get/parameters/0"
+	Pointer string
+
+	// Message describes how the part has been converted, or why it has been dropped
+	Message string
+}
+
+// String returns the message of the warning, prefixed by its location.
+func (w ConversionWarning) String() string {
+	ptr := w.Pointer
+	if ptr == "" {
+		ptr = "/"
+	}
+
+	return ptr + ": " + w.Message
+}
+
+// ConvertToOpenAPI3 converts a spec to an OpenAPI 3.0.3 JSON document.
+//
+// The conversion follows the correspondences between both versions of the specification:
+//   - host, basePath and schemes become servers. Operations with their own schemes get their own servers
+//   - definitions, parameters, responses and securityDefinitions move to components, as schemas, parameters
+//     (or requestBodies for body parameters), responses and securitySchemes
+//   - body and formData parameters become request bodies, with a media type for each type consumed
+//   - response schemas and examples become response contents, with a media type for each type produced
+//   - $refs to the global body parameters and responses are inlined in the operations which override
+//     the types consumed or produced by the spec, since components only know the types of the spec
+//   - OAuth2 flows become the corresponding flows: implicit, password, clientCredentials or authorizationCode
+//   - $refs are rewritten to the location of their target in the converted document, including the $refs to
+//     other documents, which are expected to be converted as well
+//   - within schemas, x-nullable becomes nullable, discriminators become objects and files become binary strings
+//
+// Consumed and produced types default to application/json, or to a form for formData parameters.
+// The parts which cannot be converted exactly, such as JSON schema keywords unknown to OpenAPI 3
+// or tab separated parameters, are converted on a best effort basis or dropped, and reported as warnings.
+func ConvertToOpenAPI3(spec *Swagger) ([]byte, []ConversionWarning, error) {
+	if spec == nil {
+		return nil, nil, fmt.Errorf("cannot convert a nil spec: %w", ErrSpec)
+	}
+
+	c := &openAPI3Converter{spec: spec}
+	doc, err := c.document()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot marshal the OpenAPI 3 document: %w: %w", err, ErrSpec)
+	}
+
+	return data, c.warnings, nil
+}
+
+// openAPI3Converter holds the state of the conversion of a spec to OpenAPI 3.
+//
+// The OpenAPI 3 document is built as generic JSON values.
+type openAPI3Converter struct {
+	spec     *Swagger
+	warnings []ConversionWarning
+}
+
+func (c *openAPI3Converter) warn(ptr, format string, args ...any) {
+	c.warnings = append(c.warnings, ConversionWarning{Pointer: ptr, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *openAPI3Converter) document() (map[string]any, error) {
+	sp := c.spec
+	doc := map[string]any{"openapi": OpenAPI3Version}
+	copyExtensions(doc, sp.Extensions)
+
+	info := any(map[string]any{"title": "", "version": ""})
+	if sp.Info != nil {
+		var err error
+		if info, err = genericJSON(sp.Info); err != nil {
+			return nil, err
+		}
+	}
+	doc["info"] = info
+
+	if sp.ID != "" {
+		c.warn("/id", "the id of the spec has no equivalent and is dropped")
+	}
+	if servers := c.servers("/schemes", sp.Schemes); servers != nil {
+		doc["servers"] = servers
+	}
+	if sp.Security != nil {
+		doc["security"] = sp.Security
+	}
+	if sp.Tags != nil {
+		doc["tags"] = sp.Tags
+	}
+	if sp.ExternalDocs != nil {
+		doc["externalDocs"] = sp.ExternalDocs
+	}
+
+	components, err := c.components()
+	if err != nil {
+		return nil, err
+	}
+	if len(components) > 0 {
+		doc["components"] = components
+	}
+
+	paths := map[string]any{}
+	if sp.Paths != nil {
+		copyExtensions(paths, sp.Paths.Extensions)
+		for path, item := range mapEntriesSorted(sp.Paths.Paths) {
+			converted, err := c.pathItem(path, &item)
+			if err != nil {
+				return nil, err
+			}
+			paths[path] = converted
+		}
+	}
+	doc["paths"] = paths
+
+	return doc, nil
+}
+
+// servers builds the servers of a list of schemes, at the host and base path of the spec.
+//
+// Without schemes, the URL is relative to the scheme of the document. Without a host, the URL is relative
+// to the document, and the schemes, found at ptr, are dropped. It returns nil when the spec locates no server.
+func (c *openAPI3Converter) servers(ptr string, schemes []string) []any {
+	host, basePath := c.spec.Host, c.spec.BasePath
+	if host == "" && basePath == "" && len(schemes) == 0 {
+		return nil
+	}
+
+	if basePath == "" && host != "" {
+		basePath = "/"
+	}
+
+	if host == "" {
+		// without a host, servers are relative to the document
+		if len(schemes) > 0 {
+			c.warn(ptr, "the schemes %s require a host, and are dropped", strings.Join(schemes, ", "))
+		}
+		if basePath == "" {
+			basePath = "/"
+		}
+
+		return []any{map[string]any{"url": basePath}}
+	}
+
+	if len(schemes) == 0 {
+		return []any{map[string]any{"url": "//" + host + basePath}}
+	}
+
+	servers := make([]any, 0, len(schemes))
+	for _, scheme := range schemes {
+		servers = append(servers, map[string]any{"url": scheme + "://" + host + basePath})
+	}
+
+	return servers
+}
+
+func (c *openAPI3Converter) components() (map[string]any, error) {
+	sp := c.spec
+	components := map[string]any{}
+
+	if len(sp.Definitions) > 0 {
+		schemas := map[string]any{}
+		for name, schema := range mapEntriesSorted(sp.Definitions) {
+			converted, err := c.schema(&schema, joinPointer("/definitions", name))
+			if err != nil {
+				return nil, err
+			}
+			schemas[name] = converted
+		}
+		components["schemas"] = schemas
+	}
+
+	parameters, requestBodies := map[string]any{}, map[string]any{}
+	for name, param := range mapEntriesSorted(sp.Parameters) {
+		ptr := joinPointer("/parameters", name)
+		switch param.In {
+		case "body":
+			body, err := c.requestBody([]Parameter{param}, sp.Consumes, ptr)
+			if err != nil {
+				return nil, err
+			}
+			requestBodies[name] = body
+		case "formData":
+			c.warn(ptr, "formData parameters have no equivalent in components, and are inlined in the request bodies of the operations using them")
+		default:
+			converted, err := c.parameter(&param, ptr)
+			if err != nil {
+				return nil, err
+			}
+			parameters[name] = converted
+		}
+	}
+	if len(parameters) > 0 {
+		components["parameters"] = parameters
+	}
+	if len(requestBodies) > 0 {
+		components["requestBodies"] = requestBodies
+	}
+
+	if len(sp.Responses) > 0 {
+		responses := map[string]any{}
+		for name, response := range mapEntriesSorted(sp.Responses) {
+			converted, err := c.response(&response, sp.Produces, joinPointer("/responses", name))
+			if err != nil {
+				return nil, err
+			}
+			responses[name] = converted
+		}
+		components["responses"] = responses
+	}
+
+	if len(sp.SecurityDefinitions) > 0 {
+		schemes := map[string]any{}
+		for name, scheme := range mapEntriesSorted(sp.SecurityDefinitions) {
+			if scheme == nil {
+				continue
+			}
+			schemes[name] = c.securityScheme(scheme, joinPointer("/securityDefinitions", name))
+		}
+		components["securitySchemes"] = schemes
+	}
+
+	return components, nil
+}
+
+func (c *openAPI3Converter) securityScheme(scheme *SecurityScheme, ptr string) map[string]any {
+	out := map[string]any{}
+	copyExtensions(out, scheme.Extensions)
+	if scheme.Description != "" {
+		out["description"] = scheme.Description
+	}
+
+	switch scheme.Type {
+	case "basic":
+		out["type"] = "http"
+		out["scheme"] = "basic"
+	case "apiKey":
+		out["type"] = "apiKey"
+		out["name"] = scheme.Name
+		out["in"] = scheme.In
+	case "oauth2":
+		out["type"] = "oauth2"
+		scopes := map[string]any{}
+		for name, description := range scheme.Scopes {
+			scopes[name] = description
+		}
+
+		flow := map[string]any{"scopes": scopes}
+		var name string
+		switch scheme.Flow {
+		case "implicit":
+			name = "implicit"
+			flow["authorizationUrl"] = scheme.AuthorizationURL
+		case "password":
+			name = "password"
+			flow["tokenUrl"] = scheme.TokenURL
+		case "application":
+			name = "clientCredentials"
+			flow["tokenUrl"] = scheme.TokenURL
+		case "accessCode":
+			name = "authorizationCode"
+			flow["authorizationUrl"] = scheme.AuthorizationURL
+			flow["tokenUrl"] = scheme.TokenURL
+		default:
+			c.warn(joinPointer(ptr, "flow"), "unknown OAuth2 flow %q, the security scheme has no flow", scheme.Flow)
+		}
+
+		flows := map[string]any{}
+		if name != "" {
+			flows[name] = flow
+		}
+		out["flows"] = flows
+	default:
+		c.warn(joinPointer(ptr, "type"), "unknown security scheme type %q is kept as is", scheme.Type)
+		out["type"] = scheme.Type
+	}
+
+	return out
+}
+
+func (c *openAPI3Converter) pathItem(path string, item *PathItem) (map[string]any, error) {
+	ptr := joinPointer("/paths", path)
+	out := map[string]any{}
+	copyExtensions(out, item.Extensions)
+
+	if ref := item.Ref.String(); ref != "" {
+		c.warn(ptr, "the $ref of the path item %q is kept as is, its target is not converted", ref)
+		out["$ref"] = ref
+	}
+
+	var (
+		parameters []any
+		bodies     []Parameter
+	)
+	for i, param := range item.Parameters {
+		paramPtr := joinPointer(ptr, "parameters", strconv.Itoa(i))
+		resolved, isBody := c.bodyParameter(&param)
+		if isBody {
+			bodies = append(bodies, resolved)
+
+			continue
+		}
+
+		converted, err := c.parameter(&param, paramPtr)
+		if err != nil {
+			return nil, err
+		}
+		parameters = append(parameters, converted)
+	}
+	if len(parameters) > 0 {
+		out["parameters"] = parameters
+	}
+
+	for method, op := range item.Operations() {
+		converted, err := c.operation(op, bodies, joinPointer(ptr, method))
+		if err != nil {
+			return nil, err
+		}
+		out[method] = converted
+	}
+
+	return out, nil
+}
+
+func (c *openAPI3Converter) operation(op *Operation, pathBodies []Parameter, ptr string) (map[string]any, error) {
+	out := map[string]any{}
+	copyExtensions(out, op.Extensions)
+	if op.Tags != nil {
+		out["tags"] = op.Tags
+	}
+	if op.Summary != "" {
+		out["summary"] = op.Summary
+	}
+	if op.Description != "" {
+		out["description"] = op.Description
+	}
+	if op.ID != "" {
+		out["operationId"] = op.ID
+	}
+	if op.ExternalDocs != nil {
+		out["externalDocs"] = op.ExternalDocs
+	}
+	if op.Deprecated {
+		out["deprecated"] = true
+	}
+	if op.Security != nil {
+		out["security"] = op.Security
+	}
+	if op.Schemes != nil && !slices.Equal(op.Schemes, c.spec.Schemes) {
+		if servers := c.servers(joinPointer(ptr, "schemes"), op.Schemes); servers != nil {
+			out["servers"] = servers
+		}
+	}
+
+	var (
+		parameters []any
+		bodies     []Parameter
+		bodyRef    string
+	)
+	for i, param := range op.Parameters {
+		paramPtr := joinPointer(ptr, "parameters", strconv.Itoa(i))
+		resolved, isBody := c.bodyParameter(&param)
+		if !isBody {
+			converted, err := c.parameter(&param, paramPtr)
+			if err != nil {
+				return nil, err
+			}
+			parameters = append(parameters, converted)
+
+			continue
+		}
+
+		// global body parameters are request bodies of the components, which consume the types of the spec
+		if name, ok := strings.CutPrefix(param.Ref.String(), "#/parameters/"); ok && resolved.In == "body" && op.Consumes == nil {
+			bodyRef = "#/components/requestBodies/" + name
+		}
+		bodies = append(bodies, resolved)
+	}
+	if len(parameters) > 0 {
+		out["parameters"] = parameters
+	}
+
+	// body and formData parameters of the path item apply, unless overridden
+	for _, param := range pathBodies {
+		overridden := slices.ContainsFunc(bodies, func(p Parameter) bool {
+			return p.In == param.In && (p.In == "body" || p.Name == param.Name)
+		})
+		if !overridden {
+			bodies = append(bodies, param)
+		}
+	}
+
+	switch {
+	case bodyRef != "" && len(bodies) == 1:
+		out["requestBody"] = map[string]any{"$ref": bodyRef}
+	case len(bodies) > 0:
+		consumes := op.Consumes
+		if consumes == nil {
+			consumes = c.spec.Consumes
+		}
+		body, err := c.requestBody(bodies, consumes, joinPointer(ptr, "parameters"))
+		if err != nil {
+			return nil, err
+		}
+		out["requestBody"] = body
+	}
+
+	produces := op.Produces
+	if produces == nil {
+		produces = c.spec.Produces
+	}
+	responses, err := c.responses(op.Responses, produces, joinPointer(ptr, "responses"))
+	if err != nil {
+		return nil, err
+	}
+	out["responses"] = responses
+
+	return out, nil
+}
+
+// bodyParameter resolves a parameter when it is a body or a formData parameter, defined inline or
+// by a $ref to the parameters of the spec.
+func (c *openAPI3Converter) bodyParameter(param *Parameter) (Parameter, bool) {
+	resolved := *param
+	if name, ok := strings.CutPrefix(param.Ref.String(), "#/parameters/"); ok {
+		if target, exists := c.spec.Parameters[jsonpointer.Unescape(name)]; exists {
+			resolved = target
+		}
+	}
+
+	return resolved, resolved.In == "body" || resolved.In == "formData"
+}
+
+// requestBody builds the request body of body or formData parameters.
+func (c *openAPI3Converter) requestBody(params []Parameter, consumes []string, ptr string) (map[string]any, error) {
+	out := map[string]any{}
+	var body *Parameter
+	form := map[string]any{"type": "object"}
+	properties := map[string]any{}
+	var required []string
+	hasFile := false
+
+	for i := range params {
+		param := &params[i]
+		if param.In == "body" {
+			if body != nil {
+				c.warn(ptr, "only one body parameter is allowed, the body parameter %q is dropped", param.Name)
+
+				continue
+			}
+			body = param
+
+			continue
+		}
+
+		schema, err := c.parameterSchema(param, ptr)
+		if err != nil {
+			return nil, err
+		}
+		if param.Description != "" {
+			schema["description"] = param.Description
+		}
+		properties[param.Name] = schema
+		if param.Required {
+			required = append(required, param.Name)
+		}
+		hasFile = hasFile || param.Type == "file"
+	}
+
+	if body != nil {
+		if len(properties) > 0 {
+			c.warn(ptr, "body and formData parameters cannot be mixed, formData parameters are dropped")
+		}
+		copyExtensions(out, body.Extensions)
+		if body.Description != "" {
+			out["description"] = body.Description
+		}
+		if body.Required {
+			out["required"] = true
+		}
+		if body.Name != "" {
+			out["x-codegen-request-body-name"] = body.Name
+		}
+
+		var schema any = map[string]any{}
+		if body.Schema != nil {
+			var err error
+			if schema, err = c.schema(body.Schema, ptr); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(consumes) == 0 {
+			consumes = []string{"application/json"}
+		}
+		content := map[string]any{}
+		for _, mediaType := range consumes {
+			content[mediaType] = map[string]any{"schema": schema}
+		}
+		out["content"] = content
+
+		return out, nil
+	}
+
+	form["properties"] = properties
+	if len(required) > 0 {
+		form["required"] = required
+		out["required"] = true
+	}
+
+	var mediaTypes []string
+	for _, mediaType := range consumes {
+		if mediaType == "multipart/form-data" || mediaType == "application/x-www-form-urlencoded" {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{"application/x-www-form-urlencoded"}
+		if hasFile {
+			mediaTypes = []string{"multipart/form-data"}
+		}
+	}
+
+	content := map[string]any{}
+	for _, mediaType := range mediaTypes {
+		content[mediaType] = map[string]any{"schema": form}
+	}
+	out["content"] = content
+
+	return out, nil
+}
+
+// parameter converts a parameter which is neither a body nor a formData parameter.
+func (c *openAPI3Converter) parameter(param *Parameter, ptr string) (map[string]any, error) {
+	if ref := param.Ref.String(); ref != "" {
+		return map[string]any{"$ref": c.ref(ref, ptr)}, nil
+	}
+
+	out := map[string]any{"name": param.Name, "in": param.In}
+	copyExtensions(out, param.Extensions)
+	if param.Description != "" {
+		out["description"] = param.Description
+	}
+	if param.Required {
+		out["required"] = true
+	}
+	if param.AllowEmptyValue {
+		out["allowEmptyValue"] = true
+	}
+
+	schema, err := c.parameterSchema(param, ptr)
+	if err != nil {
+		return nil, err
+	}
+	out["schema"] = schema
+
+	if param.Type == jsonArray {
+		collectionFormat := param.CollectionFormat
+		switch {
+		case collectionFormat == "multi" && (param.In == "query" || param.In == "formData"):
+			out["style"], out["explode"] = "form", true
+		case collectionFormat == "ssv" && param.In == "query":
+			out["style"], out["explode"] = "spaceDelimited", false
+		case collectionFormat == "pipes" && param.In == "query":
+			out["style"], out["explode"] = "pipeDelimited", false
+		case collectionFormat == "" || collectionFormat == "csv":
+			if param.In == "query" || param.In == "cookie" {
+				out["style"], out["explode"] = "form", false
+			} else {
+				out["style"], out["explode"] = "simple", false
+			}
+		default:
+			c.warn(joinPointer(ptr, "collectionFormat"), "the collection format %q of a %s parameter has no equivalent, and is dropped",
+				collectionFormat, param.In)
+		}
+	}
+
+	return out, nil
+}
+
+// parameterSchema builds the schema of a parameter which is not a body parameter, from its type and validations.
+func (c *openAPI3Converter) parameterSchema(param *Parameter, ptr string) (map[string]any, error) {
+	if param.Type == "file" && param.In != "formData" {
+		c.warn(joinPointer(ptr, "type"), "file parameters are only supported as formData parameters")
+	}
+
+	return c.simpleSchema(param.SimpleSchema, param.CommonValidations, ptr)
+}
+
+// simpleSchema builds the schema of a parameter, a header or the items of an array.
+func (c *openAPI3Converter) simpleSchema(simple SimpleSchema, validations CommonValidations, ptr string) (map[string]any, error) {
+	simple.CollectionFormat = ""
+	schema, err := genericJSONObject(validations)
+	if err != nil {
+		return nil, err
+	}
+	simpleValue, err := genericJSONObject(simple)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range simpleValue {
+		schema[key] = value
+	}
+	if schema["type"] == "file" {
+		schema["type"], schema["format"] = "string", "binary"
+	}
+
+	if simple.Items != nil {
+		if format := simple.Items.CollectionFormat; format != "" && format != "csv" {
+			c.warn(joinPointer(ptr, "items", "collectionFormat"), "the collection format %q of nested arrays has no equivalent, and is dropped", format)
+		}
+		items, err := c.simpleSchema(simple.Items.SimpleSchema, simple.Items.CommonValidations, joinPointer(ptr, "items"))
+		if err != nil {
+			return nil, err
+		}
+		schema["items"] = items
+	}
+
+	return schema, nil
+}
+
+func (c *openAPI3Converter) responses(responses *Responses, produces []string, ptr string) (map[string]any, error) {
+	out := map[string]any{}
+	if responses == nil {
+		return out, nil
+	}
+	copyExtensions(out, responses.Extensions)
+
+	for key, response := range responses.All() {
+		converted, err := c.response(&response, produces, joinPointer(ptr, key))
+		if err != nil {
+			return nil, err
+		}
+
+		if rng, isRange := responseCodeRange(key); isRange && rng != key {
+			// response code ranges are upper case in OpenAPI 3
+			if _, exists := out[rng]; exists {
+				c.warn(joinPointer(ptr, key), "the response code range %q duplicates %q, and is dropped", key, rng)
+
+				continue
+			}
+			key = rng
+		}
+		out[key] = converted
+	}
+
+	return out, nil
+}
+
+func (c *openAPI3Converter) response(response *Response, produces []string, ptr string) (map[string]any, error) {
+	if ref := response.Ref.String(); ref != "" {
+		// global responses are responses of the components, which produce the types of the spec
+		if name, ok := strings.CutPrefix(ref, "#/responses/"); ok && !slices.Equal(produces, c.spec.Produces) {
+			target, exists := c.spec.Responses[jsonpointer.Unescape(name)]
+			if exists && target.Ref.String() == "" && (target.Schema != nil || len(target.Examples) > 0) {
+				return c.response(&target, produces, ptr)
+			}
+		}
+
+		return map[string]any{"$ref": c.ref(ref, ptr)}, nil
+	}
+
+	out := map[string]any{"description": response.Description}
+	copyExtensions(out, response.Extensions)
+
+	if len(response.Headers) > 0 {
+		headers := map[string]any{}
+		for name, header := range mapEntriesSorted(response.Headers) {
+			headerPtr := joinPointer(ptr, "headers", name)
+			schema, err := c.simpleSchema(header.SimpleSchema, header.CommonValidations, headerPtr)
+			if err != nil {
+				return nil, err
+			}
+			if format := header.CollectionFormat; format != "" && format != "csv" {
+				c.warn(joinPointer(headerPtr, "collectionFormat"), "the collection format %q of a header has no equivalent, and is dropped", format)
+			}
+
+			converted := map[string]any{"schema": schema}
+			copyExtensions(converted, header.Extensions)
+			if header.Description != "" {
+				converted["description"] = header.Description
+			}
+			headers[name] = converted
+		}
+		out["headers"] = headers
+	}
+
+	if response.Schema == nil && len(response.Examples) == 0 {
+		return out, nil
+	}
+
+	if len(produces) == 0 {
+		produces = []string{"application/json"}
+	}
+
+	content := map[string]any{}
+	if response.Schema != nil {
+		schema, err := c.schema(response.Schema, joinPointer(ptr, "schema"))
+		if err != nil {
+			return nil, err
+		}
+		for _, mediaType := range produces {
+			content[mediaType] = map[string]any{"schema": schema}
+		}
+	}
+	for mediaType, example := range mapEntriesSorted(response.Examples) {
+		media, ok := content[mediaType].(map[string]any)
+		if !ok {
+			media = map[string]any{}
+			content[mediaType] = media
+		}
+		media["example"] = example
+	}
+	out["content"] = content
+
+	return out, nil
+}
+
+// schema converts a schema.
+func (c *openAPI3Converter) schema(schema *Schema, ptr string) (any, error) {
+	value, err := genericJSON(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.schemaValue(value, ptr), nil
+}
+
+// schemaValue converts a schema represented as a generic JSON value.
+//
+// Only keywords holding schemas are converted recursively, so that values such as examples are left untouched.
+func (c *openAPI3Converter) schemaValue(value any, ptr string) any {
+	schema, ok := value.(map[string]any)
+	if !ok {
+		return value
+	}
+
+	if ref, ok := schema["$ref"].(string); ok {
+		schema["$ref"] = c.ref(ref, ptr)
+	}
+
+	if types, ok := schema["type"].([]any); ok {
+		var kept []any
+		for _, t := range types {
+			if t == "null" {
+				schema["nullable"] = true
+
+				continue
+			}
+			kept = append(kept, t)
+		}
+
+		switch len(kept) {
+		case 0:
+			delete(schema, "type")
+		case 1:
+			schema["type"] = kept[0]
+		default:
+			c.warn(joinPointer(ptr, "type"), "schemas with several types have no equivalent, the type is dropped")
+			delete(schema, "type")
+		}
+	}
+	if schema["type"] == "file" {
+		schema["type"], schema["format"] = "string", "binary"
+	}
+
+	if nullable, ok := schema["x-nullable"].(bool); ok {
+		delete(schema, "x-nullable")
+		if nullable {
+			schema["nullable"] = true
+		}
+	}
+
+	if discriminator, ok := schema["discriminator"].(string); ok {
+		schema["discriminator"] = map[string]any{"propertyName": discriminator}
+	}
+
+	for _, keyword := range []string{"$schema", "id", "definitions", "dependencies", "patternProperties", "additionalItems"} {
+		if _, ok := schema[keyword]; ok {
+			c.warn(joinPointer(ptr, keyword), "the %s keyword has no equivalent, and is dropped", keyword)
+			delete(schema, keyword)
+		}
+	}
+
+	if properties, ok := schema["properties"].(map[string]any); ok {
+		for name, property := range properties {
+			properties[name] = c.schemaValue(property, joinPointer(ptr, "properties", name))
+		}
+	}
+
+	switch items := schema["items"].(type) {
+	case map[string]any:
+		schema["items"] = c.schemaValue(items, joinPointer(ptr, "items"))
+	case []any:
+		if len(items) == 1 {
+			schema["items"] = c.schemaValue(items[0], joinPointer(ptr, "items", "0"))
+
+			break
+		}
+		c.warn(joinPointer(ptr, "items"), "tuples of items have no equivalent, items may be anything")
+		schema["items"] = map[string]any{}
+	}
+
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		if schemas, ok := schema[keyword].([]any); ok {
+			for i, s := range schemas {
+				schemas[i] = c.schemaValue(s, joinPointer(ptr, keyword, strconv.Itoa(i)))
+			}
+		}
+	}
+
+	for _, keyword := range []string{"not", "additionalProperties"} {
+		if s, ok := schema[keyword].(map[string]any); ok {
+			schema[keyword] = c.schemaValue(s, joinPointer(ptr, keyword))
+		}
+	}
+
+	return schema
+}
+
+// openAPI3Locations are the locations of the reusable objects of a Swagger 2.0 spec in an OpenAPI 3 document.
+var openAPI3Locations = []struct{ from, to string }{ //nolint:gochecknoglobals // constant-like lookup table
+	{"/definitions/", "/components/schemas/"},
+	{"/parameters/", "/components/parameters/"},
+	{"/responses/", "/components/responses/"},
+}
+
+// ref rewrites a $ref to the location of its target in an OpenAPI 3 document.
+func (c *openAPI3Converter) ref(ref, ptr string) string {
+	document, fragment, _ := strings.Cut(ref, "#")
+	for _, location := range openAPI3Locations {
+		if name, ok := strings.CutPrefix(fragment, location.from); ok {
+			if document != "" {
+				c.warn(ptr, "the $ref %q is rewritten, assuming the document it refers to is converted as well", ref)
+			}
+
+			return document + "#" + location.to + name
+		}
+	}
+
+	c.warn(ptr, "the $ref %q does not refer to a definition, a parameter or a response, and is kept as is", ref)
+
+	return ref
+}
+
+// copyExtensions copies the vendor extensions of an object to its conversion.
+func copyExtensions(out map[string]any, extensions Extensions) {
+	for key, value := range extensions {
+		if strings.HasPrefix(strings.ToLower(key), "x-") {
+			out[key] = value
+		}
+	}
+}
+
+// genericJSON converts a value to its generic JSON representation.
+func genericJSON(value any) (any, error) {
+	converted, err := toJSONValue(reflect.ValueOf(value))
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert %T to OpenAPI 3: %w: %w", value, err, ErrSpec)
+	}
+
+	return converted, nil
+}
+
+// genericJSONObject converts a value to its generic JSON representation, which is an object.
+func genericJSONObject(value any) (map[string]any, error) {
+	converted, err := genericJSON(value)
+	if err != nil {
+		return nil, err
+	}
+
+	object, ok := converted.(map[string]any)
+	if !ok {
+		return map[string]any{}, nil
+	}
+
+	return object, nil
+}
@@ -0,0 +1,334 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+const openAPI3Spec = `{
+  "swagger": "2.0",
+  "info": { "title": "Pet store", "version": "1.0" },
+  "host": "api.example.com",
+  "basePath": "/v1",
+  "schemes": [ "https" ],
+  "consumes": [ "application/json" ],
+  "produces": [ "application/json", "application/xml" ],
+  "x-owner": "pets",
+  "paths": {
+    "/pets": {
+      "parameters": [ { "$ref": "#/parameters/limit" } ],
+      "get": {
+        "operationId": "listPets",
+        "parameters": [
+          { "name": "tags", "in": "query", "type": "array", "items": { "type": "string" }, "collectionFormat": "multi" },
+          { "name": "ids", "in": "query", "type": "array", "items": { "type": "integer" }, "collectionFormat": "tsv" }
+        ],
+        "responses": {
+          "200": {
+            "description": "pets",
+            "headers": { "X-Total": { "type": "integer", "description": "total" } },
+            "schema": { "type": "array", "items": { "$ref": "#/definitions/Pet" } },
+            "examples": { "application/json": [ { "$ref": "not a ref" } ] }
+          },
+          "default": { "$ref": "#/responses/Error" }
+        }
+      },
+      "post": {
+        "schemes": [ "http" ],
+        "parameters": [ { "$ref": "#/parameters/pet" } ],
+        "responses": { "201": { "description": "created" } },
+        "security": []
+      }
+    },
+    "/pets/{id}/photo": {
+      "put": {
+        "consumes": [ "multipart/form-data", "application/json" ],
+        "parameters": [
+          { "name": "id", "in": "path", "required": true, "type": "string" },
+          { "name": "file", "in": "formData", "required": true, "type": "file" },
+          { "name": "caption", "in": "formData", "type": "string", "maxLength": 80 }
+        ],
+        "responses": { "204": { "description": "stored" } }
+      }
+    }
+  },
+  "definitions": {
+    "Pet": {
+      "type": "object",
+      "discriminator": "kind",
+      "required": [ "kind" ],
+      "properties": {
+        "kind": { "type": "string" },
+        "owner": { "$ref": "#/definitions/Owner", "x-nullable": true },
+        "photo": { "type": "file" },
+        "other": { "$ref": "other.json#/definitions/Thing" }
+      }
+    },
+    "Owner": { "type": "object", "patternProperties": { "^x-": { "type": "string" } } }
+  },
+  "parameters": {
+    "limit": { "name": "limit", "in": "query", "type": "integer", "format": "int32", "maximum": 100 },
+    "pet": { "name": "pet", "in": "body", "required": true, "schema": { "$ref": "#/definitions/Pet" } }
+  },
+  "responses": {
+    "Error": { "description": "error", "schema": { "type": "string" } }
+  },
+  "securityDefinitions": {
+    "basic": { "type": "basic" },
+    "key": { "type": "apiKey", "name": "X-Key", "in": "header" },
+    "oauth": {
+      "type": "oauth2",
+      "flow": "accessCode",
+      "authorizationUrl": "https://auth.example.com/authorize",
+      "tokenUrl": "https://auth.example.com/token",
+      "scopes": { "read": "read pets" }
+    },
+    "service": { "type": "oauth2", "flow": "application", "tokenUrl": "https://auth.example.com/token" }
+  },
+  "security": [ { "oauth": [ "read" ] } ]
+}`
+
+func TestConvertToOpenAPI3(t *testing.T) {
+	t.Run("a spec should be converted", func(t *testing.T) {
+		doc, warnings, err := ConvertToOpenAPI3(mustSpec(t, openAPI3Spec))
+		require.NoError(t, err)
+
+		assert.JSONEqT(t, `{
+  "openapi": "3.0.3",
+  "info": { "title": "Pet store", "version": "1.0" },
+  "servers": [ { "url": "https://api.example.com/v1" } ],
+  "security": [ { "oauth": [ "read" ] } ],
+  "x-owner": "pets",
+  "paths": {
+    "/pets": {
+      "parameters": [ { "$ref": "#/components/parameters/limit" } ],
+      "get": {
+        "operationId": "listPets",
+        "parameters": [
+          {
+            "name": "tags", "in": "query", "style": "form", "explode": true,
+            "schema": { "type": "array", "items": { "type": "string" } }
+          },
+          { "name": "ids", "in": "query", "schema": { "type": "array", "items": { "type": "integer" } } }
+        ],
+        "responses": {
+          "200": {
+            "description": "pets",
+            "headers": { "X-Total": { "description": "total", "schema": { "type": "integer" } } },
+            "content": {
+              "application/json": {
+                "schema": { "type": "array", "items": { "$ref": "#/components/schemas/Pet" } },
+                "example": [ { "$ref": "not a ref" } ]
+              },
+              "application/xml": {
+                "schema": { "type": "array", "items": { "$ref": "#/components/schemas/Pet" } }
+              }
+            }
+          },
+          "default": { "$ref": "#/components/responses/Error" }
+        }
+      },
+      "post": {
+        "servers": [ { "url": "http://api.example.com/v1" } ],
+        "security": [],
+        "requestBody": { "$ref": "#/components/requestBodies/pet" },
+        "responses": { "201": { "description": "created" } }
+      }
+    },
+    "/pets/{id}/photo": {
+      "put": {
+        "parameters": [ { "name": "id", "in": "path", "required": true, "schema": { "type": "string" } } ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "multipart/form-data": {
+              "schema": {
+                "type": "object",
+                "required": [ "file" ],
+                "properties": {
+                  "file": { "type": "string", "format": "binary" },
+                  "caption": { "type": "string", "maxLength": 80 }
+                }
+              }
+            }
+          }
+        },
+        "responses": { "204": { "description": "stored" } }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {
+        "type": "object",
+        "discriminator": { "propertyName": "kind" },
+        "required": [ "kind" ],
+        "properties": {
+          "kind": { "type": "string" },
+          "owner": { "$ref": "#/components/schemas/Owner", "nullable": true },
+          "photo": { "type": "string", "format": "binary" },
+          "other": { "$ref": "other.json#/components/schemas/Thing" }
+        }
+      },
+      "Owner": { "type": "object" }
+    },
+    "parameters": {
+      "limit": { "name": "limit", "in": "query", "schema": { "type": "integer", "format": "int32", "maximum": 100 } }
+    },
+    "requestBodies": {
+      "pet": {
+        "required": true,
+        "x-codegen-request-body-name": "pet",
+        "content": { "application/json": { "schema": { "$ref": "#/components/schemas/Pet" } } }
+      }
+    },
+    "responses": {
+      "Error": {
+        "description": "error",
+        "content": {
+          "application/json": { "schema": { "type": "string" } },
+          "application/xml": { "schema": { "type": "string" } }
+        }
+      }
+    },
+    "securitySchemes": {
+      "basic": { "type": "http", "scheme": "basic" },
+      "key": { "type": "apiKey", "name": "X-Key", "in": "header" },
+      "oauth": {
+        "type": "oauth2",
+        "flows": {
+          "authorizationCode": {
+            "authorizationUrl": "https://auth.example.com/authorize",
+            "tokenUrl": "https://auth.example.com/token",
+            "scopes": { "read": "read pets" }
+          }
+        }
+      },
+      "service": {
+        "type": "oauth2",
+        "flows": { "clientCredentials": { "tokenUrl": "https://auth.example.com/token", "scopes": {} } }
+      }
+    }
+  }
+}`, doc)
+
+		messages := make([]string, 0, len(warnings))
+		for _, warning := range warnings {
+			messages = append(messages, warning.String())
+		}
+		assert.Equal(t, []string{
+			`/definitions/Owner/patternProperties: the patternProperties keyword has no equivalent, and is dropped`,
+			`/definitions/Pet/properties/other: the $ref "other.json#/definitions/Thing" is rewritten, assuming the document it refers to is converted as well`,
+			`/paths/~1pets/get/parameters/1/collectionFormat: the collection format "tsv" of a query parameter has no equivalent, and is dropped`,
+		}, messages)
+	})
+
+	t.Run("response code ranges should be upper case", func(t *testing.T) {
+		sp, err := UnmarshalSwagger([]byte(`{"swagger":"2.0","paths":{"/pets":{"get":{"responses":{
+  "2xx": { "description": "success" },
+  "4XX": { "description": "client error" },
+  "4xx": { "description": "duplicate" }
+}}}}}`), &UnmarshalOptions{ResponseCodeRanges: true})
+		require.NoError(t, err)
+
+		doc, warnings, err := ConvertToOpenAPI3(sp)
+		require.NoError(t, err)
+
+		var converted struct {
+			Paths map[string]map[string]struct {
+				Responses any `json:"responses"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(doc, &converted))
+		assert.JSONMarshalAsT(t, `{
+  "2XX": { "description": "success" },
+  "4XX": { "description": "client error" }
+}`, converted.Paths["/pets"]["get"].Responses)
+		assert.Equal(t, []ConversionWarning{{
+			Pointer: "/paths/~1pets/get/responses/4xx",
+			Message: `the response code range "4xx" duplicates "4XX", and is dropped`,
+		}}, warnings)
+	})
+
+	t.Run("global responses and bodies should be inlined when types are overridden", func(t *testing.T) {
+		doc, warnings, err := ConvertToOpenAPI3(mustSpec(t, `{
+  "swagger": "2.0",
+  "consumes": [ "application/json" ],
+  "produces": [ "application/json" ],
+  "paths": {
+    "/pets": {
+      "get": { "responses": { "default": { "$ref": "#/responses/Error" } } },
+      "post": {
+        "consumes": [ "application/xml" ],
+        "produces": [ "application/xml" ],
+        "parameters": [ { "$ref": "#/parameters/pet" } ],
+        "responses": { "default": { "$ref": "#/responses/Error" } }
+      }
+    }
+  },
+  "parameters": { "pet": { "name": "pet", "in": "body", "schema": { "type": "object" } } },
+  "responses": { "Error": { "description": "error", "schema": { "type": "string" } } }
+}`))
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+
+		var converted struct {
+			Paths map[string]map[string]struct {
+				RequestBody any `json:"requestBody"`
+				Responses   any `json:"responses"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(doc, &converted))
+		assert.JSONMarshalAsT(t, `{"default": { "$ref": "#/components/responses/Error" }}`, converted.Paths["/pets"]["get"].Responses)
+		assert.JSONMarshalAsT(t, `{
+  "content": { "application/xml": { "schema": { "type": "object" } } },
+  "x-codegen-request-body-name": "pet"
+}`, converted.Paths["/pets"]["post"].RequestBody)
+		assert.JSONMarshalAsT(t, `{"default": {
+  "description": "error",
+  "content": { "application/xml": { "schema": { "type": "string" } } }
+}}`, converted.Paths["/pets"]["post"].Responses)
+	})
+
+	t.Run("a nil spec should not be converted", func(t *testing.T) {
+		_, _, err := ConvertToOpenAPI3(nil)
+		require.ErrorIs(t, err, ErrSpec)
+	})
+
+	t.Run("servers should follow host and base path", func(t *testing.T) {
+		for _, tc := range []struct {
+			spec     string
+			expected string
+			warnings []ConversionWarning
+		}{
+			{`{"swagger":"2.0","paths":{}}`, `null`, nil},
+			{`{"swagger":"2.0","basePath":"/v1","paths":{}}`, `[{"url":"/v1"}]`, nil},
+			{`{"swagger":"2.0","host":"example.com","paths":{}}`, `[{"url":"//example.com/"}]`, nil},
+			{`{"swagger":"2.0","host":"example.com","schemes":["http","https"],"paths":{}}`, `[{"url":"http://example.com/"},{"url":"https://example.com/"}]`, nil},
+			{
+				`{"swagger":"2.0","basePath":"/v1","schemes":["http","https"],"paths":{}}`, `[{"url":"/v1"}]`,
+				[]ConversionWarning{{Pointer: "/schemes", Message: "the schemes http, https require a host, and are dropped"}},
+			},
+			{
+				`{"swagger":"2.0","paths":{"/pets":{"get":{"schemes":["wss"],"responses":{"200":{"description":"ok"}}}}}}`, `null`,
+				[]ConversionWarning{{Pointer: "/paths/~1pets/get/schemes", Message: "the schemes wss require a host, and are dropped"}},
+			},
+		} {
+			doc, warnings, err := ConvertToOpenAPI3(mustSpec(t, tc.spec))
+			require.NoError(t, err)
+			assert.Equal(t, tc.warnings, warnings, tc.spec)
+
+			var converted struct {
+				Servers any `json:"servers"`
+			}
+			require.NoError(t, json.Unmarshal(doc, &converted))
+			assert.JSONMarshalAsT(t, tc.expected, converted.Servers)
+		}
+	})
+}
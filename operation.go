@@ -83,6 +83,9 @@ func NewOperation(id string) *Operation {
 }
 
 // SuccessResponse gets a success response model.
+//
+// When no 2xx status code is documented, a "2XX" response code range is returned with a 0 status code,
+// since it documents no particular status code.
 func (o *Operation) SuccessResponse() (*Response, int, bool) {
 	if o.Responses == nil {
 		return nil, 0, false
@@ -100,6 +103,10 @@ func (o *Operation) SuccessResponse() (*Response, int, bool) {
 		return &v, responseCodes[0], true
 	}
 
+	if _, v, ok := o.Responses.codeRange("2XX"); ok {
+		return &v, 0, true
+	}
+
 	return o.Responses.Default, 0, false
 }
 
@@ -112,6 +119,13 @@ func (o Operation) JSONLookup(token string) (any, error) {
 	return r, err
 }
 
+// JSONSet sets a value by the json property name.
+//
+// Missing containers, such as extensions, are created.
+func (o *Operation) JSONSet(token string, value any) error {
+	return jsonSetToken(o, token, value)
+}
+
 // UnmarshalJSON hydrates this items instance with the data from JSON.
 func (o *Operation) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &o.OperationProps); err != nil {
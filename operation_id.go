@@ -0,0 +1,301 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// OperationIDStyle is the casing of generated operation IDs.
+type OperationIDStyle uint8
+
+// Casings of operation IDs.
+const (
+	// OperationIDCamelCase generates IDs such as "getPetsById"
+	OperationIDCamelCase OperationIDStyle = iota
+
+	// OperationIDPascalCase generates IDs such as "GetPetsById"
+	OperationIDPascalCase
+
+	// OperationIDSnakeCase generates IDs such as "get_pets_by_id"
+	OperationIDSnakeCase
+)
+
+// OperationIDRename tells what happens to the operation IDs already present in a spec.
+type OperationIDRename uint8
+
+// Treatments of existing operation IDs.
+const (
+	// KeepOperationIDs keeps existing IDs, and only fills in missing ones
+	KeepOperationIDs OperationIDRename = iota
+
+	// NormalizeOperationIDs changes the casing of existing IDs to the style of generated IDs,
+	// e.g. "GetPets" becomes "getPets" in camel case
+	NormalizeOperationIDs
+
+	// RegenerateOperationIDs replaces existing IDs with generated ones
+	RegenerateOperationIDs
+)
+
+// OperationIDOptions configures the generation of operation IDs.
+type OperationIDOptions struct {
+	// Style is the casing of generated IDs
+	Style OperationIDStyle
+
+	// WithTag prefixes generated IDs with the first tag of their operation, if any
+	WithTag bool
+
+	// Template is an optional text/template building generated IDs.
+	//
+	// The template is executed with an [OperationIDData]. Its output is used as is, then made unique.
+	Template string
+
+	// Rename tells what happens to existing IDs
+	Rename OperationIDRename
+}
+
+// OperationIDData is the data available to the template of generated operation IDs.
+type OperationIDData struct {
+	// Method is the method of the operation, in lower case
+	Method string
+
+	// Path is the path template of the operation
+	Path string
+
+	// Tag is the first tag of the operation, if any
+	Tag string
+
+	// Name is the ID generated from the method, the path template and, with WithTag, the tag, in the configured style
+	Name string
+
+	// Words are the words of the generated name, in lower case
+	Words []string
+}
+
+// OperationIDChange is an operation ID set by [GenerateOperationIDs].
+type OperationIDChange struct {
+	// Path is the path template of the operation
+	Path string
+
+	// Method is the method of the operation, in lower case
+	Method string
+
+	// Old is the previous ID of the operation, empty when it had none
+	Old string
+
+	// New is the new ID of the operation
+	New string
+}
+
+// GenerateOperationIDs sets the operation IDs of a spec.
+//
+// Missing IDs are derived from the method and the path template of their operation, e.g. "GET /pets/{id}"
+// yields "getPetsById" in camel case. Existing IDs are kept, normalized or regenerated, depending on the options.
+//
+// IDs are unique across the spec: existing IDs, kept or normalized, take precedence over generated ones.
+// Operations are processed by path, then by method, and a numeric suffix is added to IDs already in use,
+// including existing IDs duplicated in the spec.
+//
+// It returns the IDs which have been changed, in the order of their operations. With nil options, missing IDs are
+// generated in camel case.
+func GenerateOperationIDs(spec *Swagger, options *OperationIDOptions) ([]OperationIDChange, error) {
+	if options == nil {
+		options = &OperationIDOptions{}
+	}
+
+	var tmpl *template.Template
+	if options.Template != "" {
+		var err error
+		tmpl, err = template.New("operationId").Parse(options.Template)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operation ID template: %w: %w", err, ErrSpec)
+		}
+	}
+
+	// existing IDs are kept or normalized first, so they take precedence over generated ones
+	used := make(map[string]bool)
+	ids := make(map[*Operation]string)
+	var duplicates []*Operation
+	for op := range spec.Operations() {
+		old := op.Operation.ID
+		if old == "" || options.Rename == RegenerateOperationIDs {
+			continue
+		}
+
+		id := old
+		if words := splitWords(old); options.Rename == NormalizeOperationIDs && len(words) > 0 {
+			id = options.Style.format(words)
+		}
+		if used[id] {
+			duplicates = append(duplicates, op.Operation)
+		}
+		used[id] = true
+		ids[op.Operation] = id
+	}
+
+	// duplicate existing IDs are suffixed once all existing IDs are known, so the first occurrence is kept as is
+	for _, op := range duplicates {
+		id := options.Style.unique(ids[op], used)
+		used[id] = true
+		ids[op] = id
+	}
+
+	var changes []OperationIDChange
+	for op := range spec.Operations() {
+		old := op.Operation.ID
+		id, ok := ids[op.Operation]
+		if !ok {
+			generated, err := options.generate(op, tmpl)
+			if err != nil {
+				return nil, err
+			}
+			id = options.Style.unique(generated, used)
+			used[id] = true
+		}
+
+		if id == old {
+			continue
+		}
+
+		op.Operation.ID = id
+		changes = append(changes, OperationIDChange{Path: op.Path, Method: op.Method, Old: old, New: id})
+	}
+
+	return changes, nil
+}
+
+// generate derives an operation ID from the method, the path template and the tags of an operation.
+//
+// The template is nil when IDs are not built by a template.
+func (o *OperationIDOptions) generate(op IndexedOperation, tmpl *template.Template) (string, error) {
+	words := []string{op.Method}
+	var tag string
+	if len(op.Operation.Tags) > 0 {
+		tag = op.Operation.Tags[0]
+	}
+	if o.WithTag && tag != "" {
+		words = append(words, splitWords(tag)...)
+	}
+
+	for _, segment := range strings.Split(op.Path, "/") {
+		for rest := segment; rest != ""; {
+			start := strings.IndexByte(rest, '{')
+			end := strings.IndexByte(rest, '}')
+			if start < 0 || end < start {
+				words = append(words, splitWords(rest)...)
+
+				break
+			}
+			words = append(words, splitWords(rest[:start])...)
+			words = append(words, "by")
+			words = append(words, splitWords(rest[start+1:end])...)
+			rest = rest[end+1:]
+		}
+	}
+
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+
+	name := o.Style.format(words)
+	if tmpl == nil {
+		return name, nil
+	}
+
+	var b strings.Builder
+	data := OperationIDData{Method: op.Method, Path: op.Path, Tag: tag, Name: name, Words: words}
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("cannot generate the operation ID of %s: %w: %w", op.Pointer(), err, ErrSpec)
+	}
+
+	id := strings.TrimSpace(b.String())
+	if id == "" {
+		return "", fmt.Errorf("the operation ID template generated an empty ID for %s: %w", op.Pointer(), ErrSpec)
+	}
+
+	return id, nil
+}
+
+// format joins words in the casing of the style.
+func (s OperationIDStyle) format(words []string) string {
+	var b strings.Builder
+	for i, word := range words {
+		word = strings.ToLower(word)
+		switch {
+		case s == OperationIDSnakeCase:
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteString(word)
+		case i == 0 && s == OperationIDCamelCase:
+			b.WriteString(word)
+		default:
+			first, rest := []rune(word)[0], string([]rune(word)[1:])
+			b.WriteRune(unicode.ToUpper(first))
+			b.WriteString(rest)
+		}
+	}
+
+	return b.String()
+}
+
+// unique adds a numeric suffix to an ID already in use.
+func (s OperationIDStyle) unique(id string, used map[string]bool) string {
+	if !used[id] {
+		return id
+	}
+
+	separator := ""
+	if s == OperationIDSnakeCase {
+		separator = "_"
+	}
+
+	for n := 2; ; n++ {
+		candidate := id + separator + strconv.Itoa(n)
+		if !used[candidate] {
+			return candidate
+		}
+	}
+}
+
+// splitWords splits an identifier into words, on non-alphanumeric characters and on changes of case,
+// e.g. "listHTTPServers" yields "list", "HTTP" and "Servers".
+func splitWords(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+
+			continue
+		}
+
+		if start >= 0 && unicode.IsUpper(r) {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(previous) || nextIsLower {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+
+		if start < 0 {
+			start = i
+		}
+	}
+
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+
+	return words
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+const operationIDSpec = `{
+  "swagger": "2.0",
+  "paths": {
+    "/pets": {
+      "get": { "operationId": "ListPets", "tags": [ "pets" ] },
+      "post": { "tags": [ "pets" ] }
+    },
+    "/pets/{petId}": { "get": { "tags": [ "pets" ] }, "delete": { "operationId": "get_pets_by_pet_id" } },
+    "/pets/{petId}/photos/{name}.{ext}": { "get": {} },
+    "/v2/HTTPServers": { "get": { "operationId": "getPets" } }
+  }
+}`
+
+func operationIDs(sp *Swagger) []string {
+	var ids []string
+	for op := range sp.Operations() {
+		ids = append(ids, op.Method+" "+op.Path+" "+op.Operation.ID)
+	}
+
+	return ids
+}
+
+func TestGenerateOperationIDs(t *testing.T) {
+	t.Run("missing IDs should be generated", func(t *testing.T) {
+		sp := mustSpec(t, operationIDSpec)
+		changes, err := GenerateOperationIDs(sp, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, []OperationIDChange{
+			{Path: "/pets", Method: "post", New: "postPets"},
+			{Path: "/pets/{petId}", Method: "get", New: "getPetsByPetId"},
+			{Path: "/pets/{petId}/photos/{name}.{ext}", Method: "get", New: "getPetsByPetIdPhotosByNameByExt"},
+		}, changes)
+		assert.Equal(t, []string{
+			"get /pets ListPets",
+			"post /pets postPets",
+			"get /pets/{petId} getPetsByPetId",
+			"delete /pets/{petId} get_pets_by_pet_id",
+			"get /pets/{petId}/photos/{name}.{ext} getPetsByPetIdPhotosByNameByExt",
+			"get /v2/HTTPServers getPets",
+		}, operationIDs(sp))
+	})
+
+	t.Run("existing IDs should be normalized and made unique", func(t *testing.T) {
+		sp := mustSpec(t, operationIDSpec)
+		changes, err := GenerateOperationIDs(sp, &OperationIDOptions{Style: OperationIDSnakeCase, Rename: NormalizeOperationIDs})
+		require.NoError(t, err)
+		assert.Len(t, changes, 5)
+
+		assert.Equal(t, []string{
+			"get /pets list_pets",
+			"post /pets post_pets",
+			"get /pets/{petId} get_pets_by_pet_id_2",
+			"delete /pets/{petId} get_pets_by_pet_id",
+			"get /pets/{petId}/photos/{name}.{ext} get_pets_by_pet_id_photos_by_name_by_ext",
+			"get /v2/HTTPServers get_pets",
+		}, operationIDs(sp))
+	})
+
+	t.Run("duplicate existing IDs should be made unique", func(t *testing.T) {
+		sp := mustSpec(t, `{"swagger":"2.0","paths":{
+  "/a": { "get": { "operationId": "dup" } },
+  "/b": { "get": { "operationId": "dup" } },
+  "/c": { "get": { "operationId": "dup2" } }
+}}`)
+		changes, err := GenerateOperationIDs(sp, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, []OperationIDChange{
+			{Path: "/b", Method: "get", Old: "dup", New: "dup3"},
+		}, changes)
+		assert.Equal(t, []string{
+			"get /a dup",
+			"get /b dup3",
+			"get /c dup2",
+		}, operationIDs(sp))
+	})
+
+	t.Run("existing IDs should be regenerated", func(t *testing.T) {
+		sp := mustSpec(t, operationIDSpec)
+		changes, err := GenerateOperationIDs(sp, &OperationIDOptions{
+			Style:   OperationIDPascalCase,
+			WithTag: true,
+			Rename:  RegenerateOperationIDs,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, OperationIDChange{Path: "/pets", Method: "get", Old: "ListPets", New: "GetPetsPets"}, changes[0])
+
+		assert.Equal(t, []string{
+			"get /pets GetPetsPets",
+			"post /pets PostPetsPets",
+			"get /pets/{petId} GetPetsPetsByPetId",
+			"delete /pets/{petId} DeletePetsByPetId",
+			"get /pets/{petId}/photos/{name}.{ext} GetPetsByPetIdPhotosByNameByExt",
+			"get /v2/HTTPServers GetV2HttpServers",
+		}, operationIDs(sp))
+	})
+
+	t.Run("IDs should be built by a template", func(t *testing.T) {
+		sp := mustSpec(t, operationIDSpec)
+		_, err := GenerateOperationIDs(sp, &OperationIDOptions{Template: `{{ if .Tag }}{{ .Tag }}.{{ end }}{{ .Method }}`})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"get /pets ListPets",
+			"post /pets pets.post",
+			"get /pets/{petId} pets.get",
+			"delete /pets/{petId} get_pets_by_pet_id",
+			"get /pets/{petId}/photos/{name}.{ext} get",
+			"get /v2/HTTPServers getPets",
+		}, operationIDs(sp))
+	})
+
+	t.Run("invalid templates should fail", func(t *testing.T) {
+		for _, tmpl := range []string{"{{ .Method", "{{ .Missing }}", "{{ if false }}x{{ end }}"} {
+			_, err := GenerateOperationIDs(mustSpec(t, operationIDSpec), &OperationIDOptions{Template: tmpl})
+			require.ErrorIs(t, err, ErrSpec, tmpl)
+		}
+	})
+}
+
+func TestSplitWords(t *testing.T) {
+	for input, words := range map[string][]string{
+		"listHTTPServers": {"list", "HTTP", "Servers"},
+		"get_pets-by id":  {"get", "pets", "by", "id"},
+		"PetV2Photos":     {"Pet", "V2", "Photos"},
+		"ID":              {"ID"},
+		"__":              nil,
+	} {
+		assert.Equal(t, words, splitWords(input), input)
+	}
+}
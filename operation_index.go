@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// IndexedOperation is an operation of a spec, with its location.
+type IndexedOperation struct {
+	// Path is the path template of the operation
+	Path string
+
+	// Method is the method of the operation, in lower case as in a swagger document
+	Method string
+
+	// Operation is the operation, as found in the spec
+	Operation *Operation
+}
+
+// Pointer returns the JSON pointer of the operation in the spec.
+func (o IndexedOperation) Pointer() string {
+	return joinPointer("/paths", o.Path, o.Method)
+}
+
+// OperationIndex indexes the operations of a spec by operation ID, tag, method and path, and security scheme.
+//
+// Lookups and iterations list operations in a deterministic order: by path, then by method
+// in the order get, put, post, delete, options, head and patch.
+//
+// The index is not updated when the spec changes: call [OperationIndex.Update] for the paths which have
+// been added, removed or modified, or [OperationIndex.Rebuild] when the global security requirements change.
+type OperationIndex struct {
+	spec       *Swagger
+	paths      []string
+	byPath     map[string][]IndexedOperation
+	byID       map[string][]IndexedOperation
+	byTag      map[string][]IndexedOperation
+	bySecurity map[string][]IndexedOperation
+}
+
+// NewOperationIndex indexes the operations of a spec.
+//
+// A nil spec yields an empty index.
+func NewOperationIndex(spec *Swagger) *OperationIndex {
+	x := &OperationIndex{spec: spec}
+	x.Rebuild()
+
+	return x
+}
+
+// Rebuild indexes all the operations of the spec again.
+func (x *OperationIndex) Rebuild() {
+	x.paths = nil
+	x.byPath = make(map[string][]IndexedOperation)
+	x.byID = make(map[string][]IndexedOperation)
+	x.byTag = make(map[string][]IndexedOperation)
+	x.bySecurity = make(map[string][]IndexedOperation)
+
+	if x.spec == nil || x.spec.Paths == nil {
+		return
+	}
+
+	for _, path := range mapKeysSorted(x.spec.Paths.Paths) {
+		x.add(path)
+	}
+}
+
+// Update indexes the operations of some paths again, after they have been added to the spec,
+// removed from it or modified.
+func (x *OperationIndex) Update(paths ...string) {
+	for _, path := range paths {
+		x.remove(path)
+		x.add(path)
+	}
+}
+
+func (x *OperationIndex) add(path string) {
+	if x.spec == nil || x.spec.Paths == nil {
+		return
+	}
+	item, ok := x.spec.Paths.Paths[path]
+	if !ok {
+		return
+	}
+
+	ops := item.operations()
+	if len(ops) == 0 {
+		return
+	}
+
+	index, _ := slices.BinarySearch(x.paths, path)
+	x.paths = slices.Insert(x.paths, index, path)
+
+	for _, op := range ops {
+		indexed := IndexedOperation{Path: path, Method: op.method, Operation: op.operation}
+		x.byPath[path] = append(x.byPath[path], indexed)
+
+		if op.operation.ID != "" {
+			insertIndexed(x.byID, op.operation.ID, indexed)
+		}
+		for _, tag := range op.operation.Tags {
+			insertIndexed(x.byTag, tag, indexed)
+		}
+
+		security := op.operation.Security
+		if security == nil {
+			security = x.spec.Security
+		}
+		schemes := make(map[string]bool)
+		for _, requirement := range security {
+			for name := range requirement {
+				schemes[name] = true
+			}
+		}
+		for name := range schemes {
+			insertIndexed(x.bySecurity, name, indexed)
+		}
+	}
+}
+
+func (x *OperationIndex) remove(path string) {
+	index, found := slices.BinarySearch(x.paths, path)
+	if !found {
+		return
+	}
+	x.paths = slices.Delete(x.paths, index, index+1)
+	delete(x.byPath, path)
+
+	for _, byKey := range []map[string][]IndexedOperation{x.byID, x.byTag, x.bySecurity} {
+		for key, ops := range byKey {
+			ops = slices.DeleteFunc(ops, func(op IndexedOperation) bool { return op.Path == path })
+			if len(ops) == 0 {
+				delete(byKey, key)
+
+				continue
+			}
+			byKey[key] = ops
+		}
+	}
+}
+
+// insertIndexed inserts an operation in the list for a key, in the order of the index.
+func insertIndexed(byKey map[string][]IndexedOperation, key string, op IndexedOperation) {
+	ops := byKey[key]
+	index, _ := slices.BinarySearchFunc(ops, op, compareIndexed)
+	byKey[key] = slices.Insert(ops, index, op)
+}
+
+// compareIndexed orders operations by path, then by method.
+func compareIndexed(a, b IndexedOperation) int {
+	if c := strings.Compare(a.Path, b.Path); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(slices.Index(pathItemMethods, a.Method), slices.Index(pathItemMethods, b.Method))
+}
+
+// All iterates over all the operations of the spec.
+func (x *OperationIndex) All() iter.Seq[IndexedOperation] {
+	return func(yield func(IndexedOperation) bool) {
+		for _, path := range x.paths {
+			for _, op := range x.byPath[path] {
+				if !yield(op) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Len returns the number of operations in the index.
+func (x *OperationIndex) Len() int {
+	n := 0
+	for _, ops := range x.byPath {
+		n += len(ops)
+	}
+
+	return n
+}
+
+// ByID finds the operation with an operation ID.
+//
+// When the ID is used by several operations, the first one is returned.
+func (x *OperationIndex) ByID(id string) (IndexedOperation, bool) {
+	ops := x.byID[id]
+	if len(ops) == 0 {
+		return IndexedOperation{}, false
+	}
+
+	return ops[0], true
+}
+
+// ByMethodAndPath finds the operation for a method and a path template.
+//
+// The method is case-insensitive.
+func (x *OperationIndex) ByMethodAndPath(method, path string) (IndexedOperation, bool) {
+	method = strings.ToLower(method)
+	for _, op := range x.byPath[path] {
+		if op.Method == method {
+			return op, true
+		}
+	}
+
+	return IndexedOperation{}, false
+}
+
+// ByTag lists the operations with a tag.
+func (x *OperationIndex) ByTag(tag string) []IndexedOperation {
+	return slices.Clone(x.byTag[tag])
+}
+
+// BySecurityScheme lists the operations which accept a security scheme, by the name of its security definition.
+//
+// Operations without security requirements of their own accept the schemes required by the spec.
+func (x *OperationIndex) BySecurityScheme(name string) []IndexedOperation {
+	return slices.Clone(x.bySecurity[name])
+}
+
+// Duplicates reports the operation IDs used by several operations.
+//
+// It returns nil when operation IDs are unique, or a [ValidationErrors] reporting every
+// duplicate at the location of its operation ID.
+func (x *OperationIndex) Duplicates() error {
+	var errs ValidationErrors
+	for op := range x.All() {
+		first, ok := x.ByID(op.Operation.ID)
+		if !ok || first == op {
+			continue
+		}
+
+		errs = append(errs, &ValidationError{
+			Pointer: joinPointer(op.Pointer(), "operationId"),
+			Message: fmt.Sprintf("the operation ID %q is already used by %s", op.Operation.ID, first.Pointer()),
+		})
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
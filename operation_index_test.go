@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+const indexSpec = `{
+  "swagger": "2.0",
+  "security": [ { "key": [] } ],
+  "paths": {
+    "/stores": {
+      "get": { "operationId": "listStores", "tags": [ "stores" ] }
+    },
+    "/pets/{id}": {
+      "delete": { "operationId": "deletePet", "tags": [ "pets", "admin" ], "security": [ { "oauth": [ "admin" ] } ] },
+      "get": { "operationId": "getPet", "tags": [ "pets" ], "security": [] }
+    },
+    "/pets": {
+      "post": { "operationId": "createPet", "tags": [ "pets" ], "security": [ { "key": [], "oauth": [ "write" ] } ] },
+      "get": { "operationId": "listPets", "tags": [ "pets" ] }
+    },
+    "/empty": {}
+  }
+}`
+
+func indexedPointers(ops []IndexedOperation) []string {
+	pointers := make([]string, 0, len(ops))
+	for _, op := range ops {
+		pointers = append(pointers, op.Pointer())
+	}
+
+	return pointers
+}
+
+func TestOperationIndex(t *testing.T) {
+	sp := mustSpec(t, indexSpec)
+	x := NewOperationIndex(sp)
+
+	t.Run("operations should be iterated in order", func(t *testing.T) {
+		var all []IndexedOperation
+		for op := range x.All() {
+			all = append(all, op)
+		}
+		assert.Equal(t, []string{
+			"/paths/~1pets/get",
+			"/paths/~1pets/post",
+			"/paths/~1pets~1{id}/get",
+			"/paths/~1pets~1{id}/delete",
+			"/paths/~1stores/get",
+		}, indexedPointers(all))
+		assert.EqualT(t, 5, x.Len())
+	})
+
+	t.Run("operations should be found by ID, method and path", func(t *testing.T) {
+		op, ok := x.ByID("deletePet")
+		require.TrueT(t, ok)
+		assert.EqualT(t, "/pets/{id}", op.Path)
+		assert.EqualT(t, "delete", op.Method)
+		assert.TrueT(t, op.Operation == sp.Paths.Paths["/pets/{id}"].Delete)
+
+		op, ok = x.ByMethodAndPath("POST", "/pets")
+		require.TrueT(t, ok)
+		assert.EqualT(t, "createPet", op.Operation.ID)
+
+		_, ok = x.ByID("missing")
+		assert.FalseT(t, ok)
+		_, ok = x.ByMethodAndPath("put", "/pets")
+		assert.FalseT(t, ok)
+	})
+
+	t.Run("operations should be listed by tag and security scheme", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"/paths/~1pets/get",
+			"/paths/~1pets/post",
+			"/paths/~1pets~1{id}/get",
+			"/paths/~1pets~1{id}/delete",
+		}, indexedPointers(x.ByTag("pets")))
+		assert.Equal(t, []string{"/paths/~1pets~1{id}/delete"}, indexedPointers(x.ByTag("admin")))
+		assert.Empty(t, x.ByTag("missing"))
+
+		assert.Equal(t, []string{
+			"/paths/~1pets/get",
+			"/paths/~1pets/post",
+			"/paths/~1stores/get",
+		}, indexedPointers(x.BySecurityScheme("key")))
+		assert.Equal(t, []string{
+			"/paths/~1pets/post",
+			"/paths/~1pets~1{id}/delete",
+		}, indexedPointers(x.BySecurityScheme("oauth")))
+	})
+
+	t.Run("the index should be updated incrementally", func(t *testing.T) {
+		sp := mustSpec(t, indexSpec)
+		x := NewOperationIndex(sp)
+		require.NoError(t, x.Duplicates())
+
+		delete(sp.Paths.Paths, "/stores")
+		sp.Paths.Paths["/owners"] = PathItem{PathItemProps: PathItemProps{
+			Get: NewOperation("listPets").WithTags("owners"),
+		}}
+		x.Update("/stores", "/owners")
+
+		_, ok := x.ByID("listStores")
+		assert.FalseT(t, ok)
+		assert.Empty(t, x.ByTag("stores"))
+		assert.Equal(t, []string{"/paths/~1owners/get", "/paths/~1pets/get"}, indexedPointers(x.BySecurityScheme("key"))[:2])
+		assert.Equal(t, []string{"/paths/~1owners/get"}, indexedPointers(x.ByTag("owners")))
+
+		op, ok := x.ByID("listPets")
+		require.TrueT(t, ok)
+		assert.EqualT(t, "/owners", op.Path)
+
+		err := x.Duplicates()
+		var errs ValidationErrors
+		require.TrueT(t, errors.As(err, &errs))
+		require.Len(t, errs, 1)
+		assert.EqualT(t, `/paths/~1pets/get/operationId: the operation ID "listPets" is already used by /paths/~1owners/get`, errs[0].Error())
+	})
+
+	t.Run("a spec without paths should be indexed", func(t *testing.T) {
+		x := NewOperationIndex(&Swagger{})
+		assert.EqualT(t, 0, x.Len())
+		x.Update("/pets")
+		assert.EqualT(t, 0, x.Len())
+	})
+	t.Run("a nil spec should yield an empty index", func(t *testing.T) {
+		x := NewOperationIndex(nil)
+		assert.EqualT(t, 0, x.Len())
+		x.Update("/pets")
+		x.Rebuild()
+		assert.EqualT(t, 0, x.Len())
+		_, found := x.ByID("listPets")
+		assert.FalseT(t, found)
+		require.NoError(t, x.Duplicates())
+	})
+}
@@ -109,6 +109,24 @@ func TestSuccessResponse(t *testing.T) {
 	assert.TrueT(t, f)
 }
 
+func TestSuccessResponse_CodeRange(t *testing.T) {
+	ope := NewOperation("ranged")
+	ope.Responses = &Responses{ResponsesProps: ResponsesProps{
+		StatusCodeRanges: map[string]Response{"2XX": *NewResponse().WithDescription("success")},
+	}}
+
+	resp, n, f := ope.SuccessResponse()
+	require.NotNil(t, resp)
+	assert.EqualT(t, "success", resp.Description)
+	assert.EqualT(t, 0, n)
+	assert.TrueT(t, f)
+
+	ope.RespondsWith(201, NewResponse().WithDescription("created"))
+	resp, n, _ = ope.SuccessResponse()
+	assert.EqualT(t, "created", resp.Description)
+	assert.EqualT(t, 201, n)
+}
+
 func TestOperationBuilder(t *testing.T) {
 	ope := NewOperation("").WithID("operationID")
 	ope = ope.RespondsWith(200, &Response{
@@ -0,0 +1,327 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Overlay target selectors, besides JSON pointers.
+const (
+	OverlayOperationIDSelector = "operationId:"
+	OverlayTagSelector         = "tag:"
+)
+
+// Overlay is a document describing changes to apply to a swagger specification.
+//
+// Actions are applied in order. Each action selects nodes with its target, then either
+// merges its update into them (with JSON merge patch semantics, RFC 7396) or removes them.
+//
+// Targets are either:
+//   - a JSON pointer, e.g. "/paths/~1pets/get"
+//   - an operationId selector, e.g. "operationId:listPets", which selects the operation with this id
+//   - a tag selector, e.g. "tag:internal", which selects all operations with this tag
+type Overlay struct {
+	Overlay string          `json:"overlay,omitempty"`
+	Info    map[string]any  `json:"info,omitempty"`
+	Actions []OverlayAction `json:"actions"`
+}
+
+// OverlayAction is an action of an [Overlay].
+type OverlayAction struct {
+	Target      string          `json:"target"`
+	Description string          `json:"description,omitempty"`
+	Update      json.RawMessage `json:"update,omitempty"`
+	Remove      bool            `json:"remove,omitempty"`
+}
+
+// OverlayActionResult tells which nodes an overlay action applied to.
+type OverlayActionResult struct {
+	// Index is the position of the action in the overlay
+	Index int `json:"index"`
+
+	// Target is the target of the action
+	Target string `json:"target"`
+
+	// Pointers are the JSON pointers to the nodes selected by the target, in a deterministic order
+	Pointers []string `json:"pointers,omitempty"`
+}
+
+// OverlayReport tells how an overlay has been applied.
+type OverlayReport struct {
+	// Applied lists the actions which have been applied
+	Applied []OverlayActionResult `json:"applied"`
+
+	// Unmatched lists the actions with a target that selects no node
+	Unmatched []OverlayActionResult `json:"unmatched,omitempty"`
+}
+
+// ParseOverlay parses and validates an overlay document.
+func ParseOverlay(data []byte) (*Overlay, error) {
+	var overlay Overlay
+	if err := json.Unmarshal(data, &overlay); err != nil {
+		return nil, fmt.Errorf("invalid overlay: %w: %w", err, ErrOverlay)
+	}
+
+	if err := overlay.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &overlay, nil
+}
+
+// Validate checks that the actions of an overlay are well-formed.
+func (o *Overlay) Validate() error {
+	var errs []error
+	for i, action := range o.Actions {
+		if err := action.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("action %d (%q): %w", i, action.Target, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid overlay: %w: %w", errors.Join(errs...), ErrOverlay)
+	}
+
+	return nil
+}
+
+func (a OverlayAction) validate() error {
+	hasUpdate := len(a.Update) > 0 && !bytes.Equal(bytes.TrimSpace(a.Update), []byte("null"))
+	switch {
+	case a.Target == "":
+		return errors.New("missing target") //nolint:err113 // joined then wrapped with ErrOverlay
+	case hasUpdate && a.Remove:
+		return errors.New("an action cannot both update and remove its target") //nolint:err113 // joined then wrapped with ErrOverlay
+	case !hasUpdate && !a.Remove:
+		return errors.New("an action must either update or remove its target") //nolint:err113 // joined then wrapped with ErrOverlay
+	case hasUpdate && !json.Valid(a.Update):
+		return errors.New("invalid update") //nolint:err113 // joined then wrapped with ErrOverlay
+	}
+
+	switch {
+	case strings.HasPrefix(a.Target, "/"):
+		if _, err := parsePointer(a.Target); err != nil {
+			return err
+		}
+	case strings.HasPrefix(a.Target, OverlayOperationIDSelector):
+		if strings.TrimPrefix(a.Target, OverlayOperationIDSelector) == "" {
+			return errors.New("empty operationId selector") //nolint:err113 // joined then wrapped with ErrOverlay
+		}
+	case strings.HasPrefix(a.Target, OverlayTagSelector):
+		if strings.TrimPrefix(a.Target, OverlayTagSelector) == "" {
+			return errors.New("empty tag selector") //nolint:err113 // joined then wrapped with ErrOverlay
+		}
+	default:
+		return fmt.Errorf("unsupported target %q", a.Target) //nolint:err113 // joined then wrapped with ErrOverlay
+	}
+
+	return nil
+}
+
+// ApplyOverlay applies an overlay to a swagger specification.
+//
+// The overlay is validated first. Actions with a target which selects no node are not an error:
+// they are reported as unmatched.
+//
+// The overlay is atomic: if any action fails, the specification is left unchanged.
+func ApplyOverlay(spec *Swagger, overlay *Overlay) (*OverlayReport, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("cannot apply an overlay to a nil spec: %w", ErrOverlay)
+	}
+	if err := overlay.Validate(); err != nil {
+		return nil, err
+	}
+
+	patched := spec.DeepCopy()
+	root := reflect.ValueOf(patched).Elem()
+	report := &OverlayReport{Applied: []OverlayActionResult{}}
+
+	for i, action := range overlay.Actions {
+		result := OverlayActionResult{Index: i, Target: action.Target, Pointers: overlayTargets(patched, action.Target)}
+		if len(result.Pointers) == 0 {
+			report.Unmatched = append(report.Unmatched, result)
+
+			continue
+		}
+
+		for _, ptr := range result.Pointers {
+			if err := applyOverlayAction(root, ptr, action); err != nil {
+				return nil, fmt.Errorf("action %d (%q) at %q: %w: %w", i, action.Target, ptr, err, ErrOverlay)
+			}
+		}
+		report.Applied = append(report.Applied, result)
+	}
+
+	*spec = *patched
+
+	return report, nil
+}
+
+// ApplyMergePatch applies a JSON merge patch (RFC 7396) to a swagger specification.
+//
+// The patch is atomic: if it fails, the specification is left unchanged.
+func ApplyMergePatch(spec *Swagger, patch []byte) error {
+	if spec == nil {
+		return fmt.Errorf("cannot patch a nil spec: %w", ErrOverlay)
+	}
+
+	var value any
+	if err := json.Unmarshal(patch, &value); err != nil {
+		return fmt.Errorf("invalid merge patch: %w: %w", err, ErrOverlay)
+	}
+	if _, isObject := value.(map[string]any); !isObject {
+		return fmt.Errorf("a merge patch for a spec must be an object: %w", ErrOverlay)
+	}
+
+	patched := spec.DeepCopy()
+	if err := mergePatch(reflect.ValueOf(patched).Elem(), nil, value); err != nil {
+		return fmt.Errorf("invalid merge patch: %w: %w", err, ErrOverlay)
+	}
+
+	*spec = *patched
+
+	return nil
+}
+
+func applyOverlayAction(root reflect.Value, ptr string, action OverlayAction) error {
+	tokens, err := parsePointer(ptr)
+	if err != nil {
+		return err
+	}
+
+	if action.Remove {
+		return pointerRemove(root, tokens)
+	}
+
+	var value any
+	if err := json.Unmarshal(action.Update, &value); err != nil {
+		return err
+	}
+
+	return mergePatch(root, tokens, value)
+}
+
+// mergePatch merges a value into the node at some JSON pointer, following RFC 7396.
+func mergePatch(root reflect.Value, tokens []string, patch any) error {
+	patchObject, isObject := patch.(map[string]any)
+	if !isObject {
+		return mergeReplace(root, tokens, patch)
+	}
+
+	current, err := pointerGet(root, tokens)
+	if _, isTargetObject := current.(map[string]any); err != nil || !isTargetObject {
+		// the target is replaced by the patch, without its null members
+		return mergeReplace(root, tokens, mergeValues(nil, patchObject))
+	}
+
+	for _, key := range mapKeysSorted(patchObject) {
+		child := append(slices.Clip(tokens), key)
+		value := patchObject[key]
+
+		if value == nil {
+			if _, err := pointerGet(root, child); err != nil {
+				// removing a missing member is a no-op
+				continue
+			}
+			if err := pointerRemove(root, child); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := mergePatch(root, child, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mergeReplace sets the target of a merge patch: an existing value, including an array element, is replaced,
+// a missing one is added.
+func mergeReplace(root reflect.Value, tokens []string, value any) error {
+	if _, err := pointerGet(root, tokens); err == nil {
+		return pointerReplace(root, tokens, value)
+	}
+
+	return pointerAdd(root, tokens, value, false)
+}
+
+// mergeValues applies a merge patch to a generic JSON value.
+func mergeValues(target, patch any) any {
+	patchObject, isObject := patch.(map[string]any)
+	if !isObject {
+		return patch
+	}
+
+	targetObject, isObject := target.(map[string]any)
+	if !isObject {
+		targetObject = make(map[string]any, len(patchObject))
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+
+			continue
+		}
+		targetObject[key] = mergeValues(targetObject[key], value)
+	}
+
+	return targetObject
+}
+
+// overlayTargets resolves the target of an overlay action to JSON pointers.
+func overlayTargets(spec *Swagger, target string) []string {
+	switch {
+	case strings.HasPrefix(target, OverlayOperationIDSelector):
+		id := strings.TrimPrefix(target, OverlayOperationIDSelector)
+
+		return operationPointers(spec, func(op *Operation) bool { return op.ID == id })
+
+	case strings.HasPrefix(target, OverlayTagSelector):
+		tag := strings.TrimPrefix(target, OverlayTagSelector)
+
+		return operationPointers(spec, func(op *Operation) bool { return slices.Contains(op.Tags, tag) })
+
+	default:
+		tokens, err := parsePointer(target)
+		if err != nil {
+			return nil
+		}
+		if _, err := pointerGet(reflect.ValueOf(spec).Elem(), tokens); err != nil {
+			return nil
+		}
+
+		return []string{target}
+	}
+}
+
+// operationPointers returns the JSON pointers to the operations matching a predicate,
+// sorted by path then method.
+func operationPointers(spec *Swagger, match func(*Operation) bool) []string {
+	if spec.Paths == nil {
+		return nil
+	}
+
+	var pointers []string
+	for _, path := range mapKeysSorted(spec.Paths.Paths) {
+		item := spec.Paths.Paths[path]
+		for _, op := range item.operations() {
+			if match(op.operation) {
+				pointers = append(pointers, joinPointer("/paths", path, op.method))
+			}
+		}
+	}
+
+	return pointers
+}
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+const overlaySpec = `{
+  "swagger": "2.0",
+  "info": { "title": "pets", "version": "1.0" },
+  "host": "internal.example.com",
+  "basePath": "/v1",
+  "paths": {
+    "/pets": {
+      "get": { "operationId": "listPets", "tags": ["pets"], "responses": { "200": { "description": "ok" } } },
+      "post": { "operationId": "addPet", "tags": ["pets", "internal"], "responses": { "201": { "description": "created" } } }
+    },
+    "/admin": {
+      "delete": { "operationId": "purge", "tags": ["internal"], "responses": { "204": { "description": "purged" } } }
+    }
+  }
+}`
+
+func TestApplyMergePatch(t *testing.T) {
+	sp := mustSpec(t, overlaySpec)
+
+	require.NoError(t, ApplyMergePatch(sp, []byte(`{
+		"host": "api.example.com",
+		"info": { "description": "public API", "version": null, "x-audience": "public" },
+		"paths": { "/admin": null, "/pets": { "get": { "summary": "list the pets" } } },
+		"externalDocs": { "url": "https://example.com", "description": null }
+	}`)))
+
+	assert.EqualT(t, "api.example.com", sp.Host)
+	assert.EqualT(t, "public API", sp.Info.Description)
+	assert.EqualT(t, "pets", sp.Info.Title)
+	assert.Empty(t, sp.Info.Version)
+	assert.Equal(t, "public", sp.Info.Extensions["x-audience"])
+	assert.NotContains(t, sp.Paths.Paths, "/admin")
+	assert.EqualT(t, "list the pets", sp.Paths.Paths["/pets"].Get.Summary)
+	assert.EqualT(t, "listPets", sp.Paths.Paths["/pets"].Get.ID)
+	require.NotNil(t, sp.ExternalDocs)
+	assert.EqualT(t, "https://example.com", sp.ExternalDocs.URL)
+
+	t.Run("invalid merge patches should leave the spec unchanged", func(t *testing.T) {
+		original := mustSpec(t, overlaySpec)
+		for _, patch := range []string{`[]`, `{`, `{"basePath": 1}`} {
+			sp := original.DeepCopy()
+			require.ErrorIs(t, ApplyMergePatch(sp, []byte(patch)), ErrOverlay)
+			assert.TrueT(t, original.Equal(sp))
+		}
+	})
+}
+
+func TestApplyOverlay(t *testing.T) {
+	overlay, err := ParseOverlay([]byte(`{
+		"overlay": "1.0.0",
+		"info": { "title": "public variant" },
+		"actions": [
+			{ "target": "/host", "update": "api.example.com" },
+			{ "target": "tag:internal", "remove": true },
+			{ "target": "operationId:listPets", "update": { "description": "Lists all the pets." } },
+			{ "target": "operationId:removedLongAgo", "update": { "deprecated": true } },
+			{ "target": "/paths/~1admin", "update": { "x-hidden": true } }
+		]
+	}`))
+	require.NoError(t, err)
+
+	sp := mustSpec(t, overlaySpec)
+	report, err := ApplyOverlay(sp, overlay)
+	require.NoError(t, err)
+
+	assert.EqualT(t, "api.example.com", sp.Host)
+	assert.Nil(t, sp.Paths.Paths["/pets"].Post)
+	assert.Nil(t, sp.Paths.Paths["/admin"].Delete)
+	assert.EqualT(t, "Lists all the pets.", sp.Paths.Paths["/pets"].Get.Description)
+	assert.Equal(t, true, sp.Paths.Paths["/admin"].Extensions["x-hidden"])
+
+	require.Len(t, report.Applied, 4)
+	assert.Equal(t, []string{"/paths/~1admin/delete", "/paths/~1pets/post"}, report.Applied[1].Pointers)
+	assert.Equal(t, []string{"/paths/~1pets/get"}, report.Applied[2].Pointers)
+	require.Len(t, report.Unmatched, 1)
+	assert.EqualT(t, 3, report.Unmatched[0].Index)
+	assert.EqualT(t, "operationId:removedLongAgo", report.Unmatched[0].Target)
+
+	t.Run("applying an overlay should be deterministic", func(t *testing.T) {
+		again := mustSpec(t, overlaySpec)
+		againReport, err := ApplyOverlay(again, overlay)
+		require.NoError(t, err)
+		assert.TrueT(t, sp.Equal(again))
+		assert.Equal(t, report, againReport)
+	})
+
+	t.Run("array elements should be replaced", func(t *testing.T) {
+		sp := mustSpec(t, `{"swagger":"2.0","schemes":["http","ws"],"paths":{"/pets":{"get":{"parameters":[{"name":"limit","in":"query","type":"integer"}]}}}}`)
+		_, err := ApplyOverlay(sp, &Overlay{Actions: []OverlayAction{
+			{Target: "/schemes/0", Update: []byte(`"https"`)},
+			{Target: "/paths/~1pets/get/parameters/0", Update: []byte(`{"maximum": 100}`)},
+		}})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"https", "ws"}, sp.Schemes)
+		params := sp.Paths.Paths["/pets"].Get.Parameters
+		require.Len(t, params, 1)
+		assert.EqualT(t, "limit", params[0].Name)
+		require.NotNil(t, params[0].Maximum)
+		assert.EqualT(t, 100.0, *params[0].Maximum)
+	})
+
+	t.Run("a failed action should leave the spec unchanged", func(t *testing.T) {
+		original := mustSpec(t, overlaySpec)
+		sp := original.DeepCopy()
+		_, err := ApplyOverlay(sp, &Overlay{Actions: []OverlayAction{
+			{Target: "/host", Update: []byte(`"changed"`)},
+			{Target: "operationId:listPets", Update: []byte(`{"responses": 12}`)},
+		}})
+		require.ErrorIs(t, err, ErrOverlay)
+		assert.TrueT(t, original.Equal(sp))
+	})
+}
+
+func TestParseOverlay_Invalid(t *testing.T) {
+	for _, doc := range []string{
+		`{`,
+		`{"actions": [{ "update": {} }]}`,
+		`{"actions": [{ "target": "/host" }]}`,
+		`{"actions": [{ "target": "/host", "update": "x", "remove": true }]}`,
+		`{"actions": [{ "target": "$.paths", "remove": true }]}`,
+		`{"actions": [{ "target": "operationId:", "remove": true }]}`,
+		`{"actions": [{ "target": "tag:", "remove": true }]}`,
+	} {
+		_, err := ParseOverlay([]byte(doc))
+		require.ErrorIsf(t, err, ErrOverlay, "expected %s to be invalid", doc)
+	}
+}
@@ -137,6 +137,13 @@ func (p Parameter) JSONLookup(token string) (any, error) {
 	return r, err
 }
 
+// JSONSet sets a value by the json property name.
+//
+// Missing containers, such as extensions, are created.
+func (p *Parameter) JSONSet(token string, value any) error {
+	return jsonSetToken(p, token, value)
+}
+
 // WithDescription a fluent builder method for the description of the parameter.
 func (p *Parameter) WithDescription(description string) *Parameter {
 	p.Description = description
@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// PatchOperation is a JSON patch operation, as defined by RFC 6902.
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// JSON patch operations.
+const (
+	PatchAdd     = "add"
+	PatchRemove  = "remove"
+	PatchReplace = "replace"
+	PatchMove    = "move"
+	PatchCopy    = "copy"
+	PatchTest    = "test"
+)
+
+// ApplyPatch applies a JSON patch (RFC 6902) to a swagger specification.
+//
+// The patch is applied to the object model, so that untouched nodes are left as is.
+// Vendor extensions, paths, responses and $ref's are addressed like in their JSON representation,
+// e.g. "/paths/~1pets/get/responses/200/schema/$ref" or "/info/x-logo".
+//
+// The patch is atomic: if any operation fails, the specification is left unchanged.
+func ApplyPatch(spec *Swagger, patch []byte) error {
+	var operations []PatchOperation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return fmt.Errorf("invalid JSON patch: %w: %w", err, ErrPatch)
+	}
+
+	return ApplyPatchOperations(spec, operations)
+}
+
+// ApplyPatchOperations applies a list of JSON patch operations to a swagger specification.
+func ApplyPatchOperations(spec *Swagger, operations []PatchOperation) error {
+	if spec == nil {
+		return fmt.Errorf("cannot patch a nil spec: %w", ErrPatch)
+	}
+
+	patched := spec.DeepCopy()
+	root := reflect.ValueOf(patched).Elem()
+
+	for i, op := range operations {
+		if err := applyPatchOperation(root, op); err != nil {
+			return fmt.Errorf("operation %d (%s %q): %w: %w", i, op.Op, op.Path, err, ErrPatch)
+		}
+	}
+
+	*spec = *patched
+
+	return nil
+}
+
+func applyPatchOperation(root reflect.Value, op PatchOperation) error {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return err
+	}
+
+	switch op.Op {
+	case PatchAdd, PatchReplace, PatchTest:
+		if op.Value == nil {
+			return fmt.Errorf("missing value: %w", ErrSpec)
+		}
+		var value any
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return err
+		}
+
+		switch op.Op {
+		case PatchAdd:
+			return pointerAdd(root, path, value, false)
+		case PatchReplace:
+			return pointerReplace(root, path, value)
+		default:
+			actual, err := pointerGet(root, path)
+			if err != nil {
+				return err
+			}
+			if !equalValues(actual, value) {
+				return fmt.Errorf("test failed: value differs: %w", ErrSpec)
+			}
+
+			return nil
+		}
+
+	case PatchRemove:
+		return pointerRemove(root, path)
+
+	case PatchMove, PatchCopy:
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return err
+		}
+		value, err := pointerGet(root, from)
+		if err != nil {
+			return err
+		}
+
+		if op.Op == PatchMove {
+			if op.From == op.Path {
+				return nil
+			}
+			if strings.HasPrefix(op.Path, op.From+"/") {
+				return fmt.Errorf("cannot move %q into one of its children: %w", op.From, ErrSpec)
+			}
+			if err := pointerRemove(root, from); err != nil {
+				return err
+			}
+		}
+
+		return pointerAdd(root, path, value, false)
+
+	default:
+		return fmt.Errorf("unsupported operation %q: %w", op.Op, ErrSpec)
+	}
+}
+
+// GeneratePatch produces a JSON patch (RFC 6902) which transforms the old specification into the new one.
+//
+// The patch is computed on the JSON representation of the specifications. Values which are
+// semantically equal (e.g. numbers with different representations) are not reported.
+// Changes in arrays are reported element by element.
+func GeneratePatch(oldSpec, newSpec *Swagger) ([]byte, error) {
+	operations, err := GeneratePatchOperations(oldSpec, newSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(operations)
+}
+
+// GeneratePatchOperations produces the list of JSON patch operations which transforms the old specification into the new one.
+func GeneratePatchOperations(oldSpec, newSpec *Swagger) ([]PatchOperation, error) {
+	if oldSpec == nil {
+		oldSpec = &Swagger{}
+	}
+	if newSpec == nil {
+		newSpec = &Swagger{}
+	}
+
+	oldDoc, err := toJSONValue(reflect.ValueOf(oldSpec).Elem())
+	if err != nil {
+		return nil, err
+	}
+	newDoc, err := toJSONValue(reflect.ValueOf(newSpec).Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	g := &patchGenerator{operations: []PatchOperation{}}
+	if err := g.diff("", oldDoc, newDoc); err != nil {
+		return nil, err
+	}
+
+	return g.operations, nil
+}
+
+type patchGenerator struct {
+	operations []PatchOperation
+}
+
+func (g *patchGenerator) add(op, path string, value any, withValue bool) error {
+	operation := PatchOperation{Op: op, Path: path}
+	if withValue {
+		buf, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		operation.Value = buf
+	}
+	g.operations = append(g.operations, operation)
+
+	return nil
+}
+
+func (g *patchGenerator) diff(path string, o, nw any) error {
+	if equalValues(o, nw) {
+		return nil
+	}
+
+	switch oldValue := o.(type) {
+	case map[string]any:
+		newValue, ok := nw.(map[string]any)
+		if !ok {
+			break
+		}
+
+		for _, key := range mapKeysUnion(oldValue, newValue) {
+			oldChild, inOld := oldValue[key]
+			newChild, inNew := newValue[key]
+			child := joinPointer(path, key)
+			var err error
+			switch {
+			case !inOld:
+				err = g.add(PatchAdd, child, newChild, true)
+			case !inNew:
+				err = g.add(PatchRemove, child, nil, false)
+			default:
+				err = g.diff(child, oldChild, newChild)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+
+	case []any:
+		newValue, ok := nw.([]any)
+		if !ok {
+			break
+		}
+
+		common := min(len(oldValue), len(newValue))
+		for i := range common {
+			if err := g.diff(joinPointer(path, strconv.Itoa(i)), oldValue[i], newValue[i]); err != nil {
+				return err
+			}
+		}
+		for i := common; i < len(newValue); i++ {
+			if err := g.add(PatchAdd, joinPointer(path, strconv.Itoa(i)), newValue[i], true); err != nil {
+				return err
+			}
+		}
+		// remove trailing elements from the end, so that indices remain valid
+		for i := len(oldValue) - 1; i >= common; i-- {
+			if err := g.add(PatchRemove, joinPointer(path, strconv.Itoa(i)), nil, false); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return g.add(PatchReplace, path, nw, true)
+}
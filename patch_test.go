@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestApplyPatch(t *testing.T) {
+	sp := mustSpec(t, diffOldSpec)
+
+	require.NoError(t, ApplyPatch(sp, []byte(`[
+		{ "op": "replace", "path": "/host", "value": "staging.example.com" },
+		{ "op": "add", "path": "/info/x-audience", "value": "internal" },
+		{ "op": "add", "path": "/x-team", "value": "platform" },
+		{ "op": "remove", "path": "/paths/~1pets/post" },
+		{ "op": "add", "path": "/paths/~1pets/get/parameters/-", "value": { "name": "page", "in": "query", "type": "integer" } },
+		{ "op": "replace", "path": "/paths/~1pets/get/responses/200/description", "value": "the pets" },
+		{ "op": "add", "path": "/paths/~1pets/get/responses/404", "value": { "description": "not found" } },
+		{ "op": "remove", "path": "/paths/~1pets/get/responses/default" },
+		{ "op": "add", "path": "/definitions/Pet/properties/name/x-order", "value": 1 },
+		{ "op": "replace", "path": "/definitions/Pet/properties/tag/type", "value": ["string", "null"] },
+		{ "op": "add", "path": "/definitions/Pet/required/0", "value": "age" },
+		{ "op": "replace", "path": "/paths/~1pets/get/responses/200/schema/items/$ref", "value": "#/definitions/Error" },
+		{ "op": "add", "path": "/paths/~1owners", "value": { "get": { "responses": { "200": { "description": "ok" } } } } },
+		{ "op": "test", "path": "/paths/~1pets/get/parameters/0/maximum", "value": 100 }
+	]`)))
+
+	assert.EqualT(t, "staging.example.com", sp.Host)
+	assert.Equal(t, "internal", sp.Info.Extensions["x-audience"])
+	assert.Equal(t, "platform", sp.Extensions["x-team"])
+
+	pets := sp.Paths.Paths["/pets"]
+	assert.Nil(t, pets.Post)
+	require.Len(t, pets.Get.Parameters, 3)
+	assert.EqualT(t, "page", pets.Get.Parameters[2].Name)
+	assert.EqualT(t, "the pets", pets.Get.Responses.StatusCodeResponses[200].Description)
+	assert.EqualT(t, "not found", pets.Get.Responses.StatusCodeResponses[404].Description)
+	assert.Nil(t, pets.Get.Responses.Default)
+	assert.EqualT(t, "#/definitions/Error", pets.Get.Responses.StatusCodeResponses[200].Schema.Items.Schema.Ref.String())
+
+	pet := sp.Definitions["Pet"]
+	assert.InDelta(t, 1.0, pet.Properties["name"].Extensions["x-order"], 1e-9)
+	assert.Equal(t, StringOrArray{"string", "null"}, pet.Properties["tag"].Type)
+	assert.Equal(t, []string{"age", "name"}, pet.Required)
+
+	require.Contains(t, sp.Paths.Paths, "/owners")
+	assert.EqualT(t, "ok", sp.Paths.Paths["/owners"].Get.Responses.StatusCodeResponses[200].Description)
+}
+
+func TestApplyPatch_MoveAndCopy(t *testing.T) {
+	sp := mustSpec(t, diffOldSpec)
+
+	require.NoError(t, ApplyPatch(sp, []byte(`[
+		{ "op": "copy", "from": "/definitions/Pet", "path": "/definitions/Animal" },
+		{ "op": "move", "from": "/paths/~1pets~1{id}", "path": "/paths/~1animals~1{id}" },
+		{ "op": "move", "from": "/definitions/Pet/properties/tag", "path": "/definitions/Pet/properties/label" }
+	]`)))
+
+	require.Contains(t, sp.Definitions, "Animal")
+	assert.Contains(t, sp.Definitions["Animal"].Properties, "tag")
+	assert.NotContains(t, sp.Definitions["Pet"].Properties, "tag")
+	assert.Contains(t, sp.Definitions["Pet"].Properties, "label")
+	assert.NotContains(t, sp.Paths.Paths, "/pets/{id}")
+	assert.Contains(t, sp.Paths.Paths, "/animals/{id}")
+}
+
+func TestApplyPatch_Errors(t *testing.T) {
+	original := mustSpec(t, diffOldSpec)
+
+	for _, tc := range []struct {
+		name  string
+		patch string
+	}{
+		{"invalid json", `{`},
+		{"failed test", `[{ "op": "test", "path": "/host", "value": "other" }]`},
+		{"missing target", `[{ "op": "replace", "path": "/paths/~1unknown/get", "value": {} }]`},
+		{"remove missing value", `[{ "op": "remove", "path": "/definitions/Unknown" }]`},
+		{"unknown field", `[{ "op": "add", "path": "/info/unknown", "value": 1 }]`},
+		{"missing value", `[{ "op": "add", "path": "/host" }]`},
+		{"type mismatch", `[{ "op": "replace", "path": "/basePath", "value": 12 }]`},
+		{"array index out of range", `[{ "op": "add", "path": "/schemes/5", "value": "ws" }]`},
+		{"move into a child", `[{ "op": "move", "from": "/definitions", "path": "/definitions/Pet/x-defs" }]`},
+		{"unknown operation", `[{ "op": "merge", "path": "/host", "value": "x" }]`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sp := original.DeepCopy()
+			err := ApplyPatch(sp, []byte(tc.patch))
+			require.ErrorIs(t, err, ErrPatch)
+			assert.TrueT(t, original.Equal(sp), "a failed patch should leave the spec unchanged")
+		})
+	}
+}
+
+func TestGeneratePatch(t *testing.T) {
+	oldSpec, newSpec := mustSpec(t, diffOldSpec), mustSpec(t, diffNewSpec)
+
+	patch, err := GeneratePatch(oldSpec, newSpec)
+	require.NoError(t, err)
+
+	var operations []PatchOperation
+	require.NoError(t, json.Unmarshal(patch, &operations))
+	assert.NotEmpty(t, operations)
+
+	patched := oldSpec.DeepCopy()
+	require.NoError(t, ApplyPatch(patched, patch))
+	assert.TrueT(t, newSpec.Equal(patched))
+
+	t.Run("identical specs should produce an empty patch", func(t *testing.T) {
+		patch, err := GeneratePatch(oldSpec, oldSpec.DeepCopy())
+		require.NoError(t, err)
+		assert.JSONEqBytes(t, []byte(`[]`), patch)
+	})
+
+	t.Run("round trip on a larger spec", func(t *testing.T) {
+		var sp Swagger
+		require.NoError(t, json.Unmarshal(PetStore20, &sp))
+
+		modified := sp.DeepCopy()
+		modified.Info.Title = "changed"
+		modified.Schemes = []string{"https"}
+		delete(modified.Definitions, "Tag")
+		pet := modified.Definitions["Pet"]
+		pet.Required = append(pet.Required, "tags")
+		modified.Definitions["Pet"] = pet
+
+		patch, err := GeneratePatch(&sp, modified)
+		require.NoError(t, err)
+
+		patched := sp.DeepCopy()
+		require.NoError(t, ApplyPatch(patched, patch))
+		assert.TrueT(t, modified.Equal(patched))
+	})
+}
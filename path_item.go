@@ -5,6 +5,8 @@ package spec
 
 import (
 	"encoding/json"
+	"iter"
+	"strings"
 
 	"github.com/go-openapi/jsonpointer"
 	"github.com/go-openapi/swag/jsonutils"
@@ -46,6 +48,26 @@ func (p PathItem) JSONLookup(token string) (any, error) {
 	return r, err
 }
 
+// Operations iterates over the operations defined on this path item, by method.
+//
+// Methods are yielded in lower case, in the order get, put, post, delete, options, head and patch.
+func (p *PathItem) Operations() iter.Seq2[string, *Operation] {
+	return func(yield func(string, *Operation) bool) {
+		for _, op := range p.operations() {
+			if !yield(op.method, op.operation) {
+				return
+			}
+		}
+	}
+}
+
+// JSONSet sets a value by the json property name.
+//
+// Missing containers, such as extensions, are created.
+func (p *PathItem) JSONSet(token string, value any) error {
+	return jsonSetToken(p, token, value)
+}
+
 // UnmarshalJSON hydrates this items instance with the data from JSON.
 func (p *PathItem) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &p.Refable); err != nil {
@@ -74,3 +96,53 @@ func (p PathItem) MarshalJSON() ([]byte, error) {
 	concated := jsonutils.ConcatJSON(b3, b4, b5)
 	return concated, nil
 }
+
+// pathItemMethods lists the methods supported by a path item, in the order
+// used to iterate over operations.
+//
+//nolint:gochecknoglobals // constant-like list of methods
+var pathItemMethods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+type methodOperation struct {
+	method    string
+	operation *Operation
+}
+
+// operations lists the operations defined on this path item, in a stable order.
+//
+// Methods are reported as lower-case names, as they appear in a swagger document.
+func (p *PathItemProps) operations() []methodOperation {
+	ops := make([]methodOperation, 0, len(pathItemMethods))
+	for _, method := range pathItemMethods {
+		if op := *p.operationField(method); op != nil {
+			ops = append(ops, methodOperation{method: method, operation: op})
+		}
+	}
+
+	return ops
+}
+
+// operationField returns the address of the field holding the operation for a method,
+// or nil if the method is not supported.
+//
+// The method name is case-insensitive.
+func (p *PathItemProps) operationField(method string) **Operation {
+	switch strings.ToLower(method) {
+	case "get":
+		return &p.Get
+	case "put":
+		return &p.Put
+	case "post":
+		return &p.Post
+	case "delete":
+		return &p.Delete
+	case "options":
+		return &p.Options
+	case "head":
+		return &p.Head
+	case "patch":
+		return &p.Patch
+	default:
+		return nil
+	}
+}
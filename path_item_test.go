@@ -62,3 +62,21 @@ const pathItemJSON = `{
 func TestIntegrationPathItem(t *testing.T) {
 	assert.JSONUnmarshalAsT(t, pathItem, pathItemJSON)
 }
+
+func TestPathItem_Operations(t *testing.T) {
+	item := &PathItem{PathItemProps: PathItemProps{
+		Patch: NewOperation("patchPet"),
+		Get:   NewOperation("getPet"),
+		Post:  NewOperation("createPet"),
+	}}
+
+	var methods []string
+	for method, op := range item.Operations() {
+		methods = append(methods, method+" "+op.ID)
+	}
+	assert.Equal(t, []string{"get getPet", "post createPet", "patch patchPet"}, methods)
+
+	for range item.Operations() {
+		break
+	}
+}
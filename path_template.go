@@ -0,0 +1,237 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// PathTemplate is a parsed path template, i.e. a key of [Paths] such as "/pets/{id}".
+//
+// A segment of the template is either static text, a single parameter such as "{id}",
+// or text mixed with parameters, such as "{name}.{ext}".
+type PathTemplate struct {
+	path     string
+	segments []templateSegment
+}
+
+// templateSegment is a segment of a path template.
+//
+// The literals surround the parameters: there is always one more literal than parameters.
+type templateSegment struct {
+	literals []string
+	params   []string
+}
+
+// ParsePathTemplate parses a path template.
+//
+// The template must start with "/". Parameters must be named, unique, and separated by some text
+// when they share a segment.
+func ParsePathTemplate(path string) (*PathTemplate, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("path template %q must start with \"/\": %w", path, ErrSpec)
+	}
+
+	t := &PathTemplate{path: path}
+	seen := make(map[string]bool)
+	for _, segment := range strings.Split(path[1:], "/") {
+		parsed, err := parseTemplateSegment(segment)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path template %q: %w", path, err)
+		}
+
+		for _, name := range parsed.params {
+			if seen[name] {
+				return nil, fmt.Errorf("invalid path template %q: duplicate parameter %q: %w", path, name, ErrSpec)
+			}
+			seen[name] = true
+		}
+
+		t.segments = append(t.segments, parsed)
+	}
+
+	return t, nil
+}
+
+func parseTemplateSegment(segment string) (templateSegment, error) {
+	parsed := templateSegment{literals: []string{""}}
+	for rest := segment; rest != ""; {
+		start := strings.IndexAny(rest, "{}")
+		if start < 0 {
+			parsed.literals[len(parsed.literals)-1] += rest
+
+			break
+		}
+		if rest[start] == '}' {
+			return templateSegment{}, fmt.Errorf("unexpected '}' in segment %q: %w", segment, ErrSpec)
+		}
+		parsed.literals[len(parsed.literals)-1] += rest[:start]
+
+		end := strings.IndexAny(rest[start+1:], "{}")
+		if end <= 0 || rest[start+1+end] != '}' {
+			return templateSegment{}, fmt.Errorf("invalid parameter in segment %q: %w", segment, ErrSpec)
+		}
+		if len(parsed.params) > 0 && parsed.literals[len(parsed.literals)-1] == "" {
+			return templateSegment{}, fmt.Errorf("parameters must be separated by some text in segment %q: %w", segment, ErrSpec)
+		}
+
+		parsed.params = append(parsed.params, rest[start+1:start+1+end])
+		parsed.literals = append(parsed.literals, "")
+		rest = rest[start+end+2:]
+	}
+
+	return parsed, nil
+}
+
+// String returns the path template, as parsed.
+func (t *PathTemplate) String() string {
+	return t.path
+}
+
+// Params returns the names of the parameters of the template, in order of appearance.
+func (t *PathTemplate) Params() []string {
+	var names []string
+	for _, segment := range t.segments {
+		names = append(names, segment.params...)
+	}
+
+	return names
+}
+
+// Expand builds a path from the template, replacing parameters with their escaped value.
+//
+// It fails when a value is missing for a parameter.
+func (t *PathTemplate) Expand(values map[string]string) (string, error) {
+	var b strings.Builder
+	for _, segment := range t.segments {
+		b.WriteByte('/')
+		b.WriteString(segment.literals[0])
+		for i, name := range segment.params {
+			value, ok := values[name]
+			if !ok {
+				return "", fmt.Errorf("missing value for path parameter %q in %q: %w", name, t.path, ErrSpec)
+			}
+			b.WriteString(url.PathEscape(value))
+			b.WriteString(segment.literals[i+1])
+		}
+	}
+
+	return b.String(), nil
+}
+
+// Match matches an escaped URL path with the template, and returns the unescaped values of the parameters.
+//
+// When a segment mixes text and parameters, each parameter but the last one stops at the first occurrence
+// of the text which follows it, e.g. "{name}.{ext}" matches "cat.tar.gz" with name "cat" and ext "tar.gz".
+func (t *PathTemplate) Match(path string) (map[string]string, bool) {
+	segments, ok := splitURLPath(path)
+	if !ok || len(segments) != len(t.segments) {
+		return nil, false
+	}
+
+	values := make(map[string]string)
+	for i, segment := range t.segments {
+		captured, ok := segment.capture(segments[i])
+		if !ok {
+			return nil, false
+		}
+		for j, name := range segment.params {
+			values[name] = captured[j]
+		}
+	}
+
+	return values, true
+}
+
+// normalized returns the template without the names of its parameters,
+// so that equivalent templates such as "/pets/{id}" and "/pets/{petId}" are equal.
+func (t *PathTemplate) normalized() string {
+	shapes := make([]string, 0, len(t.segments))
+	for _, segment := range t.segments {
+		shapes = append(shapes, segment.shape())
+	}
+
+	return "/" + strings.Join(shapes, "/")
+}
+
+// isStatic tells if the segment has no parameter.
+func (s templateSegment) isStatic() bool {
+	return len(s.params) == 0
+}
+
+// isParam tells if the segment is made of a single parameter.
+func (s templateSegment) isParam() bool {
+	return len(s.params) == 1 && s.literals[0] == "" && s.literals[1] == ""
+}
+
+// shape returns the segment without the names of its parameters, e.g. "{}.{}" for "{name}.{ext}".
+func (s templateSegment) shape() string {
+	return strings.Join(s.literals, "{}")
+}
+
+// capture extracts the values of the parameters of the segment from an unescaped path segment.
+func (s templateSegment) capture(segment string) ([]string, bool) {
+	if s.isStatic() {
+		return nil, segment == s.literals[0]
+	}
+
+	first, last := s.literals[0], s.literals[len(s.literals)-1]
+	if len(segment) < len(first)+len(last) || !strings.HasPrefix(segment, first) || !strings.HasSuffix(segment, last) {
+		return nil, false
+	}
+
+	rest := segment[len(first) : len(segment)-len(last)]
+	values := make([]string, 0, len(s.params))
+	for _, literal := range s.literals[1 : len(s.literals)-1] {
+		end := strings.Index(rest, literal)
+		if end <= 0 {
+			return nil, false
+		}
+		values = append(values, rest[:end])
+		rest = rest[end+len(literal):]
+	}
+	if rest == "" {
+		return nil, false
+	}
+
+	return append(values, rest), true
+}
+
+// splitURLPath splits an escaped URL path into unescaped segments.
+func splitURLPath(path string) ([]string, bool) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, false
+	}
+
+	segments := strings.Split(path[1:], "/")
+	for i, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, false
+		}
+		segments[i] = unescaped
+	}
+
+	return segments, true
+}
+
+// CheckPathTemplates checks the path templates of the spec against the parameters declared for them.
+//
+// It reports invalid and equivalent templates, parameters of a template which are not declared as path
+// parameters by an operation or its path item, and path parameters which do not appear in their template.
+//
+// It returns nil when no mismatch is found, or a [ValidationErrors] with all the mismatches found.
+// These checks are also part of [Swagger.Validate].
+func (s *Swagger) CheckPathTemplates() error {
+	v := &validator{spec: s, templatesOnly: true}
+	v.paths()
+
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return v.errs
+}
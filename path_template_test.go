@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestParsePathTemplate(t *testing.T) {
+	template, err := ParsePathTemplate("/pets/{id}/photos/{name}.{ext}")
+	require.NoError(t, err)
+	assert.EqualT(t, "/pets/{id}/photos/{name}.{ext}", template.String())
+	assert.Equal(t, []string{"id", "name", "ext"}, template.Params())
+	assert.EqualT(t, "/pets/{}/photos/{}.{}", template.normalized())
+
+	static, err := ParsePathTemplate("/")
+	require.NoError(t, err)
+	assert.Empty(t, static.Params())
+
+	for _, path := range []string{"pets", "/pets/{id", "/pets/{}", "/pets/id}", "/pets/{a}{b}", "/pets/{{a}}", "/pets/{id}/{id}"} {
+		t.Run(path, func(t *testing.T) {
+			_, err := ParsePathTemplate(path)
+			require.ErrorIs(t, err, ErrSpec)
+		})
+	}
+}
+
+func TestPathTemplate_Expand(t *testing.T) {
+	template, err := ParsePathTemplate("/pets/{id}/photos/{name}.{ext}")
+	require.NoError(t, err)
+
+	path, err := template.Expand(map[string]string{"id": "a/b c", "name": "cat", "ext": "png"})
+	require.NoError(t, err)
+	assert.EqualT(t, "/pets/a%2Fb%20c/photos/cat.png", path)
+
+	values, ok := template.Match(path)
+	require.TrueT(t, ok)
+	assert.Equal(t, map[string]string{"id": "a/b c", "name": "cat", "ext": "png"}, values)
+
+	_, err = template.Expand(map[string]string{"id": "1"})
+	require.ErrorIs(t, err, ErrSpec)
+}
+
+func TestPathTemplate_Match(t *testing.T) {
+	template, err := ParsePathTemplate("/pets/{id}/photos/{name}.{ext}")
+	require.NoError(t, err)
+
+	values, ok := template.Match("/pets/42/photos/cat.tar.gz")
+	require.TrueT(t, ok)
+	assert.Equal(t, map[string]string{"id": "42", "name": "cat", "ext": "tar.gz"}, values)
+
+	for _, path := range []string{"pets/42/photos/cat.png", "/pets/42/photos/cat", "/pets/42/photos/.png", "/pets/42/photos/cat.", "/pets/42", "/pets/%zz/photos/a.b", "/stores/42/photos/a.b"} {
+		_, ok := template.Match(path)
+		assert.FalseT(t, ok, path)
+	}
+}
+
+func TestSwagger_CheckPathTemplates(t *testing.T) {
+	require.NoError(t, mustSpec(t, validSpec).CheckPathTemplates())
+
+	sp := mustSpec(t, `{
+  "swagger": "2.0",
+  "paths": {
+    "/pets/{id}": {
+      "parameters": [ { "name": "petId", "in": "path", "type": "string" } ],
+      "get": {
+        "parameters": [
+          { "name": "id", "in": "path", "type": "string", "required": true },
+          { "name": "id", "in": "path", "type": "string", "required": true }
+        ]
+      },
+      "put": {}
+    },
+    "/pets/{name}": {},
+    "/pets/{id}/toys/{id}": { "get": {} }
+  }
+}`)
+
+	err := sp.CheckPathTemplates()
+	require.ErrorIs(t, err, ErrValidation)
+
+	var errs ValidationErrors
+	require.TrueT(t, errors.As(err, &errs))
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		messages = append(messages, e.Error())
+	}
+	assert.Equal(t, []string{
+		`/paths/~1pets~1{id}/put: the path parameter "id" is not declared`,
+		`/paths/~1pets~1{id}/parameters/0: the path parameter "petId" does not appear in the path template`,
+		`/paths/~1pets~1{id}~1toys~1{id}: the path template "/pets/{id}/toys/{id}" is invalid`,
+		`/paths/~1pets~1{name}: the path "/pets/{name}" is equivalent to "/pets/{id}"`,
+	}, messages)
+}
@@ -34,6 +34,13 @@ func (p Paths) JSONLookup(token string) (any, error) {
 	return nil, fmt.Errorf("object has no field %q: %w", token, ErrSpec)
 }
 
+// JSONSet sets a value by the json property name.
+//
+// Missing containers, such as extensions, are created.
+func (p *Paths) JSONSet(token string, value any) error {
+	return jsonSetToken(p, token, value)
+}
+
 // UnmarshalJSON hydrates this items instance with the data from JSON.
 func (p *Paths) UnmarshalJSON(data []byte) error {
 	var res map[string]json.RawMessage
@@ -0,0 +1,592 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+)
+
+// This file provides write access to the spec object model through JSON pointers.
+//
+// The JSONLookup methods return copies of the nodes they traverse, so they cannot be used to write.
+// Instead, pointers are resolved here by reflection on addressable values. Map entries, which are
+// not addressable, are copied, modified then stored back into their map.
+//
+// With jsonpointer, writes go through the JSONSet method of the last node traversed. Since JSONLookup
+// returns copies of map entries, jsonpointer cannot write into structs held by maps, such as a path item
+// or a definition: SetByPointer resolves the whole pointer by reflection, and writes such entries back.
+
+//nolint:gochecknoglobals // constant-like reflected types
+var (
+	typeOfRef        = reflect.TypeFor[Ref]()
+	typeOfExtensions = reflect.TypeFor[Extensions]()
+)
+
+// pointerSlot is an addressable location in the object model, designated by a JSON pointer token.
+type pointerSlot struct {
+	// value is an addressable value for the slot
+	value reflect.Value
+
+	// exists tells if the slot holds a value
+	exists bool
+
+	// store writes back the value into its container, when the value is a copy
+	store func()
+
+	// remove deletes the value from its container
+	remove func()
+
+	// insert adds a value to an array container, at the position of the slot.
+	// It is nil for object containers.
+	insert func(reflect.Value)
+}
+
+// SetByPointer sets a value at a JSON pointer in a document of the object model,
+// e.g. "/paths/~1pets/get/responses/200/schema/properties/name" in a *Swagger.
+//
+// The document must be a pointer to one of the types of this package, such as *Swagger or *Schema.
+// The value is either of the type expected at this location or its generic JSON representation.
+//
+// Missing intermediate containers, such as path items, responses, schemas or maps are created.
+// In arrays, existing elements are replaced and the "-" token appends a new element.
+func SetByPointer(document any, ptr string, value any) error {
+	tokens, err := parsePointer(ptr)
+	if err != nil {
+		return err
+	}
+
+	root := reflect.ValueOf(document)
+	if root.Kind() != reflect.Pointer || root.IsNil() {
+		return fmt.Errorf("cannot set a value in a %T, a non-nil pointer is required: %w", document, ErrSpec)
+	}
+
+	return pointerSet(root.Elem(), tokens, value)
+}
+
+// jsonSetToken implements the JSONSetable interface of jsonpointer for the types of the object model.
+func jsonSetToken(node any, token string, value any) error {
+	return pointerSet(reflect.ValueOf(node).Elem(), []string{token}, value)
+}
+
+// setMapEntry implements the JSONSetable interface of jsonpointer for maps of the object model.
+func setMapEntry[V any](m map[string]V, token string, value any) error {
+	if m == nil {
+		return fmt.Errorf("cannot set %q in a nil map: %w", token, ErrSpec)
+	}
+
+	var entry V
+	if err := assignJSONValue(reflect.ValueOf(&entry).Elem(), value); err != nil {
+		return err
+	}
+	m[token] = entry
+
+	return nil
+}
+
+// pointerSet sets a value at a JSON pointer, creating missing containers.
+//
+// Unlike pointerAdd, existing array elements are replaced rather than shifted.
+func pointerSet(root reflect.Value, tokens []string, value any) error {
+	if len(tokens) == 0 {
+		return assignJSONValue(root, value)
+	}
+
+	return atPointer(root, tokens, true, func(s pointerSlot) error {
+		if s.insert != nil && !s.exists {
+			v := reflect.New(s.value.Type()).Elem()
+			if err := assignJSONValue(v, value); err != nil {
+				return err
+			}
+			s.insert(v)
+
+			return nil
+		}
+
+		if err := assignJSONValue(s.value, value); err != nil {
+			return err
+		}
+		s.store()
+
+		return nil
+	})
+}
+
+// parsePointer splits a JSON pointer into its unescaped tokens.
+func parsePointer(ptr string) ([]string, error) {
+	p, err := jsonpointer.New(ptr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON pointer %q: %w: %w", ptr, err, ErrSpec)
+	}
+
+	return p.DecodedTokens(), nil
+}
+
+// pointerGet returns the value designated by a JSON pointer, in its generic JSON form.
+func pointerGet(root reflect.Value, tokens []string) (any, error) {
+	if len(tokens) == 0 {
+		return toJSONValue(root)
+	}
+
+	var result any
+	err := atPointer(root, tokens, false, func(s pointerSlot) error {
+		if !s.exists {
+			return fmt.Errorf("no value at %q: %w", tokens[len(tokens)-1], ErrSpec)
+		}
+
+		var err error
+		result, err = toJSONValue(s.value)
+
+		return err
+	})
+
+	return result, err
+}
+
+// pointerAdd adds a value at a JSON pointer, following the semantics of a JSON patch "add" operation:
+// object members are created or replaced, array elements are inserted.
+//
+// When create is true, missing intermediate containers are created.
+func pointerAdd(root reflect.Value, tokens []string, value any, create bool) error {
+	if len(tokens) == 0 {
+		return assignJSONValue(root, value)
+	}
+
+	return atPointer(root, tokens, create, func(s pointerSlot) error {
+		if s.insert != nil {
+			v := reflect.New(s.value.Type()).Elem()
+			if err := assignJSONValue(v, value); err != nil {
+				return err
+			}
+			s.insert(v)
+
+			return nil
+		}
+
+		if err := assignJSONValue(s.value, value); err != nil {
+			return err
+		}
+		s.store()
+
+		return nil
+	})
+}
+
+// pointerReplace replaces an existing value at a JSON pointer.
+func pointerReplace(root reflect.Value, tokens []string, value any) error {
+	if len(tokens) == 0 {
+		return assignJSONValue(root, value)
+	}
+
+	return atPointer(root, tokens, false, func(s pointerSlot) error {
+		if !s.exists {
+			return fmt.Errorf("no value to replace at %q: %w", tokens[len(tokens)-1], ErrSpec)
+		}
+		if err := assignJSONValue(s.value, value); err != nil {
+			return err
+		}
+		s.store()
+
+		return nil
+	})
+}
+
+// pointerRemove removes an existing value at a JSON pointer.
+//
+// Struct fields are reset to their zero value, map entries are deleted and array elements are removed.
+func pointerRemove(root reflect.Value, tokens []string) error {
+	if len(tokens) == 0 {
+		return fmt.Errorf("cannot remove the root of a document: %w", ErrSpec)
+	}
+
+	return atPointer(root, tokens, false, func(s pointerSlot) error {
+		if !s.exists {
+			return fmt.Errorf("no value to remove at %q: %w", tokens[len(tokens)-1], ErrSpec)
+		}
+		s.remove()
+
+		return nil
+	})
+}
+
+// atPointer navigates to the slot designated by a JSON pointer, then calls fn with it.
+func atPointer(v reflect.Value, tokens []string, create bool, fn func(pointerSlot) error) error {
+	v, err := derefForWrite(v, create)
+	if err != nil {
+		return err
+	}
+
+	if v.Kind() == reflect.Interface {
+		// generic JSON values are copied, then stored back
+		inner := v.Elem()
+		cp := reflect.New(inner.Type()).Elem()
+		cp.Set(inner)
+		if err := atPointer(cp, tokens, create, fn); err != nil {
+			return err
+		}
+		v.Set(cp)
+
+		return nil
+	}
+
+	s, err := lookupSlot(v, tokens[0], create && len(tokens) > 1)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 1 {
+		return fn(s)
+	}
+
+	if !s.exists {
+		if !create || s.insert != nil {
+			return fmt.Errorf("no value at %q: %w", tokens[0], ErrSpec)
+		}
+		if s.value.Kind() == reflect.Interface {
+			s.value.Set(reflect.ValueOf(map[string]any{}))
+		}
+	}
+
+	if err := atPointer(s.value, tokens[1:], create, fn); err != nil {
+		return err
+	}
+	s.store()
+
+	return nil
+}
+
+// derefForWrite follows pointers, allocating nil ones when create is true.
+func derefForWrite(v reflect.Value, create bool) (reflect.Value, error) {
+	for {
+		switch v.Kind() {
+		case reflect.Pointer:
+			if v.IsNil() {
+				if !create {
+					return v, fmt.Errorf("cannot traverse a nil %s: %w", v.Type(), ErrSpec)
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		case reflect.Interface:
+			if v.IsNil() {
+				if !create {
+					return v, fmt.Errorf("cannot traverse a null value: %w", ErrSpec)
+				}
+				v.Set(reflect.ValueOf(map[string]any{}))
+			}
+
+			return v, nil
+		default:
+			return v, nil
+		}
+	}
+}
+
+// lookupSlot finds the slot for a token in a container.
+func lookupSlot(v reflect.Value, token string, create bool) (pointerSlot, error) {
+	switch v.Kind() {
+	case reflect.Map:
+		return mapSlot(v, token)
+	case reflect.Slice:
+		return sliceSlot(v, token)
+	case reflect.Struct:
+		return structSlot(v, token, create)
+	default:
+		return pointerSlot{}, fmt.Errorf("cannot resolve token %q in a %s: %w", token, v.Type(), ErrSpec)
+	}
+}
+
+func mapSlot(m reflect.Value, token string) (pointerSlot, error) {
+	var key reflect.Value
+	switch m.Type().Key().Kind() {
+	case reflect.String:
+		key = reflect.ValueOf(token).Convert(m.Type().Key())
+	case reflect.Int:
+		i, err := strconv.Atoi(token)
+		if err != nil {
+			return pointerSlot{}, fmt.Errorf("invalid key %q: %w", token, ErrSpec)
+		}
+		key = reflect.ValueOf(i).Convert(m.Type().Key())
+	default:
+		return pointerSlot{}, fmt.Errorf("unsupported map type %s: %w", m.Type(), ErrSpec)
+	}
+
+	value := reflect.New(m.Type().Elem()).Elem()
+	existing := m.MapIndex(key)
+	if existing.IsValid() {
+		value.Set(existing)
+	}
+
+	return pointerSlot{
+		value:  value,
+		exists: existing.IsValid(),
+		store: func() {
+			if m.IsNil() {
+				m.Set(reflect.MakeMap(m.Type()))
+			}
+			m.SetMapIndex(key, value)
+		},
+		remove: func() {
+			m.SetMapIndex(key, reflect.Value{})
+		},
+	}, nil
+}
+
+func sliceSlot(s reflect.Value, token string) (pointerSlot, error) {
+	index := s.Len()
+	if token != "-" {
+		i, err := strconv.Atoi(token)
+		if err != nil || i < 0 || i > s.Len() || (token != "0" && strings.HasPrefix(token, "0")) {
+			return pointerSlot{}, fmt.Errorf("invalid array index %q: %w", token, ErrSpec)
+		}
+		index = i
+	}
+
+	slot := pointerSlot{
+		exists: index < s.Len(),
+		store:  func() {},
+		remove: func() {
+			s.Set(reflect.AppendSlice(s.Slice(0, index), s.Slice(index+1, s.Len())))
+		},
+		insert: func(v reflect.Value) {
+			grown := reflect.MakeSlice(s.Type(), 0, s.Len()+1)
+			grown = reflect.AppendSlice(grown, s.Slice(0, index))
+			grown = reflect.Append(grown, v)
+			grown = reflect.AppendSlice(grown, s.Slice(index, s.Len()))
+			s.Set(grown)
+		},
+	}
+	if slot.exists {
+		slot.value = s.Index(index)
+	} else {
+		slot.value = reflect.New(s.Type().Elem()).Elem()
+	}
+
+	return slot, nil
+}
+
+// structSlot resolves a token in one of the types of the object model.
+//
+// Types with a custom JSON representation are handled first: vendor extensions, paths, responses,
+// schemas or booleans, schemas or arrays, $ref's and extra schema properties.
+func structSlot(v reflect.Value, token string, create bool) (pointerSlot, error) {
+	switch v.Type() {
+	case reflect.TypeFor[Paths]():
+		if !isExtensionKey(token) {
+			return mapSlot(v.FieldByName("Paths"), token)
+		}
+	case reflect.TypeFor[Responses]():
+		if token == "default" {
+			return fieldSlot(v.FieldByName("Default")), nil
+		}
+		if rng, ok := responseCodeRange(token); ok {
+			// an existing range is designated whatever the case of its key
+			responses, _ := v.Addr().Interface().(*Responses)
+			if key, _, exists := responses.codeRange(rng); exists {
+				return mapSlot(v.FieldByName("StatusCodeRanges"), key)
+			}
+
+			return mapSlot(v.FieldByName("StatusCodeRanges"), token)
+		}
+		if !isExtensionKey(token) {
+			return mapSlot(v.FieldByName("StatusCodeResponses"), token)
+		}
+	case reflect.TypeFor[SchemaOrBool]():
+		return schemaOrBoolSlot(v, token, create)
+	case reflect.TypeFor[SchemaOrArray]():
+		if _, err := strconv.Atoi(token); err == nil || token == "-" {
+			return sliceSlot(v.FieldByName("Schemas"), token)
+		}
+
+		return nestedSchemaSlot(v.FieldByName("Schema"), token, create)
+	case reflect.TypeFor[SchemaOrStringArray]():
+		if _, err := strconv.Atoi(token); err == nil || token == "-" {
+			return sliceSlot(v.FieldByName("Property"), token)
+		}
+
+		return nestedSchemaSlot(v.FieldByName("Schema"), token, create)
+	}
+
+	if isExtensionKey(token) {
+		if ext, ok := findField(v, func(f reflect.StructField) bool { return f.Type == typeOfExtensions }); ok {
+			return mapSlot(ext, token)
+		}
+	}
+
+	if token == jsonRef {
+		if ref, ok := findField(v, func(f reflect.StructField) bool { return f.Type == typeOfRef }); ok {
+			return fieldSlot(ref), nil
+		}
+	}
+
+	if field, ok := findField(v, func(f reflect.StructField) bool { return jsonFieldName(f) == token }); ok {
+		return fieldSlot(field), nil
+	}
+
+	if extra := v.FieldByName("ExtraProps"); extra.IsValid() && extra.Kind() == reflect.Map {
+		return mapSlot(extra, token)
+	}
+
+	return pointerSlot{}, fmt.Errorf("object has no field %q: %w", token, ErrSpec)
+}
+
+// schemaOrBoolSlot resolves a token in the schema of a [SchemaOrBool].
+func schemaOrBoolSlot(v reflect.Value, token string, create bool) (pointerSlot, error) {
+	sb, _ := v.Addr().Interface().(*SchemaOrBool)
+	if sb.Schema == nil && create {
+		sb.Allows = true
+	}
+
+	return nestedSchemaSlot(v.FieldByName("Schema"), token, create)
+}
+
+func nestedSchemaSlot(schema reflect.Value, token string, create bool) (pointerSlot, error) {
+	target, err := derefForWrite(schema, create)
+	if err != nil {
+		return pointerSlot{}, err
+	}
+
+	return structSlot(target, token, create)
+}
+
+func fieldSlot(field reflect.Value) pointerSlot {
+	return pointerSlot{
+		value:  field,
+		exists: !isZeroValue(field),
+		store:  func() {},
+		remove: func() { field.SetZero() },
+	}
+}
+
+// findField finds a field in a struct or in its embedded structs.
+func findField(v reflect.Value, match func(reflect.StructField) bool) (reflect.Value, bool) {
+	t := v.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if match(f) {
+			return v.Field(i), true
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if found, ok := findField(v.Field(i), match); ok {
+				return found, true
+			}
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+func jsonFieldName(f reflect.StructField) string {
+	tag, ok := f.Tag.Lookup("json")
+	if !ok {
+		return ""
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "-" {
+		return ""
+	}
+
+	return name
+}
+
+func isExtensionKey(token string) bool {
+	return strings.HasPrefix(strings.ToLower(token), "x-")
+}
+
+func isZeroValue(v reflect.Value) bool {
+	if v.Type() == typeOfRef {
+		ref, _ := v.Interface().(Ref)
+
+		return ref.String() == ""
+	}
+
+	return v.IsZero()
+}
+
+// assignJSONValue assigns a generic JSON value to a typed value of the object model.
+func assignJSONValue(dst reflect.Value, value any) error {
+	if dst.Type() == typeOfRef {
+		if ref, ok := value.(Ref); ok {
+			dst.Set(reflect.ValueOf(ref))
+
+			return nil
+		}
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("a $ref must be a string, got %T: %w", value, ErrSpec)
+		}
+		ref, err := NewRef(str)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(ref))
+
+		return nil
+	}
+
+	if dst.Kind() == reflect.Interface {
+		if value == nil {
+			dst.SetZero()
+
+			return nil
+		}
+		dst.Set(reflect.ValueOf(deepCopyAny(value)))
+
+		return nil
+	}
+
+	// typed values are assigned as is
+	if rv := reflect.ValueOf(value); rv.IsValid() {
+		if rv.Type().AssignableTo(dst.Type()) {
+			dst.Set(rv)
+
+			return nil
+		}
+		if rv.Kind() == reflect.Pointer && !rv.IsNil() && rv.Elem().Type().AssignableTo(dst.Type()) {
+			dst.Set(rv.Elem())
+
+			return nil
+		}
+	}
+
+	buf, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	target := reflect.New(dst.Type())
+	if err := json.Unmarshal(buf, target.Interface()); err != nil {
+		return fmt.Errorf("cannot assign value to %s: %w: %w", dst.Type(), err, ErrSpec)
+	}
+	dst.Set(target.Elem())
+
+	return nil
+}
+
+// toJSONValue returns the generic JSON form of a typed value.
+func toJSONValue(v reflect.Value) (any, error) {
+	if v.Type() == typeOfRef {
+		ref, _ := v.Interface().(Ref)
+
+		return ref.String(), nil
+	}
+
+	buf, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+	if err := json.Unmarshal(buf, &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
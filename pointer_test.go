@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestJSONSet(t *testing.T) {
+	t.Run("extensions should be created", func(t *testing.T) {
+		op := NewOperation("getPets")
+		require.NoError(t, op.JSONSet("x-internal", true))
+		assert.Equal(t, true, op.Extensions["x-internal"])
+
+		tag := NewTag("pets", "", nil)
+		require.NoError(t, tag.JSONSet("x-order", 1))
+		assert.Equal(t, 1, tag.Extensions["x-order"])
+	})
+
+	t.Run("fields should be set by their json name", func(t *testing.T) {
+		var info Info
+		require.NoError(t, info.JSONSet("title", "pets"))
+		assert.EqualT(t, "pets", info.Title)
+
+		var param Parameter
+		require.NoError(t, param.JSONSet("in", "query"))
+		assert.EqualT(t, "query", param.In)
+
+		var sch Schema
+		require.NoError(t, sch.JSONSet("$ref", "#/definitions/Pet"))
+		assert.EqualT(t, "#/definitions/Pet", sch.Ref.String())
+	})
+
+	t.Run("responses should be set by status code", func(t *testing.T) {
+		var responses Responses
+		require.NoError(t, responses.JSONSet("200", NewResponse().WithDescription("ok")))
+		require.NoError(t, responses.JSONSet("default", map[string]any{"description": "error"}))
+		assert.EqualT(t, "ok", responses.StatusCodeResponses[200].Description)
+		require.NotNil(t, responses.Default)
+		assert.EqualT(t, "error", responses.Default.Description)
+	})
+
+	t.Run("jsonpointer should write through JSONSet", func(t *testing.T) {
+		sp := mustSpec(t, `{"swagger":"2.0","paths":{"/pets":{"get":{"responses":{"200":{"description":"ok"}}}}}}`)
+
+		ptr, err := jsonpointer.New("/info")
+		require.NoError(t, err)
+		_, err = ptr.Set(sp, &Info{InfoProps: InfoProps{Title: "pets"}})
+		require.NoError(t, err)
+		require.NotNil(t, sp.Info)
+		assert.EqualT(t, "pets", sp.Info.Title)
+
+		ptr, err = jsonpointer.New("/x-generator")
+		require.NoError(t, err)
+		_, err = ptr.Set(sp, "spec")
+		require.NoError(t, err)
+		assert.Equal(t, "spec", sp.Extensions["x-generator"])
+	})
+
+	t.Run("jsonpointer should set map entries and write through pointers", func(t *testing.T) {
+		sp := mustSpec(t, `{
+  "swagger": "2.0",
+  "paths": {
+    "/pets": {
+      "get": {
+        "responses": {
+          "200": { "description": "ok", "schema": { "type": "object", "properties": { "name": { "type": "string" } } } }
+        }
+      }
+    }
+  },
+  "definitions": { "Pet": { "type": "object" } }
+}`)
+
+		for _, tc := range []struct {
+			ptr   string
+			value any
+		}{
+			{"/paths/~1pets/get/responses/200/schema/properties/name", map[string]any{"type": "string", "format": "uuid"}},
+			{"/paths/~1pets/get/summary", "list pets"},
+			{"/definitions/Owner", new(Schema).Typed("object", "")},
+		} {
+			ptr, err := jsonpointer.New(tc.ptr)
+			require.NoError(t, err)
+			_, err = ptr.Set(sp, tc.value)
+			require.NoError(t, err, tc.ptr)
+		}
+
+		op := sp.Paths.Paths["/pets"].Get
+		assert.EqualT(t, "uuid", op.Responses.StatusCodeResponses[200].Schema.Properties["name"].Format)
+		assert.EqualT(t, "list pets", op.Summary)
+		assert.EqualT(t, "object", sp.Definitions["Owner"].Type[0])
+	})
+
+	t.Run("jsonpointer lookups should return map entries as values", func(t *testing.T) {
+		sp := mustSpec(t, `{"swagger":"2.0","paths":{"/pets":{"get":{"responses":{"200":{"description":"ok"}}}}},"definitions":{"Pet":{"type":"object"}}}`)
+
+		response, _, err := jsonpointer.GetForToken(sp.Paths.Paths["/pets"].Get.Responses, "200")
+		require.NoError(t, err)
+		assert.IsType(t, Response{}, response)
+
+		ptr, err := jsonpointer.New("/definitions/Pet")
+		require.NoError(t, err)
+		definition, _, err := ptr.Get(sp)
+		require.NoError(t, err)
+		assert.IsType(t, Schema{}, definition)
+	})
+
+	t.Run("invalid values should be rejected", func(t *testing.T) {
+		var sch Schema
+		require.ErrorIs(t, sch.JSONSet("minimum", "small"), ErrSpec)
+	})
+}
+
+func TestSetByPointer(t *testing.T) {
+	t.Run("intermediate containers should be created", func(t *testing.T) {
+		var sp Swagger
+		require.NoError(t, SetByPointer(&sp, "/paths/~1pets/get/responses/200/schema/properties/name", StringProperty()))
+
+		require.NotNil(t, sp.Paths)
+		get := sp.Paths.Paths["/pets"].Get
+		require.NotNil(t, get)
+		require.NotNil(t, get.Responses)
+		schema := get.Responses.StatusCodeResponses[200].Schema
+		require.NotNil(t, schema)
+		assert.Equal(t, *StringProperty(), schema.Properties["name"])
+	})
+
+	t.Run("existing values should be replaced", func(t *testing.T) {
+		sp := mustSpec(t, `{
+  "swagger": "2.0",
+  "paths": { "/pets": { "get": { "parameters": [ { "name": "limit", "in": "query", "type": "integer" } ] } } },
+  "definitions": { "Pet": { "type": "object", "properties": { "name": { "type": "string" } } } }
+}`)
+
+		require.NoError(t, SetByPointer(sp, "/definitions/Pet/properties/name/maxLength", 64))
+		require.NoError(t, SetByPointer(sp, "/definitions/Pet/x-nullable", true))
+		require.NoError(t, SetByPointer(sp, "/paths/~1pets/get/parameters/0/name", "max"))
+		require.NoError(t, SetByPointer(sp, "/paths/~1pets/get/parameters/-", QueryParam("offset")))
+
+		pet := sp.Definitions["Pet"]
+		name := pet.Properties["name"]
+		require.NotNil(t, name.MaxLength)
+		assert.EqualT(t, int64(64), *name.MaxLength)
+		assert.Equal(t, true, pet.Extensions["x-nullable"])
+
+		params := sp.Paths.Paths["/pets"].Get.Parameters
+		require.Len(t, params, 2)
+		assert.EqualT(t, "max", params[0].Name)
+		assert.EqualT(t, "offset", params[1].Name)
+	})
+
+	t.Run("map entries should be written back, several levels deep", func(t *testing.T) {
+		sp := mustSpec(t, `{
+  "swagger": "2.0",
+  "paths": {
+    "/pets": {
+      "get": {
+        "responses": {
+          "200": { "description": "ok", "schema": { "type": "object", "properties": { "name": { "type": "string" } } } }
+        }
+      }
+    }
+  },
+  "definitions": { "Pet": { "type": "object", "properties": { "id": { "type": "integer" } } } }
+}`)
+
+		require.NoError(t, SetByPointer(sp, "/paths/~1pets/get/responses/200/schema/properties/name/maxLength", 36))
+		require.NoError(t, SetByPointer(sp, "/paths/~1pets/get/responses/200/description", "pets"))
+		require.NoError(t, SetByPointer(sp, "/paths/~1pets/x-internal", true))
+		require.NoError(t, SetByPointer(sp, "/definitions/Pet/properties/id/format", "int64"))
+		require.NoError(t, SetByPointer(sp, "/definitions/Pet/title", "a pet"))
+
+		responses := sp.Paths.Paths["/pets"].Get.Responses
+		name := responses.StatusCodeResponses[200].Schema.Properties["name"]
+		require.NotNil(t, name.MaxLength)
+		assert.EqualT(t, int64(36), *name.MaxLength)
+		assert.EqualT(t, "pets", responses.StatusCodeResponses[200].Description)
+		assert.Equal(t, true, sp.Paths.Paths["/pets"].Extensions["x-internal"])
+		assert.EqualT(t, "int64", sp.Definitions["Pet"].Properties["id"].Format)
+		assert.EqualT(t, "a pet", sp.Definitions["Pet"].Title)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var sp Swagger
+		require.ErrorIs(t, SetByPointer(sp, "/info", &Info{}), ErrSpec)
+		require.ErrorIs(t, SetByPointer(&sp, "info", &Info{}), ErrSpec)
+		require.ErrorIs(t, SetByPointer(&sp, "/tags/3/name", "pets"), ErrSpec)
+	})
+}
@@ -94,6 +94,11 @@ func (items OrderSchemaItems) marshalJSONItem(item OrderSchemaItem, output *byte
 // It knows how to transform its keys into an ordered slice.
 type SchemaProperties map[string]Schema
 
+// JSONSet sets a property by name.
+func (properties SchemaProperties) JSONSet(token string, value any) error {
+	return setMapEntry(properties, token, value)
+}
+
 // ToOrderedSchemaItems transforms the map of properties into a sortable slice.
 func (properties SchemaProperties) ToOrderedSchemaItems() OrderSchemaItems {
 	items := make(OrderSchemaItems, 0, len(properties))
@@ -128,3 +128,59 @@ func ResolveItemsWithBase(root any, ref Ref, options *ExpandOptions) (*Items, er
 func ResolveItems(root any, ref Ref, options *ExpandOptions) (*Items, error) {
 	return ResolveItemsWithBase(root, ref, options)
 }
+
+// refResolver resolves $ref's found in a document, while keeping track of the
+// document which holds the resolved target.
+//
+// This is useful to follow $ref's nested in remote documents, which must be
+// resolved relative to the remote document and not to the root document.
+type refResolver struct {
+	loader   *schemaLoader
+	rootBase string
+}
+
+func newRefResolver(root any, options *ExpandOptions) *refResolver {
+	loader := defaultSchemaLoader(root, optionsOrDefault(options), nil, nil)
+
+	return &refResolver{
+		loader:   loader,
+		rootBase: loader.options.RelativeBase,
+	}
+}
+
+// resolve resolves a $ref found in the document located at base and stores the result in target.
+//
+// An empty base stands for the root document.
+//
+// It returns the location of the document in which the target has been found, to be
+// used as the base to resolve $ref's nested in the target.
+func (r *refResolver) resolve(ref Ref, base string, target any) (string, error) {
+	if base == "" {
+		base = r.rootBase
+	}
+
+	normalized := normalizeRef(&ref, base)
+	targetBase := normalized.RemoteURI()
+
+	toResolve := *normalized
+	if targetBase == r.rootBase {
+		// the target lives in the root document, which may have been altered in memory
+		toResolve = MustCreateRef("#" + normalized.GetPointer().String())
+	}
+
+	if err := r.loader.Resolve(&toResolve, target, base); err != nil {
+		return "", err
+	}
+
+	return targetBase, nil
+}
+
+// key yields a unique identifier for a $ref found in the document located at base,
+// e.g. to detect cycles.
+func (r *refResolver) key(ref Ref, base string) string {
+	if base == "" {
+		base = r.rootBase
+	}
+
+	return normalizeRef(&ref, base).String()
+}
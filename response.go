@@ -51,6 +51,13 @@ func (r Response) JSONLookup(token string) (any, error) {
 	return ptr, err
 }
 
+// JSONSet sets a value by the json property name.
+//
+// Missing containers, such as extensions, are created.
+func (r *Response) JSONSet(token string, value any) error {
+	return jsonSetToken(r, token, value)
+}
+
 // UnmarshalJSON hydrates this items instance with the data from JSON.
 func (r *Response) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &r.ResponseProps); err != nil {
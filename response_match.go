@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// ResponseRule tells which rule selected the response documented for an HTTP status code.
+type ResponseRule uint8
+
+// Rules selecting a response, by order of precedence.
+const (
+	// ResponseRuleNone means that no response is documented for the status code
+	ResponseRuleNone ResponseRule = iota
+
+	// ResponseRuleCode selects the response documented for the exact status code
+	ResponseRuleCode
+
+	// ResponseRuleRange selects the response documented for the range of the status code, e.g. "4XX"
+	ResponseRuleRange
+
+	// ResponseRuleDefault selects the default response
+	ResponseRuleDefault
+)
+
+// String returns the name of the rule.
+func (r ResponseRule) String() string {
+	switch r {
+	case ResponseRuleCode:
+		return "code"
+	case ResponseRuleRange:
+		return "range"
+	case ResponseRuleDefault:
+		return "default"
+	default:
+		return "none"
+	}
+}
+
+// ResponseMatch is the response documented for an HTTP status code.
+type ResponseMatch struct {
+	// Response is the documented response, which may be a $ref. It is nil when no response applies
+	Response *Response
+
+	// Rule tells which rule selected the response
+	Rule ResponseRule
+
+	// Key is the key of the response in its responses object: a status code, a range such as "4XX" or "default"
+	Key string
+}
+
+// Resolve returns the matched response, following its $ref's.
+//
+// The root is the document holding the responses, e.g. a *Swagger.
+// It returns nil when no response applies.
+func (m ResponseMatch) Resolve(root any, options *ExpandOptions) (*Response, error) {
+	response := m.Response
+	if response == nil || response.Ref.String() == "" {
+		return response, nil
+	}
+
+	resolver := newRefResolver(root, options)
+	seen := make(map[string]struct{})
+	base := ""
+	for response.Ref.String() != "" {
+		key := resolver.key(response.Ref, base)
+		if _, isCircular := seen[key]; isCircular {
+			return nil, fmt.Errorf("circular $ref for response %q: %s: %w", m.Key, key, ErrSpec)
+		}
+		seen[key] = struct{}{}
+
+		target := new(Response)
+		targetBase, err := resolver.resolve(response.Ref, base, target)
+		if err != nil {
+			return nil, err
+		}
+		response, base = target, targetBase
+	}
+
+	return response, nil
+}
+
+// MatchResponse returns the response documented for an HTTP status code, with the rule selecting it.
+//
+// The response for the exact status code is preferred, then the response for the range of the
+// status code (e.g. "4XX"), then the default response.
+func (r *Responses) MatchResponse(status int) ResponseMatch {
+	if r == nil {
+		return ResponseMatch{}
+	}
+
+	if resp, ok := r.StatusCodeResponses[status]; ok {
+		return ResponseMatch{Response: &resp, Rule: ResponseRuleCode, Key: strconv.Itoa(status)}
+	}
+
+	if status >= 100 && status < 600 {
+		if key, resp, ok := r.codeRange(strconv.Itoa(status/100) + "XX"); ok {
+			return ResponseMatch{Response: &resp, Rule: ResponseRuleRange, Key: key}
+		}
+	}
+
+	if r.Default != nil {
+		return ResponseMatch{Response: r.Default, Rule: ResponseRuleDefault, Key: "default"}
+	}
+
+	return ResponseMatch{}
+}
+
+// MatchResponse returns the response documented by this operation for an HTTP status code.
+//
+// See [Responses.MatchResponse].
+func (o *Operation) MatchResponse(status int) ResponseMatch {
+	if o == nil {
+		return ResponseMatch{}
+	}
+
+	return o.Responses.MatchResponse(status)
+}
+
+// ErrorResponses lists the error responses documented by this operation.
+//
+// Responses for 4xx and 5xx status codes come first, by ascending status code, then the "4XX" and "5XX"
+// ranges. The default response, which conventionally describes errors, comes last.
+func (o *Operation) ErrorResponses() []ResponseMatch {
+	if o == nil || o.Responses == nil {
+		return nil
+	}
+
+	responses := o.Responses
+	codes := make([]int, 0, len(responses.StatusCodeResponses))
+	for code := range responses.StatusCodeResponses {
+		if code >= http.StatusBadRequest {
+			codes = append(codes, code)
+		}
+	}
+	sort.Ints(codes)
+
+	var matches []ResponseMatch
+	for _, code := range codes {
+		resp := responses.StatusCodeResponses[code]
+		matches = append(matches, ResponseMatch{Response: &resp, Rule: ResponseRuleCode, Key: strconv.Itoa(code)})
+	}
+
+	for _, rng := range []string{"4XX", "5XX"} {
+		if key, resp, ok := responses.codeRange(rng); ok {
+			matches = append(matches, ResponseMatch{Response: &resp, Rule: ResponseRuleRange, Key: key})
+		}
+	}
+
+	if responses.Default != nil {
+		matches = append(matches, ResponseMatch{Response: responses.Default, Rule: ResponseRuleDefault, Key: "default"})
+	}
+
+	return matches
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestResponses_MatchResponse(t *testing.T) {
+	r := &Responses{ResponsesProps: ResponsesProps{
+		Default:             NewResponse().WithDescription("error"),
+		StatusCodeResponses: map[int]Response{404: *NewResponse().WithDescription("not found")},
+		StatusCodeRanges:    map[string]Response{"4XX": *NewResponse().WithDescription("client error")},
+	}}
+
+	for _, tc := range []struct {
+		status      int
+		description string
+		rule        ResponseRule
+		key         string
+	}{
+		{404, "not found", ResponseRuleCode, "404"},
+		{400, "client error", ResponseRuleRange, "4XX"},
+		{500, "error", ResponseRuleDefault, "default"},
+		{999, "error", ResponseRuleDefault, "default"},
+	} {
+		t.Run(tc.rule.String(), func(t *testing.T) {
+			match := r.MatchResponse(tc.status)
+			require.NotNil(t, match.Response)
+			assert.EqualT(t, tc.description, match.Response.Description)
+			assert.EqualT(t, tc.rule, match.Rule)
+			assert.EqualT(t, tc.key, match.Key)
+		})
+	}
+
+	t.Run("no response should match without a default", func(t *testing.T) {
+		r := &Responses{ResponsesProps: ResponsesProps{
+			StatusCodeResponses: map[int]Response{200: *NewResponse()},
+		}}
+		assert.Equal(t, ResponseMatch{}, r.MatchResponse(201))
+		assert.Equal(t, ResponseMatch{}, (*Responses)(nil).MatchResponse(200))
+		assert.Equal(t, ResponseMatch{}, NewOperation("empty").MatchResponse(200))
+	})
+}
+
+const responseRefsSpec = `{
+  "swagger": "2.0",
+  "info": { "title": "pets", "version": "1.0" },
+  "paths": {
+    "/pets": {
+      "get": {
+        "responses": {
+          "200": { "description": "ok" },
+          "404": { "$ref": "#/responses/NotFound" },
+          "400": { "$ref": "#/responses/Loop" },
+          "500": { "description": "server error" },
+          "default": { "$ref": "#/responses/Error" }
+        }
+      }
+    }
+  },
+  "responses": {
+    "NotFound": { "$ref": "#/responses/Error" },
+    "Error": { "description": "error", "schema": { "type": "string" } },
+    "Loop": { "$ref": "#/responses/Loop" }
+  }
+}`
+
+func TestResponseMatch_Resolve(t *testing.T) {
+	sp := mustSpec(t, responseRefsSpec)
+	op := sp.Paths.Paths["/pets"].Get
+
+	t.Run("chained $ref's should be followed", func(t *testing.T) {
+		resp, err := op.MatchResponse(404).Resolve(sp, nil)
+		require.NoError(t, err)
+		assert.EqualT(t, "error", resp.Description)
+		require.NotNil(t, resp.Schema)
+	})
+
+	t.Run("responses without a $ref should be returned as is", func(t *testing.T) {
+		resp, err := op.MatchResponse(200).Resolve(sp, nil)
+		require.NoError(t, err)
+		assert.EqualT(t, "ok", resp.Description)
+
+		resp, err = ResponseMatch{}.Resolve(sp, nil)
+		require.NoError(t, err)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("circular $ref's should be reported", func(t *testing.T) {
+		_, err := op.MatchResponse(400).Resolve(sp, nil)
+		require.ErrorIs(t, err, ErrSpec)
+	})
+}
+
+func TestOperation_ErrorResponses(t *testing.T) {
+	op := mustSpec(t, responseRefsSpec).Paths.Paths["/pets"].Get
+	op.Responses.StatusCodeRanges = map[string]Response{
+		"2XX": *NewResponse().WithDescription("success"),
+		"5XX": *NewResponse().WithDescription("server error"),
+	}
+
+	matches := op.ErrorResponses()
+	keys := make([]string, 0, len(matches))
+	for _, m := range matches {
+		keys = append(keys, m.Rule.String()+" "+m.Key)
+	}
+	assert.Equal(t, []string{"code 400", "code 404", "code 500", "range 5XX", "default default"}, keys)
+
+	assert.Nil(t, NewOperation("empty").ErrorResponses())
+}
@@ -4,9 +4,12 @@
 package spec
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -44,12 +47,82 @@ func (r Responses) JSONLookup(token string) (any, error) {
 			return scr, nil
 		}
 	}
+	if rng, ok := responseCodeRange(token); ok {
+		if _, scr, ok := r.codeRange(rng); ok {
+			return scr, nil
+		}
+	}
 	return nil, fmt.Errorf("object has no field %q: %w", token, ErrSpec)
 }
 
+// JSONSet sets a value by the json property name.
+//
+// Missing containers, such as extensions, are created.
+func (r *Responses) JSONSet(token string, value any) error {
+	return jsonSetToken(r, token, value)
+}
+
+// ResponseFor returns the response documented for an HTTP status code.
+//
+// The response for the exact status code is preferred, then the response for its range (e.g. "4XX"),
+// then the default response. The boolean is false when only the default response applies.
+//
+// See [Responses.MatchResponse] to know which rule selected the response.
+func (r *Responses) ResponseFor(status int) (*Response, bool) {
+	match := r.MatchResponse(status)
+
+	return match.Response, match.Rule == ResponseRuleCode || match.Rule == ResponseRuleRange
+}
+
+// All iterates over the responses, by key: status codes and response code ranges in status order,
+// each range after the status codes it covers, then "default". Nil responses yield nothing.
+func (r *Responses) All() iter.Seq2[string, Response] {
+	return func(yield func(string, Response) bool) {
+		if r == nil {
+			return
+		}
+
+		type entry struct {
+			status   int
+			key      string
+			response Response
+		}
+
+		entries := make([]entry, 0, len(r.StatusCodeResponses)+len(r.StatusCodeRanges))
+		for code, response := range r.StatusCodeResponses {
+			entries = append(entries, entry{status: code, key: strconv.Itoa(code), response: response})
+		}
+		for rng, response := range r.StatusCodeRanges {
+			// a range comes after the last status code it covers
+			entries = append(entries, entry{status: int(rng[0]-'0')*100 + 99, key: rng, response: response})
+		}
+		slices.SortFunc(entries, func(a, b entry) int {
+			return cmp.Or(cmp.Compare(a.status, b.status), strings.Compare(a.key, b.key))
+		})
+
+		for _, e := range entries {
+			if !yield(e.key, e.response) {
+				return
+			}
+		}
+
+		if r.Default != nil {
+			yield("default", *r.Default)
+		}
+	}
+}
+
 // UnmarshalJSON hydrates this items instance with the data from JSON.
+//
+// Response code ranges and unknown keys are dropped: see [Responses.UnmarshalJSONWithOptions].
 func (r *Responses) UnmarshalJSON(data []byte) error {
-	if err := json.Unmarshal(data, &r.ResponsesProps); err != nil {
+	return r.UnmarshalJSONWithOptions(data, nil)
+}
+
+// UnmarshalJSONWithOptions hydrates this items instance with the data from JSON,
+// with options telling how to handle response code ranges and unknown keys.
+func (r *Responses) UnmarshalJSONWithOptions(data []byte, options *UnmarshalOptions) error {
+	if err := r.ResponsesProps.unmarshalJSON(data, options); err != nil {
 		return err
 	}
 
@@ -79,9 +152,14 @@ func (r Responses) MarshalJSON() ([]byte, error) {
 // ResponsesProps describes all responses for an operation.
 // It tells what is the default response and maps all responses with a
 // HTTP status code.
+//
+// StatusCodeRanges maps response code ranges, such as "2XX", to a response.
+// Keys are kept as written, e.g. "2xx". It is only filled when unmarshaling with
+// [UnmarshalOptions.ResponseCodeRanges] enabled.
 type ResponsesProps struct {
 	Default             *Response
 	StatusCodeResponses map[int]Response
+	StatusCodeRanges    map[string]Response
 }
 
 // MarshalJSON marshals responses as JSON.
@@ -93,11 +171,24 @@ func (r ResponsesProps) MarshalJSON() ([]byte, error) {
 	for k, v := range r.StatusCodeResponses {
 		toser[strconv.Itoa(k)] = v
 	}
+	for k, v := range r.StatusCodeRanges {
+		toser[k] = v
+	}
 	return json.Marshal(toser)
 }
 
 // UnmarshalJSON unmarshals responses from JSON.
+//
+// Response code ranges and unknown keys are dropped.
 func (r *ResponsesProps) UnmarshalJSON(data []byte) error {
+	return r.unmarshalJSON(data, nil)
+}
+
+func (r *ResponsesProps) unmarshalJSON(data []byte, options *UnmarshalOptions) error {
+	if options == nil {
+		options = &UnmarshalOptions{}
+	}
+
 	var res map[string]json.RawMessage
 	if err := json.Unmarshal(data, &res); err != nil {
 		return err
@@ -112,18 +203,65 @@ func (r *ResponsesProps) UnmarshalJSON(data []byte) error {
 		delete(res, "default")
 	}
 	for k, v := range res {
-		if !strings.HasPrefix(k, "x-") {
-			var statusCodeResp Response
-			if err := json.Unmarshal(v, &statusCodeResp); err != nil {
-				return err
+		if strings.HasPrefix(k, "x-") {
+			continue
+		}
+
+		var statusCodeResp Response
+		if err := json.Unmarshal(v, &statusCodeResp); err != nil {
+			return err
+		}
+
+		nk, err := strconv.Atoi(k)
+		rng, isRange := responseCodeRange(k)
+		isRange = isRange && options.ResponseCodeRanges
+		if err != nil && !isRange {
+			if options.StrictResponses {
+				return fmt.Errorf("invalid response key %q: %w", k, ErrSpec)
+			}
+			continue
+		}
+		if isRange {
+			if other, _, exists := r.codeRange(rng); exists && other != k && options.StrictResponses {
+				return fmt.Errorf("response keys %q and %q designate the same range: %w", other, k, ErrSpec)
 			}
-			if nk, err := strconv.Atoi(k); err == nil {
-				if r.StatusCodeResponses == nil {
-					r.StatusCodeResponses = map[int]Response{}
-				}
-				r.StatusCodeResponses[nk] = statusCodeResp
+			if r.StatusCodeRanges == nil {
+				r.StatusCodeRanges = map[string]Response{}
 			}
+			r.StatusCodeRanges[k] = statusCodeResp
+			continue
 		}
+		if r.StatusCodeResponses == nil {
+			r.StatusCodeResponses = map[int]Response{}
+		}
+		r.StatusCodeResponses[nk] = statusCodeResp
 	}
 	return nil
 }
+
+// codeRange returns the key and the response of a response code range, such as "4XX", whatever the case of its key.
+//
+// When several keys designate the range, e.g. "4XX" and "4xx", the first one in alphabetical order is returned.
+func (r ResponsesProps) codeRange(rng string) (string, Response, bool) {
+	if resp, ok := r.StatusCodeRanges[rng]; ok {
+		return rng, resp, true
+	}
+
+	for _, key := range mapKeysSorted(r.StatusCodeRanges) {
+		if canonical, ok := responseCodeRange(key); ok && canonical == rng {
+			return key, r.StatusCodeRanges[key], true
+		}
+	}
+
+	return "", Response{}, false
+}
+
+// responseCodeRange tells if a key is a response code range, from "1XX" to "5XX",
+// and returns it in its canonical upper case form.
+func responseCodeRange(key string) (string, bool) {
+	if len(key) != 3 || key[0] < '1' || key[0] > '5' || strings.ToUpper(key[1:]) != "XX" {
+		return "", false
+	}
+
+	return key[:1] + "XX", true
+}
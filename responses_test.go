@@ -18,6 +18,7 @@
 package spec
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/go-openapi/testify/v2/assert"
@@ -123,3 +124,129 @@ func TestResponsesBuild(t *testing.T) {
          }
 			 }`, resp)
 }
+
+const rangedResponsesJSON = `{
+	"200": { "description": "ok" },
+	"2XX": { "description": "success" },
+	"4xx": { "description": "client error" },
+	"default": { "description": "error" },
+	"x-go-name": "Ranged"
+}`
+
+func TestResponses_CodeRanges(t *testing.T) {
+	t.Run("ranges should be dropped by default", func(t *testing.T) {
+		var r Responses
+		require.NoError(t, json.Unmarshal([]byte(rangedResponsesJSON), &r))
+		assert.Len(t, r.StatusCodeResponses, 1)
+		assert.Empty(t, r.StatusCodeRanges)
+	})
+
+	t.Run("ranges should round-trip when enabled", func(t *testing.T) {
+		var r Responses
+		require.NoError(t, r.UnmarshalJSONWithOptions([]byte(rangedResponsesJSON), &UnmarshalOptions{ResponseCodeRanges: true}))
+		require.Len(t, r.StatusCodeRanges, 2)
+		assert.EqualT(t, "client error", r.StatusCodeRanges["4xx"].Description)
+		assert.JSONMarshalAsT(t, rangedResponsesJSON, r)
+
+		res, err := r.JSONLookup("4XX")
+		require.NoError(t, err)
+		assert.Equal(t, r.StatusCodeRanges["4xx"], res)
+		require.NoError(t, SetByPointer(&r, "/4XX/description", "bad request"))
+		assert.EqualT(t, "bad request", r.StatusCodeRanges["4xx"].Description)
+		require.Len(t, r.StatusCodeRanges, 2)
+		require.NoError(t, SetByPointer(&r, "/5XX/description", "server error"))
+		assert.EqualT(t, "server error", r.StatusCodeRanges["5XX"].Description)
+	})
+
+	t.Run("unknown keys should fail in strict mode", func(t *testing.T) {
+		var r Responses
+		require.ErrorIs(t, r.UnmarshalJSONWithOptions([]byte(rangedResponsesJSON), &UnmarshalOptions{StrictResponses: true}), ErrSpec)
+
+		options := &UnmarshalOptions{ResponseCodeRanges: true, StrictResponses: true}
+		require.NoError(t, r.UnmarshalJSONWithOptions([]byte(rangedResponsesJSON), options))
+		require.ErrorIs(t, r.UnmarshalJSONWithOptions([]byte(`{"6XX":{"description":"nope"}}`), options), ErrSpec)
+		require.ErrorIs(t, r.UnmarshalJSONWithOptions([]byte(`{"ok":{"description":"nope"}}`), options), ErrSpec)
+	})
+
+	t.Run("keys designating the same range should be kept apart", func(t *testing.T) {
+		const colliding = `{"2xx":{"description":"lower"},"2XX":{"description":"upper"}}`
+
+		var r Responses
+		require.NoError(t, r.UnmarshalJSONWithOptions([]byte(colliding), &UnmarshalOptions{ResponseCodeRanges: true}))
+		assert.Len(t, r.StatusCodeRanges, 2)
+		assert.JSONMarshalAsT(t, colliding, r)
+
+		resp, matched := r.ResponseFor(204)
+		assert.TrueT(t, matched)
+		assert.EqualT(t, "upper", resp.Description)
+
+		var strict Responses
+		options := &UnmarshalOptions{ResponseCodeRanges: true, StrictResponses: true}
+		require.ErrorIs(t, strict.UnmarshalJSONWithOptions([]byte(colliding), options), ErrSpec)
+	})
+}
+
+func TestResponses_ResponseFor(t *testing.T) {
+	r := &Responses{ResponsesProps: ResponsesProps{
+		Default:             NewResponse().WithDescription("error"),
+		StatusCodeResponses: map[int]Response{404: *NewResponse().WithDescription("not found")},
+		StatusCodeRanges:    map[string]Response{"4XX": *NewResponse().WithDescription("client error")},
+	}}
+
+	for _, tc := range []struct {
+		status      int
+		description string
+		matched     bool
+	}{
+		{404, "not found", true},
+		{400, "client error", true},
+		{500, "error", false},
+		{999, "error", false},
+	} {
+		resp, matched := r.ResponseFor(tc.status)
+		require.NotNil(t, resp)
+		assert.EqualT(t, tc.description, resp.Description)
+		assert.EqualT(t, tc.matched, matched)
+	}
+
+	resp, matched := (*Responses)(nil).ResponseFor(200)
+	assert.Nil(t, resp)
+	assert.FalseT(t, matched)
+}
+
+func TestResponses_All(t *testing.T) {
+	r := &Responses{ResponsesProps: ResponsesProps{
+		Default: NewResponse().WithDescription("error"),
+		StatusCodeResponses: map[int]Response{
+			404: *NewResponse().WithDescription("not found"),
+			200: *NewResponse().WithDescription("ok"),
+			201: *NewResponse().WithDescription("created"),
+		},
+		StatusCodeRanges: map[string]Response{
+			"4XX": *NewResponse().WithDescription("client error"),
+			"2XX": *NewResponse().WithDescription("success"),
+		},
+	}}
+
+	var keys []string
+	for key, response := range r.All() {
+		keys = append(keys, key+":"+response.Description)
+	}
+	assert.Equal(t, []string{
+		"200:ok", "201:created", "2XX:success", "404:not found", "4XX:client error", "default:error",
+	}, keys)
+
+	keys = keys[:0]
+	for key := range r.All() {
+		keys = append(keys, key)
+		if len(keys) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"200", "201"}, keys)
+
+	var op Operation
+	for key := range op.Responses.All() {
+		t.Errorf("unexpected response %q for nil responses", key)
+	}
+}
@@ -0,0 +1,258 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Router matches HTTP requests to the operations of a spec.
+//
+// Path templates are compiled into a tree of path segments. When several templates match a path,
+// static segments take precedence over segments mixing text and parameters, such as "{name}.{ext}",
+// which take precedence over segments made of a single parameter.
+type Router struct {
+	basePath string
+	root     *routeNode
+}
+
+// RouteMatch is the outcome of matching a request with a [Router].
+type RouteMatch struct {
+	// Status is http.StatusOK when an operation matched, http.StatusMethodNotAllowed when the path
+	// matched but not the method, and http.StatusNotFound when no path matched
+	Status int
+
+	// Path is the path template which matched, as a key of the paths of the spec
+	Path string
+
+	// PathItem is the path item which matched, once its $ref is resolved
+	PathItem *PathItem
+
+	// Operation is the operation which matched, if any
+	Operation *Operation
+
+	// Params are the values of the path parameters, by name. Values are unescaped
+	Params map[string]string
+
+	// Allowed lists the methods supported by the path item, in upper case, when the method is not allowed
+	Allowed []string
+}
+
+// routeNode is a node of the tree of path segments.
+type routeNode struct {
+	static map[string]*routeNode
+	mixed  []*mixedRouteNode
+	param  *routeNode
+	route  *route
+}
+
+// mixedRouteNode matches a segment mixing text and parameters, such as "{name}.{ext}".
+type mixedRouteNode struct {
+	*routeNode
+
+	segment templateSegment
+}
+
+// route is a path template attached to the node matching its last segment.
+type route struct {
+	template *PathTemplate
+	pathItem *PathItem
+}
+
+// NewRouter compiles the paths of a spec into a router.
+//
+// It fails when a path template is not valid, or when two path templates are equivalent,
+// e.g. "/pets/{id}" and "/pets/{petId}".
+//
+// Path items defined by a $ref are resolved against the spec. It fails when they cannot be resolved.
+func NewRouter(sp *Swagger) (*Router, error) {
+	if sp == nil {
+		return nil, fmt.Errorf("cannot route a nil spec: %w", ErrSpec)
+	}
+
+	r := &Router{
+		basePath: strings.TrimSuffix(sp.BasePath, "/"),
+		root:     &routeNode{},
+	}
+	if sp.Paths == nil {
+		return r, nil
+	}
+
+	resolver := newRefResolver(sp, nil)
+	for _, path := range mapKeysSorted(sp.Paths.Paths) {
+		template, err := ParsePathTemplate(path)
+		if err != nil {
+			return nil, err
+		}
+
+		item, err := resolveRoutedPathItem(resolver, path, sp.Paths.Paths[path])
+		if err != nil {
+			return nil, err
+		}
+		if err := r.add(template, &item); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// resolveRoutedPathItem follows the $ref's of a path item.
+func resolveRoutedPathItem(resolver *refResolver, path string, item PathItem) (PathItem, error) {
+	source := joinPointer("/paths", path)
+	current, base := item, ""
+	for seen := map[string]bool{}; current.Ref.String() != ""; {
+		key := resolver.key(current.Ref, base)
+		if seen[key] {
+			return PathItem{}, fmt.Errorf("circular $ref for path item %s: %s: %w", source, key, ErrSpec)
+		}
+		seen[key] = true
+
+		var target PathItem
+		targetBase, err := resolver.resolve(current.Ref, base, &target)
+		if err != nil {
+			return PathItem{}, fmt.Errorf("cannot resolve path item %s: %w", source, err)
+		}
+		current, base = target, targetBase
+	}
+
+	return current, nil
+}
+
+func (r *Router) add(template *PathTemplate, item *PathItem) error {
+	node := r.root
+	for _, segment := range template.segments {
+		switch {
+		case segment.isStatic():
+			node = node.staticChild(segment.literals[0])
+		case segment.isParam():
+			if node.param == nil {
+				node.param = &routeNode{}
+			}
+			node = node.param
+		default:
+			node = node.mixedChild(segment)
+		}
+	}
+
+	if node.route != nil {
+		return fmt.Errorf("path %q is equivalent to %q: %w", template, node.route.template, ErrSpec)
+	}
+	node.route = &route{template: template, pathItem: item}
+
+	return nil
+}
+
+func (n *routeNode) staticChild(segment string) *routeNode {
+	if n.static == nil {
+		n.static = make(map[string]*routeNode)
+	}
+
+	child, ok := n.static[segment]
+	if !ok {
+		child = &routeNode{}
+		n.static[segment] = child
+	}
+
+	return child
+}
+
+func (n *routeNode) mixedChild(segment templateSegment) *routeNode {
+	shape := segment.shape()
+	for _, child := range n.mixed {
+		if child.segment.shape() == shape {
+			return child.routeNode
+		}
+	}
+
+	child := &mixedRouteNode{routeNode: &routeNode{}, segment: segment}
+	n.mixed = append(n.mixed, child)
+	// longer shapes are more specific: try them first
+	slices.SortStableFunc(n.mixed, func(a, b *mixedRouteNode) int {
+		return len(b.segment.shape()) - len(a.segment.shape())
+	})
+
+	return child.routeNode
+}
+
+// match returns the route matching the remaining segments, with the values of its parameters.
+func (n *routeNode) match(segments []string, values []string) (*route, []string) {
+	if len(segments) == 0 {
+		return n.route, values
+	}
+
+	segment, rest := segments[0], segments[1:]
+	if child, ok := n.static[segment]; ok {
+		if found, params := child.match(rest, values); found != nil {
+			return found, params
+		}
+	}
+
+	for _, child := range n.mixed {
+		captured, ok := child.segment.capture(segment)
+		if !ok {
+			continue
+		}
+		if found, params := child.match(rest, append(slices.Clip(values), captured...)); found != nil {
+			return found, params
+		}
+	}
+
+	if n.param != nil && segment != "" {
+		if found, params := n.param.match(rest, append(slices.Clip(values), segment)); found != nil {
+			return found, params
+		}
+	}
+
+	return nil, nil
+}
+
+// Match finds the operation for a request method and URL path.
+//
+// The path is the escaped path of the request URL, including the base path of the spec.
+func (r *Router) Match(method, path string) RouteMatch {
+	rel, ok := strings.CutPrefix(path, r.basePath)
+	if !ok || (rel != "" && !strings.HasPrefix(rel, "/")) {
+		return RouteMatch{Status: http.StatusNotFound}
+	}
+	if rel == "" {
+		rel = "/"
+	}
+
+	segments, ok := splitURLPath(rel)
+	if !ok {
+		return RouteMatch{Status: http.StatusNotFound}
+	}
+
+	found, values := r.root.match(segments, nil)
+	if found == nil {
+		return RouteMatch{Status: http.StatusNotFound}
+	}
+
+	match := RouteMatch{
+		Status:   http.StatusOK,
+		Path:     found.template.String(),
+		PathItem: found.pathItem,
+		Params:   make(map[string]string, len(values)),
+	}
+	for i, name := range found.template.Params() {
+		match.Params[name] = values[i]
+	}
+
+	if field := found.pathItem.operationField(method); field != nil && *field != nil {
+		match.Operation = *field
+
+		return match
+	}
+
+	match.Status = http.StatusMethodNotAllowed
+	for _, op := range found.pathItem.operations() {
+		match.Allowed = append(match.Allowed, strings.ToUpper(op.method))
+	}
+
+	return match
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+const routerSpec = `{
+  "swagger": "2.0",
+  "info": { "title": "pets", "version": "1.0" },
+  "basePath": "/v1",
+  "paths": {
+    "/": { "get": { "operationId": "root" } },
+    "/pets": { "get": { "operationId": "listPets" }, "post": { "operationId": "createPet" } },
+    "/pets/mine": { "get": { "operationId": "listMyPets" } },
+    "/pets/{id}": { "get": { "operationId": "getPet" }, "delete": { "operationId": "deletePet" } },
+    "/pets/{id}/photos/{name}.{ext}": { "get": { "operationId": "getPhoto" } },
+    "/pets/{id}/photos/{name}": { "get": { "operationId": "getPhotoMetadata" } },
+    "/pets/{petId}/toys/{toyId}": { "get": { "operationId": "getToy" } },
+    "/stores/{storeId}/pets/mine": { "get": { "operationId": "listStorePets" } }
+  }
+}`
+
+func TestRouter_Match(t *testing.T) {
+	router, err := NewRouter(mustSpec(t, routerSpec))
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		method      string
+		path        string
+		operationID string
+		params      map[string]string
+	}{
+		{http.MethodGet, "/v1", "root", map[string]string{}},
+		{http.MethodGet, "/v1/", "root", map[string]string{}},
+		{http.MethodGet, "/v1/pets", "listPets", map[string]string{}},
+		{http.MethodPost, "/v1/pets", "createPet", map[string]string{}},
+		{"get", "/v1/pets/mine", "listMyPets", map[string]string{}},
+		{http.MethodGet, "/v1/pets/42", "getPet", map[string]string{"id": "42"}},
+		{http.MethodGet, "/v1/pets/a%2Fb", "getPet", map[string]string{"id": "a/b"}},
+		{http.MethodGet, "/v1/pets/42/photos/cat.tar.gz", "getPhoto", map[string]string{"id": "42", "name": "cat", "ext": "tar.gz"}},
+		{http.MethodGet, "/v1/pets/42/photos/cat", "getPhotoMetadata", map[string]string{"id": "42", "name": "cat"}},
+		{http.MethodGet, "/v1/pets/mine/toys/ball", "getToy", map[string]string{"petId": "mine", "toyId": "ball"}},
+		{http.MethodGet, "/v1/stores/s1/pets/mine", "listStorePets", map[string]string{"storeId": "s1"}},
+	} {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			match := router.Match(tc.method, tc.path)
+			require.EqualT(t, http.StatusOK, match.Status)
+			require.NotNil(t, match.Operation)
+			assert.EqualT(t, tc.operationID, match.Operation.ID)
+			assert.Equal(t, tc.params, match.Params)
+			assert.NotNil(t, match.PathItem)
+		})
+	}
+
+	t.Run("unknown paths should not be found", func(t *testing.T) {
+		for _, path := range []string{"/pets", "/v1/pets/", "/v10/pets", "/v1/pets/42/photos/a/b", "/v1/pets/%zz", "/v1/stores"} {
+			assert.EqualT(t, http.StatusNotFound, router.Match(http.MethodGet, path).Status, path)
+		}
+	})
+
+	t.Run("unsupported methods should list the allowed ones", func(t *testing.T) {
+		match := router.Match(http.MethodPut, "/v1/pets/42")
+		assert.EqualT(t, http.StatusMethodNotAllowed, match.Status)
+		assert.EqualT(t, "/pets/{id}", match.Path)
+		assert.Nil(t, match.Operation)
+		assert.Equal(t, []string{"GET", "DELETE"}, match.Allowed)
+	})
+}
+
+func TestNewRouter_Errors(t *testing.T) {
+	for _, path := range []string{"/pets/{id", "/pets/{}", "/pets/{a}{b}", "/pets/id}"} {
+		t.Run(path, func(t *testing.T) {
+			sp := &Swagger{}
+			sp.Paths = &Paths{Paths: map[string]PathItem{path: {}}}
+			_, err := NewRouter(sp)
+			require.ErrorIs(t, err, ErrSpec)
+		})
+	}
+
+	t.Run("equivalent templates should be reported", func(t *testing.T) {
+		sp := &Swagger{}
+		sp.Paths = &Paths{Paths: map[string]PathItem{"/pets/{id}": {}, "/pets/{petId}": {}}}
+		_, err := NewRouter(sp)
+		require.ErrorIs(t, err, ErrSpec)
+	})
+}
+
+func TestNewRouter_NilSpec(t *testing.T) {
+	_, err := NewRouter(nil)
+	require.ErrorIs(t, err, ErrSpec)
+}
+
+func TestNewRouter_PathItemRef(t *testing.T) {
+	sp := &Swagger{}
+	require.NoError(t, sp.UnmarshalJSON([]byte(`{
+  "swagger": "2.0",
+  "info": { "title": "pets", "version": "1.0" },
+  "paths": {
+    "/pets": { "get": { "operationId": "listPets" } },
+    "/animals": { "$ref": "#/paths/~1pets" },
+    "/beasts": { "$ref": "#/paths/~1animals" }
+  }
+}`)))
+
+	router, err := NewRouter(sp)
+	require.NoError(t, err)
+
+	for _, path := range []string{"/animals", "/beasts"} {
+		match := router.Match(http.MethodGet, path)
+		assert.EqualT(t, http.StatusOK, match.Status, path)
+		require.NotNil(t, match.Operation, path)
+		assert.EqualT(t, "listPets", match.Operation.ID, path)
+	}
+
+	t.Run("unresolved path items should be reported", func(t *testing.T) {
+		sp.Paths.Paths["/plants"] = PathItem{Refable: Refable{Ref: MustCreateRef("#/paths/~1missing")}}
+		_, err := NewRouter(sp)
+		require.Error(t, err)
+	})
+
+	t.Run("circular path items should be reported", func(t *testing.T) {
+		sp.Paths.Paths["/plants"] = PathItem{Refable: Refable{Ref: MustCreateRef("#/paths/~1plants")}}
+		_, err := NewRouter(sp)
+		require.ErrorIs(t, err, ErrSpec)
+	})
+}
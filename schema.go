@@ -6,6 +6,7 @@ package spec
 import (
 	"encoding/json"
 	"fmt"
+	"iter"
 	"strings"
 
 	"github.com/go-openapi/jsonpointer"
@@ -228,6 +229,43 @@ func (s Schema) JSONLookup(token string) (any, error) {
 	return r, err
 }
 
+// JSONSet sets a value by the json property name.
+//
+// Missing containers, such as extensions, are created.
+func (s *Schema) JSONSet(token string, value any) error {
+	return jsonSetToken(s, token, value)
+}
+
+// AllSubschemas iterates over the subschemas of this schema, recursively, with their JSON pointer
+// relative to this schema, e.g. "/properties/name".
+//
+// Subschemas are found in allOf, oneOf, anyOf, not, items, properties, additionalProperties,
+// patternProperties, dependencies, additionalItems and definitions. They are yielded depth-first,
+// each one before its own subschemas. $ref's are not followed.
+//
+// Subschemas held in maps, such as properties, are yielded as copies.
+func (s *Schema) AllSubschemas() iter.Seq2[string, *Schema] {
+	return func(yield func(string, *Schema) bool) {
+		yieldSubschemas(s, "", yield)
+	}
+}
+
+func yieldSubschemas(s *Schema, ptr string, yield func(string, *Schema) bool) bool {
+	for _, child := range walkChildren(s) {
+		sub, ok := child.node.(*Schema)
+		if !ok {
+			continue
+		}
+
+		subPtr := joinPointer(ptr, child.tokens...)
+		if !yield(subPtr, sub) || !yieldSubschemas(sub, subPtr, yield) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // WithID sets the id for this schema, allows for chaining.
 func (s *Schema) WithID(id string) *Schema {
 	s.ID = id
@@ -0,0 +1,453 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SchemaRegistry builds the schemas of Go types, and adds the definitions of named struct types to a spec.
+//
+// Schemas follow the JSON encoding of values, as implemented by encoding/json:
+//   - json tags rename fields, skip them with "-", make them optional with "omitempty",
+//     and encode numbers and booleans as strings with "string"
+//   - named struct types become definitions, referred to with a $ref. Recursive types are supported
+//   - embedded structs, which fields are promoted, are composed with allOf
+//   - pointers are unwrapped: pointer fields are optional, and nullable unless omitted when nil
+//   - time.Time is a date-time string, []byte is a base64 string, maps allow additional properties,
+//     slices and arrays are arrays, interfaces and types with a custom JSON encoding accept any value
+//
+// A field is required unless it is a pointer or is omitted when empty. Validation tags, in the style of
+// github.com/go-playground/validator, add validations to the schema of a field. Supported rules are
+// required, min, max, len, gt, gte, lt, lte, oneof, unique, and the email, uuid, uri, url, hostname,
+// ipv4, ipv6 and datetime formats. Other rules are ignored.
+type SchemaRegistry struct {
+	spec  *Swagger
+	names map[reflect.Type]string
+}
+
+// NewSchemaRegistry creates a registry which adds definitions to a spec.
+func NewSchemaRegistry(spec *Swagger) *SchemaRegistry {
+	return &SchemaRegistry{spec: spec, names: make(map[reflect.Type]string)}
+}
+
+// SchemaFor builds a self-contained schema for a Go type.
+//
+// The definitions of the named struct types it uses are held in the definitions of the schema.
+// See [SchemaRegistry] for the mapping of Go types to schemas.
+func SchemaFor(t reflect.Type) (*Schema, error) {
+	r := NewSchemaRegistry(&Swagger{})
+	schema, err := r.schemaFor(indirectType(t), true)
+	if err != nil {
+		return nil, err
+	}
+	schema.Definitions = r.spec.Definitions
+
+	return schema, nil
+}
+
+// SchemaFor builds the schema of a Go type, adding the definitions of the named struct types
+// it uses to the spec. For a named struct type, the schema is a $ref to its definition.
+func (r *SchemaRegistry) SchemaFor(t reflect.Type) (*Schema, error) {
+	return r.schemaFor(t, false)
+}
+
+// Register adds the definition of named struct types to the spec, and returns the name of their definitions.
+func (r *SchemaRegistry) Register(types ...reflect.Type) ([]string, error) {
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		t = indirectType(t)
+		if t.Kind() != reflect.Struct || t.Name() == "" {
+			return nil, fmt.Errorf("cannot register %s, which is not a named struct type: %w", t, ErrSpec)
+		}
+
+		if _, err := r.schemaFor(t, false); err != nil {
+			return nil, err
+		}
+		names = append(names, r.names[t])
+	}
+
+	return names, nil
+}
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()              //nolint:gochecknoglobals // constant-like type
+	rawMessageType    = reflect.TypeFor[json.RawMessage]()        //nolint:gochecknoglobals // constant-like type
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()         //nolint:gochecknoglobals // constant-like type
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]() //nolint:gochecknoglobals // constant-like type
+)
+
+// schemaFor builds the schema of a type. With inline, a named struct type is described by its
+// definition rather than by a $ref.
+func (r *SchemaRegistry) schemaFor(t reflect.Type, inline bool) (*Schema, error) {
+	switch {
+	case t == timeType:
+		return DateTimeProperty(), nil
+	case t == rawMessageType:
+		return &Schema{}, nil
+	case t.Kind() != reflect.Interface && t.Kind() != reflect.Pointer &&
+		(t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType)):
+		return &Schema{}, nil
+	case t.Kind() != reflect.Interface && t.Kind() != reflect.Pointer &&
+		(t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)):
+		return StringProperty(), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return BooleanProperty(), nil
+	case reflect.Int, reflect.Int64:
+		return Int64Property(), nil
+	case reflect.Int8:
+		return Int8Property(), nil
+	case reflect.Int16:
+		return Int16Property(), nil
+	case reflect.Int32:
+		return Int32Property(), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return Int64Property().WithMinimum(0, false), nil
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return new(Schema).Typed("integer", "uint64").WithMinimum(0, false), nil
+	case reflect.Float32:
+		return Float32Property(), nil
+	case reflect.Float64:
+		return Float64Property(), nil
+	case reflect.String:
+		return StringProperty(), nil
+	case reflect.Interface:
+		return &Schema{}, nil
+	case reflect.Pointer:
+		return r.schemaFor(t.Elem(), inline)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return StrFmtProperty("byte"), nil
+		}
+
+		items, err := r.schemaFor(t.Elem(), false)
+		if err != nil {
+			return nil, err
+		}
+		schema := ArrayProperty(items)
+		if t.Kind() == reflect.Array {
+			schema.WithMinItems(int64(t.Len())).WithMaxItems(int64(t.Len()))
+		}
+
+		return schema, nil
+	case reflect.Map:
+		switch t.Key().Kind() {
+		case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		default:
+			if !reflect.PointerTo(t.Key()).Implements(textMarshalerType) {
+				return nil, fmt.Errorf("unsupported map key type %s: %w", t.Key(), ErrSpec)
+			}
+		}
+
+		values, err := r.schemaFor(t.Elem(), false)
+		if err != nil {
+			return nil, err
+		}
+
+		return MapProperty(values), nil
+	case reflect.Struct:
+		if t.Name() == "" || inline {
+			return r.structSchema(t)
+		}
+
+		return r.definition(t)
+	default:
+		return nil, fmt.Errorf("unsupported type %s: %w", t, ErrSpec)
+	}
+}
+
+// definition adds the definition of a named struct type to the spec, and returns a $ref to it.
+func (r *SchemaRegistry) definition(t reflect.Type) (*Schema, error) {
+	if name, ok := r.names[t]; ok {
+		return RefSchema("#/definitions/" + name), nil
+	}
+
+	name := r.definitionName(t)
+	// registered before the definition is built, so that recursive types refer to it
+	r.names[t] = name
+
+	schema, err := r.structSchema(t)
+	if err != nil {
+		delete(r.names, t)
+
+		return nil, err
+	}
+
+	if r.spec.Definitions == nil {
+		r.spec.Definitions = make(Definitions)
+	}
+	r.spec.Definitions[name] = *schema
+
+	return RefSchema("#/definitions/" + name), nil
+}
+
+// definitionName returns a name for the definition of a type, unique in the spec.
+//
+// Types with the same name in different packages are qualified by their package.
+func (r *SchemaRegistry) definitionName(t reflect.Type) string {
+	sanitize := func(name string) string {
+		return strings.Map(func(c rune) rune {
+			if unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '.' {
+				return c
+			}
+
+			return -1
+		}, name)
+	}
+
+	taken := func(name string) bool {
+		_, exists := r.spec.Definitions[name]
+		for _, other := range r.names {
+			exists = exists || other == name
+		}
+
+		return exists
+	}
+
+	name := sanitize(t.Name())
+	if !taken(name) {
+		return name
+	}
+
+	name = sanitize(path.Base(t.PkgPath())) + "." + name
+	unique := name
+	for n := 2; taken(unique); n++ {
+		unique = name + strconv.Itoa(n)
+	}
+
+	return unique
+}
+
+// structSchema builds the schema of the fields of a struct.
+func (r *SchemaRegistry) structSchema(t reflect.Type) (*Schema, error) {
+	object := new(Schema).Typed("object", "")
+	var embedded []Schema
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		fieldType := indirectType(field.Type)
+		isEmbeddedStruct := field.Anonymous && fieldType.Kind() == reflect.Struct
+		if !field.IsExported() && !isEmbeddedStruct {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if isEmbeddedStruct && name == "" {
+			// the fields of embedded structs are promoted
+			schema, err := r.schemaFor(fieldType, false)
+			if err != nil {
+				return nil, fmt.Errorf("field %s of %s: %w", field.Name, t, err)
+			}
+			embedded = append(embedded, *schema)
+
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		schema, err := r.schemaFor(field.Type, false)
+		if err != nil {
+			return nil, fmt.Errorf("field %s of %s: %w", field.Name, t, err)
+		}
+
+		omitEmpty, asString := false, false
+		for option := range strings.SplitSeq(options, ",") {
+			omitEmpty = omitEmpty || option == "omitempty" || option == "omitzero"
+			asString = asString || option == "string"
+		}
+
+		if asString && len(schema.Type) == 1 && schema.Ref.String() == "" {
+			switch schema.Type[0] {
+			case "integer", "number", "boolean":
+				schema.Type = StringOrArray{"string"}
+				schema.Minimum = nil
+			}
+		}
+
+		isPointer := field.Type.Kind() == reflect.Pointer
+		if isPointer && !omitEmpty && schema.Ref.String() == "" {
+			schema.AddExtension("x-nullable", true)
+		}
+
+		required, err := applyValidateTag(schema, fieldType, field.Tag.Get("validate"))
+		if err != nil {
+			return nil, fmt.Errorf("field %s of %s: %w", field.Name, t, err)
+		}
+		if required || (!omitEmpty && !isPointer) {
+			object.AddRequired(name)
+		}
+
+		object.SetProperty(name, *schema)
+	}
+
+	if len(embedded) == 0 {
+		return object, nil
+	}
+
+	if len(object.Properties) > 0 {
+		embedded = append(embedded, *object)
+	}
+
+	return ComposedSchema(embedded...), nil
+}
+
+// applyValidateTag adds the validations of a validate tag to a schema, and tells if the value is required.
+func applyValidateTag(schema *Schema, t reflect.Type, tag string) (bool, error) {
+	if tag == "" {
+		return false, nil
+	}
+
+	required := false
+	for rule := range strings.SplitSeq(tag, ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "dive":
+			// the following rules apply to elements
+			return required, nil
+		case "required":
+			required = true
+		case "min", "max", "len", "gt", "gte", "lt", "lte":
+			if err := applyBound(schema, t, key, value); err != nil {
+				return false, err
+			}
+		case "oneof":
+			for item := range strings.FieldsSeq(value) {
+				enumValue, err := parseEnumValue(t, item)
+				if err != nil {
+					return false, err
+				}
+				schema.Enum = append(schema.Enum, enumValue)
+			}
+		case "unique":
+			schema.UniqueValues()
+		case "email", "uuid", "uri", "hostname", "ipv4", "ipv6":
+			schema.Format = key
+		case "url":
+			schema.Format = "uri"
+		case "datetime":
+			schema.Format = "date-time"
+		}
+	}
+
+	return required, nil
+}
+
+// applyBound applies a min, max, len, gt, gte, lt or lte rule, depending on the kind of the value.
+func applyBound(schema *Schema, t reflect.Type, rule, value string) error {
+	switch t.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s rule %q: %w", rule, value, ErrSpec)
+		}
+
+		var minimum, maximum *int64
+		switch rule {
+		case "min", "gte":
+			minimum = &n
+		case "gt":
+			minimum = ptrTo(n + 1)
+		case "max", "lte":
+			maximum = &n
+		case "lt":
+			maximum = ptrTo(n - 1)
+		case "len":
+			minimum, maximum = &n, &n
+		}
+
+		switch t.Kind() {
+		case reflect.String:
+			schema.MinLength, schema.MaxLength = firstNonNil(minimum, schema.MinLength), firstNonNil(maximum, schema.MaxLength)
+		case reflect.Map:
+			schema.MinProperties, schema.MaxProperties = firstNonNil(minimum, schema.MinProperties), firstNonNil(maximum, schema.MaxProperties)
+		default:
+			schema.MinItems, schema.MaxItems = firstNonNil(minimum, schema.MinItems), firstNonNil(maximum, schema.MaxItems)
+		}
+	default:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s rule %q: %w", rule, value, ErrSpec)
+		}
+
+		switch rule {
+		case "min", "gte":
+			schema.WithMinimum(n, false)
+		case "gt":
+			schema.WithMinimum(n, true)
+		case "max", "lte":
+			schema.WithMaximum(n, false)
+		case "lt":
+			schema.WithMaximum(n, true)
+		case "len":
+			schema.WithMinimum(n, false).WithMaximum(n, false)
+		}
+	}
+
+	return nil
+}
+
+// parseEnumValue converts a value of a oneof rule to the JSON value of a type.
+func parseEnumValue(t reflect.Type, value string) (any, error) {
+	var (
+		parsed any
+		err    error
+	)
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err = strconv.ParseInt(value, 10, 64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		parsed, err = strconv.ParseUint(value, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		parsed, err = strconv.ParseFloat(value, 64)
+	default:
+		parsed = value
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid oneof value %q for %s: %w", value, t, ErrSpec)
+	}
+
+	return parsed, nil
+}
+
+// indirectType returns the type pointed to by pointer types.
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
+
+// firstNonNil returns the first non-nil pointer.
+func firstNonNil[T any](a, b *T) *T {
+	if a != nil {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+type schemaForAudit struct {
+	CreatedAt time.Time  `json:"createdAt"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+type schemaForPet struct {
+	schemaForAudit
+
+	ID       uint64            `json:"id,string"`
+	Name     string            `json:"name"               validate:"required,min=1,max=64"`
+	Kind     string            `json:"kind,omitempty"     validate:"oneof=cat dog"`
+	Age      int32             `json:"age,omitempty"      validate:"gte=0,lt=100"`
+	Tags     []string          `json:"tags,omitempty"     validate:"max=10,unique,dive,min=1"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Photo    []byte            `json:"photo,omitempty"`
+	Owner    *schemaForPerson  `json:"owner"`
+	Contact  string            `json:"contact,omitempty"  validate:"email"`
+	Extra    any               `json:"extra,omitempty"`
+	Internal string            `json:"-"`
+	Untagged bool
+	private  string //nolint:unused // unexported fields are not serialized
+}
+
+type schemaForPerson struct {
+	Name    string             `json:"name"`
+	Friends []*schemaForPerson `json:"friends,omitempty"`
+}
+
+func TestSchemaRegistry(t *testing.T) {
+	t.Run("named structs should become definitions", func(t *testing.T) {
+		sp := NewSwagger()
+		r := NewSchemaRegistry(sp)
+
+		schema, err := r.SchemaFor(reflect.TypeFor[schemaForPet]())
+		require.NoError(t, err)
+		assert.EqualT(t, "#/definitions/schemaForPet", schema.Ref.String())
+		assert.Equal(t, []string{"schemaForAudit", "schemaForPerson", "schemaForPet"}, mapKeysSorted(sp.Definitions))
+
+		assert.JSONMarshalAsT(t, `{
+  "type": "object",
+  "required": [ "createdAt" ],
+  "properties": {
+    "createdAt": { "type": "string", "format": "date-time" },
+    "deletedAt": { "type": "string", "format": "date-time" }
+  }
+}`, sp.Definitions["schemaForAudit"])
+
+		assert.JSONMarshalAsT(t, `{
+  "allOf": [
+    { "$ref": "#/definitions/schemaForAudit" },
+    {
+      "type": "object",
+      "required": [ "id", "name", "Untagged" ],
+      "properties": {
+        "id": { "type": "string", "format": "uint64" },
+        "name": { "type": "string", "minLength": 1, "maxLength": 64 },
+        "kind": { "type": "string", "enum": [ "cat", "dog" ] },
+        "age": { "type": "integer", "format": "int32", "minimum": 0, "maximum": 100, "exclusiveMaximum": true },
+        "tags": { "type": "array", "items": { "type": "string" }, "maxItems": 10, "uniqueItems": true },
+        "labels": { "type": "object", "additionalProperties": { "type": "string" } },
+        "photo": { "type": "string", "format": "byte" },
+        "owner": { "$ref": "#/definitions/schemaForPerson" },
+        "contact": { "type": "string", "format": "email" },
+        "extra": {},
+        "Untagged": { "type": "boolean" }
+      }
+    }
+  ]
+}`, sp.Definitions["schemaForPet"])
+	})
+
+	t.Run("recursive types should refer to their definition", func(t *testing.T) {
+		sp := NewSwagger()
+		schema, err := NewSchemaRegistry(sp).SchemaFor(reflect.TypeFor[*schemaForPerson]())
+		require.NoError(t, err)
+		assert.EqualT(t, "#/definitions/schemaForPerson", schema.Ref.String())
+
+		assert.JSONMarshalAsT(t, `{
+  "type": "object",
+  "required": [ "name" ],
+  "properties": {
+    "name": { "type": "string" },
+    "friends": { "type": "array", "items": { "$ref": "#/definitions/schemaForPerson" } }
+  }
+}`, sp.Definitions["schemaForPerson"])
+	})
+
+	t.Run("conflicting names should be qualified", func(t *testing.T) {
+		sp := NewSwagger()
+		sp.AddDefinition("schemaForPerson", StringProperty())
+
+		names, err := NewSchemaRegistry(sp).Register(reflect.TypeFor[schemaForPerson]())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"spec.schemaForPerson"}, names)
+		assert.EqualT(t, "#/definitions/spec.schemaForPerson",
+			sp.Definitions["spec.schemaForPerson"].Properties["friends"].Items.Schema.Ref.String())
+	})
+
+	t.Run("unsupported types should fail", func(t *testing.T) {
+		r := NewSchemaRegistry(NewSwagger())
+		_, err := r.SchemaFor(reflect.TypeFor[struct{ C chan int }]())
+		require.ErrorIs(t, err, ErrSpec)
+
+		_, err = r.SchemaFor(reflect.TypeFor[struct {
+			N int `validate:"max=ten"`
+		}]())
+		require.ErrorIs(t, err, ErrSpec)
+
+		_, err = r.Register(reflect.TypeFor[[]string]())
+		require.ErrorIs(t, err, ErrSpec)
+	})
+}
+
+func TestSchemaFor(t *testing.T) {
+	t.Run("schemas should be self-contained", func(t *testing.T) {
+		schema, err := SchemaFor(reflect.TypeFor[schemaForPerson]())
+		require.NoError(t, err)
+		assert.EqualT(t, "object", schema.Type[0])
+		assert.EqualT(t, "#/definitions/schemaForPerson", schema.Properties["friends"].Items.Schema.Ref.String())
+		assert.Equal(t, []string{"schemaForPerson"}, mapKeysSorted(schema.Definitions))
+	})
+
+	t.Run("builtin types should map to formats", func(t *testing.T) {
+		for _, tc := range []struct {
+			typ      reflect.Type
+			expected string
+		}{
+			{reflect.TypeFor[int](), `{"type":"integer","format":"int64"}`},
+			{reflect.TypeFor[uint8](), `{"type":"integer","format":"int64","minimum":0}`},
+			{reflect.TypeFor[float32](), `{"type":"number","format":"float"}`},
+			{reflect.TypeFor[[2]float64](), `{"type":"array","items":{"type":"number","format":"double"},"minItems":2,"maxItems":2}`},
+			{reflect.TypeFor[map[int]bool](), `{"type":"object","additionalProperties":{"type":"boolean"}}`},
+			{reflect.TypeFor[json.RawMessage](), `{}`},
+			{reflect.TypeFor[*time.Time](), `{"type":"string","format":"date-time"}`},
+		} {
+			schema, err := SchemaFor(tc.typ)
+			require.NoError(t, err)
+			assert.JSONMarshalAsT(t, tc.expected, schema)
+		}
+	})
+}
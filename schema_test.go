@@ -210,3 +210,40 @@ func TestSchemaWithValidation(t *testing.T) {
 
 	assert.Equal(t, val, s.Validations())
 }
+
+func TestSchema_AllSubschemas(t *testing.T) {
+	var s Schema
+	require.NoError(t, json.Unmarshal([]byte(`{
+  "allOf": [ { "$ref": "#/definitions/Base" } ],
+  "properties": {
+    "tags": { "type": "array", "items": { "type": "string" } },
+    "kind": { "not": { "enum": [ "none" ] } }
+  },
+  "additionalProperties": { "anyOf": [ { "type": "integer" }, { "type": "number" } ] }
+}`), &s))
+
+	var pointers []string
+	for ptr, sub := range s.AllSubschemas() {
+		require.NotNil(t, sub)
+		pointers = append(pointers, ptr)
+	}
+	assert.Equal(t, []string{
+		"/allOf/0",
+		"/properties/kind",
+		"/properties/kind/not",
+		"/properties/tags",
+		"/properties/tags/items",
+		"/additionalProperties",
+		"/additionalProperties/anyOf/0",
+		"/additionalProperties/anyOf/1",
+	}, pointers)
+
+	pointers = pointers[:0]
+	for ptr := range s.AllSubschemas() {
+		pointers = append(pointers, ptr)
+		if ptr == "/properties/kind/not" {
+			break
+		}
+	}
+	assert.Len(t, pointers, 3)
+}
@@ -0,0 +1,946 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ValidationDirection tells if a value is sent or received by an API, which matters for readOnly properties.
+type ValidationDirection int
+
+// Directions of validated values.
+const (
+	// DirectionNone ignores readOnly properties
+	DirectionNone ValidationDirection = iota
+
+	// DirectionRequest validates a value sent in a request: readOnly properties must not be present,
+	// and are not required
+	DirectionRequest
+
+	// DirectionResponse validates a value received in a response: readOnly properties are validated as any other
+	DirectionResponse
+)
+
+// SchemaValidationOptions configures the validation of values against a schema.
+type SchemaValidationOptions struct {
+	// Root is the document holding the schema, e.g. a *Swagger. It is used to resolve $ref's
+	// and the types designated by discriminators. When nil, the schema is its own root.
+	Root any
+
+	// ExpandOptions configures how $ref's are resolved, e.g. the location of the root document
+	ExpandOptions *ExpandOptions
+
+	// Direction of the validated value
+	Direction ValidationDirection
+
+	// Formats adds or replaces the checks of string formats.
+	//
+	// Built-in checks are provided for the formats date, date-time, email, hostname, ipv4, ipv6, uri, uuid and byte,
+	// and for the number formats int32, int64, float and double. Other formats are not checked.
+	Formats map[string]func(string) bool
+}
+
+// SchemaValidationError is a violation of a schema by a value, found by a [SchemaValidator].
+type SchemaValidationError struct {
+	// InstancePointer is the JSON pointer to the invalid part of the value, e.g. "/pets/0/name"
+	InstancePointer string
+
+	// SchemaPointer is the location of the violated keyword, as a JSON reference from the root of the schema,
+	// e.g. "#/properties/pets/items/$ref" or "#/definitions/Pet/properties/name/maxLength"
+	SchemaPointer string
+
+	// Keyword is the violated keyword, e.g. "maxLength"
+	Keyword string
+
+	// Message describes the violation
+	Message string
+
+	// Causes are the violations of the schema of anyOf or oneOf which is the closest to match the value,
+	// when the value matches none of them
+	Causes SchemaValidationErrors
+}
+
+// Error returns the message of the violation, prefixed by its location in the value.
+func (e *SchemaValidationError) Error() string {
+	ptr := e.InstancePointer
+	if ptr == "" {
+		ptr = "/"
+	}
+
+	return ptr + ": " + e.Message
+}
+
+// Unwrap allows to check that the error is a validation error with errors.Is(err, ErrValidation).
+func (e *SchemaValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// SchemaValidationErrors is the list of violations found by a [SchemaValidator].
+type SchemaValidationErrors []*SchemaValidationError
+
+// Error returns all the violations, one per line.
+func (e SchemaValidationErrors) Error() string {
+	lines := make([]string, 0, len(e))
+	for _, err := range e {
+		lines = append(lines, err.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns the individual violations.
+func (e SchemaValidationErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// SchemaValidator validates values against a JSON schema (draft 4), with the additions of Swagger 2.0:
+// readOnly properties, discriminators, nullable schemas (with x-nullable or nullable) and formats.
+//
+// A validator caches resolved $ref's and compiled patterns, and may be reused to validate several values.
+// It is not safe for concurrent use.
+type SchemaValidator struct {
+	schema   *Schema
+	options  SchemaValidationOptions
+	resolver *refResolver
+	resolved map[string]resolvedSchema
+	regexps  map[string]*regexp.Regexp
+}
+
+type resolvedSchema struct {
+	schema   *Schema
+	location string
+	base     string
+	err      error
+}
+
+// NewSchemaValidator builds a validator for a schema.
+func NewSchemaValidator(schema *Schema, options *SchemaValidationOptions) *SchemaValidator {
+	v := &SchemaValidator{
+		schema:   schema,
+		resolved: make(map[string]resolvedSchema),
+		regexps:  make(map[string]*regexp.Regexp),
+	}
+	if options != nil {
+		v.options = *options
+	}
+
+	root := v.options.Root
+	if root == nil {
+		root = schema
+	}
+	v.resolver = newRefResolver(root, v.options.ExpandOptions)
+
+	return v
+}
+
+// ValidateValue validates a value against a schema.
+//
+// This is a shorthand for NewSchemaValidator(schema, options).Validate(value).
+func ValidateValue(schema *Schema, value any, options *SchemaValidationOptions) error {
+	return NewSchemaValidator(schema, options).Validate(value)
+}
+
+// Validate validates a value against the schema.
+//
+// The value is either a generic JSON value, as produced by [json.Unmarshal] into an any,
+// or any other value, which is then validated in its JSON representation.
+//
+// It returns nil when the value is valid, or a [SchemaValidationErrors] with all the violations found.
+func (v *SchemaValidator) Validate(value any) error {
+	if v.schema == nil {
+		return nil
+	}
+
+	generic, err := toGenericJSON(value)
+	if err != nil {
+		return fmt.Errorf("cannot validate a %T: %w: %w", value, err, ErrSpec)
+	}
+
+	state := &validationState{
+		discriminated: make(map[string]bool),
+		refs:          make(map[string]bool),
+	}
+	errs := v.validate(v.schema, schemaLocation{ptr: "#"}, generic, "", state)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// schemaLocation is the location of a schema, within the document located at base.
+type schemaLocation struct {
+	ptr  string
+	base string
+}
+
+func (l schemaLocation) child(tokens ...string) schemaLocation {
+	return schemaLocation{ptr: joinPointer(l.ptr, tokens...), base: l.base}
+}
+
+// validationState keeps track of the traversal of the schema.
+type validationState struct {
+	// discriminated marks the parts of the value which have already been dispatched to the type named
+	// by their discriminator
+	discriminated map[string]bool
+
+	// refs marks the $ref's being followed for parts of the value, to break cycles
+	refs map[string]bool
+}
+
+func (v *SchemaValidator) errorf(loc schemaLocation, ptr, keyword, format string, args ...any) *SchemaValidationError {
+	return &SchemaValidationError{
+		InstancePointer: ptr,
+		SchemaPointer:   joinPointer(loc.ptr, keyword),
+		Keyword:         keyword,
+		Message:         fmt.Sprintf(format, args...),
+	}
+}
+
+func (v *SchemaValidator) validate(s *Schema, loc schemaLocation, value any, ptr string, state *validationState) SchemaValidationErrors {
+	if s.Ref.String() != "" {
+		return v.validateRef(s, loc, value, ptr, state)
+	}
+
+	if value == nil && (s.Nullable || isExtensionTrue(s.Extensions, "x-nullable")) {
+		return nil
+	}
+
+	if s.Discriminator != "" && !state.discriminated[ptr] {
+		if obj, ok := value.(map[string]any); ok {
+			return v.validateDiscriminated(s, loc, obj, ptr, state)
+		}
+	}
+
+	var errs SchemaValidationErrors
+	if err := v.validateType(s, loc, value, ptr); err != nil {
+		// other keywords would only add noise
+		return append(errs, err)
+	}
+
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool { return equalJSON(e, value) }) {
+		errs = append(errs, v.errorf(loc, ptr, "enum", "the value must be one of %s", jsonString(s.Enum)))
+	}
+
+	switch tv := value.(type) {
+	case string:
+		errs = append(errs, v.validateString(s, loc, tv, ptr)...)
+	case map[string]any:
+		errs = append(errs, v.validateObject(s, loc, tv, ptr, state)...)
+	case []any:
+		errs = append(errs, v.validateArray(s, loc, tv, ptr, state)...)
+	default:
+		if r, ok := jsonNumber(value); ok {
+			errs = append(errs, v.validateNumber(s, loc, r, ptr)...)
+		}
+	}
+
+	errs = append(errs, v.validateComposition(s, loc, value, ptr, state)...)
+
+	return errs
+}
+
+// validateRef validates a value against the target of a $ref. In draft 4, the other keywords of the schema are ignored.
+func (v *SchemaValidator) validateRef(s *Schema, loc schemaLocation, value any, ptr string, state *validationState) SchemaValidationErrors {
+	target := v.resolve(s.Ref, loc.base)
+	if target.err != nil {
+		return SchemaValidationErrors{v.errorf(loc, ptr, jsonRef, "cannot resolve %q: %v", s.Ref.String(), target.err)}
+	}
+
+	key := target.location + " " + ptr
+	if state.discriminated[ptr] {
+		// a base type is validated again, once the value has been dispatched to its subtype
+		key += " discriminated"
+	}
+	if state.refs[key] {
+		// the same part of the value is already being validated against this schema
+		return nil
+	}
+	state.refs[key] = true
+	defer delete(state.refs, key)
+
+	return v.validate(target.schema, schemaLocation{ptr: target.location, base: target.base}, value, ptr, state)
+}
+
+// resolve resolves a $ref found in the document located at base.
+func (v *SchemaValidator) resolve(ref Ref, base string) resolvedSchema {
+	key := v.resolver.key(ref, base)
+	if resolved, ok := v.resolved[key]; ok {
+		return resolved
+	}
+
+	var target Schema
+	targetBase, err := v.resolver.resolve(ref, base, &target)
+	resolved := resolvedSchema{schema: &target, base: targetBase, err: err}
+
+	normalized := normalizeRef(&ref, v.baseOrRoot(base))
+	if normalized.RemoteURI() == v.resolver.rootBase {
+		resolved.location = "#" + normalized.GetPointer().String()
+	} else {
+		resolved.location = normalized.String()
+	}
+	v.resolved[key] = resolved
+
+	return resolved
+}
+
+func (v *SchemaValidator) baseOrRoot(base string) string {
+	if base == "" {
+		return v.resolver.rootBase
+	}
+
+	return base
+}
+
+// validateDiscriminated validates an object against the type named by its discriminator.
+//
+// The type is a definition of the root document, which must be either the schema itself or a schema
+// which composes it with allOf.
+func (v *SchemaValidator) validateDiscriminated(s *Schema, loc schemaLocation, obj map[string]any, ptr string, state *validationState) SchemaValidationErrors {
+	const keyword = "discriminator"
+
+	state.discriminated[ptr] = true
+	defer delete(state.discriminated, ptr)
+
+	name, ok := obj[s.Discriminator].(string)
+	if !ok {
+		return SchemaValidationErrors{v.errorf(loc, joinPointer(ptr, s.Discriminator), keyword, "the discriminator %q must be a string", s.Discriminator)}
+	}
+
+	typeRef := MustCreateRef("#" + joinPointer("/definitions", name))
+	subtype := v.resolve(typeRef, "")
+	if subtype.err != nil {
+		return SchemaValidationErrors{v.errorf(loc, joinPointer(ptr, s.Discriminator), keyword, "unknown type %q", name)}
+	}
+
+	if subtype.location != loc.ptr && !v.extends(subtype, loc, make(map[string]bool)) {
+		return SchemaValidationErrors{v.errorf(loc, joinPointer(ptr, s.Discriminator), keyword, "the type %q does not extend %s", name, loc.ptr)}
+	}
+
+	if subtype.location == loc.ptr {
+		return v.validate(s, loc, obj, ptr, state)
+	}
+
+	return v.validate(subtype.schema, schemaLocation{ptr: subtype.location, base: subtype.base}, obj, ptr, state)
+}
+
+// extends tells if a schema composes the schema at a given location with allOf, directly or not.
+func (v *SchemaValidator) extends(s resolvedSchema, parent schemaLocation, seen map[string]bool) bool {
+	if seen[s.location] {
+		return false
+	}
+	seen[s.location] = true
+
+	for _, sub := range s.schema.AllOf {
+		if sub.Ref.String() == "" {
+			continue
+		}
+		target := v.resolve(sub.Ref, s.base)
+		if target.err != nil {
+			continue
+		}
+		if target.location == parent.ptr || v.extends(target, parent, seen) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (v *SchemaValidator) validateType(s *Schema, loc schemaLocation, value any, ptr string) *SchemaValidationError {
+	if len(s.Type) == 0 {
+		return nil
+	}
+
+	for _, t := range s.Type {
+		if jsonTypeMatches(t, value) {
+			return nil
+		}
+	}
+
+	if len(s.Type) == 1 {
+		return v.errorf(loc, ptr, "type", "expected a value of type %s, got %s", s.Type[0], jsonType(value))
+	}
+
+	return v.errorf(loc, ptr, "type", "expected a value of one of the types %s, got %s", strings.Join(s.Type, ", "), jsonType(value))
+}
+
+func (v *SchemaValidator) validateNumber(s *Schema, loc schemaLocation, r *big.Rat, ptr string) SchemaValidationErrors {
+	var errs SchemaValidationErrors
+
+	if s.MultipleOf != nil && *s.MultipleOf > 0 {
+		if m, ok := jsonNumber(*s.MultipleOf); ok && !new(big.Rat).Quo(r, m).IsInt() {
+			errs = append(errs, v.errorf(loc, ptr, "multipleOf", "the value must be a multiple of %v", *s.MultipleOf))
+		}
+	}
+
+	if s.Maximum != nil {
+		if m, ok := jsonNumber(*s.Maximum); ok {
+			switch c := r.Cmp(m); {
+			case s.ExclusiveMaximum && c >= 0:
+				errs = append(errs, v.errorf(loc, ptr, "maximum", "the value must be lower than %v", *s.Maximum))
+			case c > 0:
+				errs = append(errs, v.errorf(loc, ptr, "maximum", "the value must be lower than or equal to %v", *s.Maximum))
+			}
+		}
+	}
+
+	if s.Minimum != nil {
+		if m, ok := jsonNumber(*s.Minimum); ok {
+			switch c := r.Cmp(m); {
+			case s.ExclusiveMinimum && c <= 0:
+				errs = append(errs, v.errorf(loc, ptr, "minimum", "the value must be greater than %v", *s.Minimum))
+			case c < 0:
+				errs = append(errs, v.errorf(loc, ptr, "minimum", "the value must be greater than or equal to %v", *s.Minimum))
+			}
+		}
+	}
+
+	if s.Format != "" && !checkNumberFormat(s.Format, r) {
+		errs = append(errs, v.errorf(loc, ptr, "format", "the value is not a valid %s", s.Format))
+	}
+
+	return errs
+}
+
+func (v *SchemaValidator) validateString(s *Schema, loc schemaLocation, str string, ptr string) SchemaValidationErrors {
+	var errs SchemaValidationErrors
+
+	length := int64(utf8.RuneCountInString(str))
+	if s.MaxLength != nil && length > *s.MaxLength {
+		errs = append(errs, v.errorf(loc, ptr, "maxLength", "the value must have at most %d characters", *s.MaxLength))
+	}
+	if s.MinLength != nil && length < *s.MinLength {
+		errs = append(errs, v.errorf(loc, ptr, "minLength", "the value must have at least %d characters", *s.MinLength))
+	}
+
+	if s.Pattern != "" {
+		re, err := v.regexp(s.Pattern)
+		switch {
+		case err != nil:
+			errs = append(errs, v.errorf(loc, ptr, "pattern", "invalid pattern %q: %v", s.Pattern, err))
+		case !re.MatchString(str):
+			errs = append(errs, v.errorf(loc, ptr, "pattern", "the value must match the pattern %q", s.Pattern))
+		}
+	}
+
+	if s.Format != "" && !v.checkStringFormat(s.Format, str) {
+		errs = append(errs, v.errorf(loc, ptr, "format", "the value is not a valid %s", s.Format))
+	}
+
+	return errs
+}
+
+func (v *SchemaValidator) regexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := v.regexps[pattern]; ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	v.regexps[pattern] = re
+
+	return re, nil
+}
+
+func (v *SchemaValidator) validateArray(s *Schema, loc schemaLocation, arr []any, ptr string, state *validationState) SchemaValidationErrors {
+	var errs SchemaValidationErrors
+
+	if s.MaxItems != nil && int64(len(arr)) > *s.MaxItems {
+		errs = append(errs, v.errorf(loc, ptr, "maxItems", "the array must have at most %d items", *s.MaxItems))
+	}
+	if s.MinItems != nil && int64(len(arr)) < *s.MinItems {
+		errs = append(errs, v.errorf(loc, ptr, "minItems", "the array must have at least %d items", *s.MinItems))
+	}
+
+	if s.UniqueItems {
+	unique:
+		for i := range arr {
+			for j := range i {
+				if equalJSON(arr[i], arr[j]) {
+					errs = append(errs, v.errorf(loc, joinPointer(ptr, strconv.Itoa(i)), "uniqueItems", "the item is a duplicate of item %d", j))
+
+					break unique
+				}
+			}
+		}
+	}
+
+	if s.Items == nil {
+		return errs
+	}
+
+	if s.Items.Schema != nil {
+		for i, item := range arr {
+			errs = append(errs, v.validate(s.Items.Schema, loc.child("items"), item, joinPointer(ptr, strconv.Itoa(i)), state)...)
+		}
+
+		return errs
+	}
+
+	for i, item := range arr {
+		itemPtr := joinPointer(ptr, strconv.Itoa(i))
+		switch {
+		case i < len(s.Items.Schemas):
+			errs = append(errs, v.validate(&s.Items.Schemas[i], loc.child("items", strconv.Itoa(i)), item, itemPtr, state)...)
+		case s.AdditionalItems == nil:
+		case s.AdditionalItems.Schema != nil:
+			errs = append(errs, v.validate(s.AdditionalItems.Schema, loc.child("additionalItems"), item, itemPtr, state)...)
+		case !s.AdditionalItems.Allows:
+			errs = append(errs, v.errorf(loc, itemPtr, "additionalItems", "the array must have at most %d items", len(s.Items.Schemas)))
+		}
+	}
+
+	return errs
+}
+
+func (v *SchemaValidator) validateObject(s *Schema, loc schemaLocation, obj map[string]any, ptr string, state *validationState) SchemaValidationErrors {
+	var errs SchemaValidationErrors
+
+	if s.MaxProperties != nil && int64(len(obj)) > *s.MaxProperties {
+		errs = append(errs, v.errorf(loc, ptr, "maxProperties", "the object must have at most %d properties", *s.MaxProperties))
+	}
+	if s.MinProperties != nil && int64(len(obj)) < *s.MinProperties {
+		errs = append(errs, v.errorf(loc, ptr, "minProperties", "the object must have at least %d properties", *s.MinProperties))
+	}
+
+	for _, name := range s.Required {
+		if _, ok := obj[name]; ok {
+			continue
+		}
+		if v.options.Direction == DirectionRequest && v.isReadOnly(s, loc, name) {
+			continue
+		}
+		errs = append(errs, v.errorf(loc, ptr, "required", "the property %q is required", name))
+	}
+
+	for _, name := range mapKeysSorted(obj) {
+		value := obj[name]
+		propPtr := joinPointer(ptr, name)
+		matched := false
+
+		if prop, ok := s.Properties[name]; ok {
+			matched = true
+			if v.options.Direction == DirectionRequest && v.isReadOnly(s, loc, name) {
+				errs = append(errs, v.errorf(loc.child("properties", name), propPtr, "readOnly", "the property %q is read-only", name))
+			}
+			errs = append(errs, v.validate(&prop, loc.child("properties", name), value, propPtr, state)...)
+		}
+
+		for _, pattern := range mapKeysSorted(s.PatternProperties) {
+			re, err := v.regexp(pattern)
+			if err != nil {
+				errs = append(errs, v.errorf(loc.child("patternProperties"), propPtr, pattern, "invalid pattern %q: %v", pattern, err))
+
+				continue
+			}
+			if !re.MatchString(name) {
+				continue
+			}
+			matched = true
+			prop := s.PatternProperties[pattern]
+			errs = append(errs, v.validate(&prop, loc.child("patternProperties", pattern), value, propPtr, state)...)
+		}
+
+		switch {
+		case matched || s.AdditionalProperties == nil:
+		case s.AdditionalProperties.Schema != nil:
+			errs = append(errs, v.validate(s.AdditionalProperties.Schema, loc.child("additionalProperties"), value, propPtr, state)...)
+		case !s.AdditionalProperties.Allows:
+			errs = append(errs, v.errorf(loc, propPtr, "additionalProperties", "the property %q is not allowed", name))
+		}
+	}
+
+	for _, name := range mapKeysSorted(s.Dependencies) {
+		if _, ok := obj[name]; !ok {
+			continue
+		}
+
+		dependency := s.Dependencies[name]
+		if dependency.Schema != nil {
+			errs = append(errs, v.validate(dependency.Schema, loc.child("dependencies", name), obj, ptr, state)...)
+
+			continue
+		}
+		for _, required := range dependency.Property {
+			if _, ok := obj[required]; !ok {
+				errs = append(errs, v.errorf(loc.child("dependencies"), ptr, name, "the property %q is required by %q", required, name))
+			}
+		}
+	}
+
+	return errs
+}
+
+// isReadOnly tells if a property of a schema is read-only.
+func (v *SchemaValidator) isReadOnly(s *Schema, loc schemaLocation, name string) bool {
+	prop, ok := s.Properties[name]
+	if !ok {
+		return false
+	}
+
+	for seen := map[string]bool{}; prop.Ref.String() != ""; {
+		target := v.resolve(prop.Ref, loc.base)
+		if target.err != nil || seen[target.location] {
+			return false
+		}
+		seen[target.location] = true
+		prop, loc = *target.schema, schemaLocation{ptr: target.location, base: target.base}
+	}
+
+	return prop.ReadOnly
+}
+
+func (v *SchemaValidator) validateComposition(s *Schema, loc schemaLocation, value any, ptr string, state *validationState) SchemaValidationErrors {
+	var errs SchemaValidationErrors
+
+	for i := range s.AllOf {
+		errs = append(errs, v.validate(&s.AllOf[i], loc.child("allOf", strconv.Itoa(i)), value, ptr, state)...)
+	}
+
+	if len(s.AnyOf) > 0 {
+		var closest SchemaValidationErrors
+		valid := false
+		for i := range s.AnyOf {
+			branchErrs := v.validate(&s.AnyOf[i], loc.child("anyOf", strconv.Itoa(i)), value, ptr, state)
+			if len(branchErrs) == 0 {
+				valid = true
+
+				break
+			}
+			closest = closerBranch(closest, branchErrs, ptr)
+		}
+		if !valid {
+			err := v.errorf(loc, ptr, "anyOf", "the value must match at least one schema of anyOf")
+			err.Causes = closest
+			errs = append(errs, err)
+		}
+	}
+
+	if len(s.OneOf) > 0 {
+		var (
+			closest SchemaValidationErrors
+			matched []string
+		)
+		for i := range s.OneOf {
+			branchErrs := v.validate(&s.OneOf[i], loc.child("oneOf", strconv.Itoa(i)), value, ptr, state)
+			if len(branchErrs) == 0 {
+				matched = append(matched, strconv.Itoa(i))
+
+				continue
+			}
+			closest = closerBranch(closest, branchErrs, ptr)
+		}
+		switch len(matched) {
+		case 1:
+		case 0:
+			err := v.errorf(loc, ptr, "oneOf", "the value must match exactly one schema of oneOf, it matches none")
+			err.Causes = closest
+			errs = append(errs, err)
+		default:
+			errs = append(errs, v.errorf(loc, ptr, "oneOf", "the value must match exactly one schema of oneOf, it matches %s", strings.Join(matched, ", ")))
+		}
+	}
+
+	if s.Not != nil && len(v.validate(s.Not, loc.child("not"), value, ptr, state)) == 0 {
+		errs = append(errs, v.errorf(loc, ptr, "not", "the value must not match the schema of not"))
+	}
+
+	return errs
+}
+
+// closerBranch returns the violations of the branch of anyOf or oneOf which is the closest to match a value:
+// branches which accept the type of the value are preferred, then those with the fewest violations.
+func closerBranch(closest, candidate SchemaValidationErrors, ptr string) SchemaValidationErrors {
+	if closest == nil {
+		return candidate
+	}
+
+	rejectsType := func(errs SchemaValidationErrors) bool {
+		return slices.ContainsFunc(errs, func(e *SchemaValidationError) bool {
+			return e.Keyword == "type" && e.InstancePointer == ptr
+		})
+	}
+	if rejected, candidateRejected := rejectsType(closest), rejectsType(candidate); rejected != candidateRejected {
+		if rejected {
+			return candidate
+		}
+
+		return closest
+	}
+
+	if len(candidate) < len(closest) {
+		return candidate
+	}
+
+	return closest
+}
+
+// Leaves replaces the violations of anyOf and oneOf by the violations of their closest branch,
+// recursively. This yields the most specific violations, at the expense of the alternatives.
+func (e SchemaValidationErrors) Leaves() SchemaValidationErrors {
+	leaves := make(SchemaValidationErrors, 0, len(e))
+	for _, err := range e {
+		if len(err.Causes) == 0 {
+			leaves = append(leaves, err)
+
+			continue
+		}
+		leaves = append(leaves, err.Causes.Leaves()...)
+	}
+
+	return leaves
+}
+
+func (v *SchemaValidator) checkStringFormat(format, str string) bool {
+	if check, ok := v.options.Formats[format]; ok {
+		return check(str)
+	}
+
+	switch format {
+	case "date":
+		_, err := time.Parse(time.DateOnly, str)
+
+		return err == nil
+	case "date-time":
+		_, err := time.Parse(time.RFC3339Nano, strings.ToUpper(str))
+
+		return err == nil
+	case "email":
+		addr, err := mail.ParseAddress(str)
+
+		return err == nil && addr.Address == str
+	case "hostname":
+		return isHostname(str)
+	case "ipv4":
+		addr, err := netip.ParseAddr(str)
+
+		return err == nil && addr.Is4()
+	case "ipv6":
+		addr, err := netip.ParseAddr(str)
+
+		return err == nil && addr.Is6() && addr.Zone() == ""
+	case "uri":
+		u, err := url.Parse(str)
+
+		return err == nil && u.IsAbs()
+	case "uuid":
+		return isUUID(str)
+	case "byte":
+		_, err := base64.StdEncoding.DecodeString(str)
+
+		return err == nil
+	default:
+		return true
+	}
+}
+
+func checkNumberFormat(format string, r *big.Rat) bool {
+	switch format {
+	case "int32":
+		return r.IsInt() && r.Num().IsInt64() && r.Num().Int64() >= math.MinInt32 && r.Num().Int64() <= math.MaxInt32
+	case "int64":
+		return r.IsInt() && r.Num().IsInt64()
+	case "float":
+		f, _ := r.Float64()
+
+		return math.Abs(f) <= math.MaxFloat32
+	case "double":
+		f, _ := r.Float64()
+
+		return !math.IsInf(f, 0)
+	default:
+		return true
+	}
+}
+
+func isHostname(str string) bool {
+	str = strings.TrimSuffix(str, ".")
+	if str == "" || len(str) > 253 {
+		return false
+	}
+
+	for label := range strings.SplitSeq(str, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '-' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func isUUID(str string) bool {
+	if len(str) != 36 {
+		return false
+	}
+
+	for i, c := range str {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if (c < '0' || c > '9') && (c < 'a' || c > 'f') && (c < 'A' || c > 'F') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// toGenericJSON returns a value in the form produced by the JSON decoder.
+func toGenericJSON(value any) (any, error) {
+	switch value.(type) {
+	case nil, bool, string, float64, json.Number, map[string]any, []any,
+		int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32:
+		return value, nil
+	}
+
+	buf, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic any
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	return generic, nil
+}
+
+// jsonNumber returns the exact value of a JSON number.
+func jsonNumber(value any) (*big.Rat, bool) {
+	switch value.(type) {
+	case float64, float32, json.Number, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		r, ok := normalizeValue(value).(*big.Rat)
+
+		return r, ok
+	default:
+		return nil, false
+	}
+}
+
+// jsonType returns the JSON type of a generic JSON value.
+func jsonType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	}
+
+	if r, ok := jsonNumber(value); ok {
+		if r.IsInt() {
+			return "integer"
+		}
+
+		return "number"
+	}
+
+	return fmt.Sprintf("%T", value)
+}
+
+func jsonTypeMatches(t string, value any) bool {
+	switch actual := jsonType(value); t {
+	case "number":
+		return actual == "number" || actual == "integer"
+	case "file":
+		// file parameters and responses are not represented in JSON
+		return true
+	default:
+		return actual == t
+	}
+}
+
+// equalJSON compares generic JSON values.
+//
+// Unlike equalValues, null differs from empty objects or arrays.
+func equalJSON(a, b any) bool {
+	switch va := a.(type) {
+	case nil:
+		return b == nil
+	case bool, string:
+		return a == b
+	case map[string]any:
+		vb, ok := b.(map[string]any)
+		if !ok || len(va) != len(vb) {
+			return false
+		}
+		for key, value := range va {
+			other, ok := vb[key]
+			if !ok || !equalJSON(value, other) {
+				return false
+			}
+		}
+
+		return true
+	case []any:
+		vb, ok := b.([]any)
+		if !ok || len(va) != len(vb) {
+			return false
+		}
+		for i := range va {
+			if !equalJSON(va[i], vb[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	ra, ok := jsonNumber(a)
+	if !ok {
+		return false
+	}
+	rb, ok := jsonNumber(b)
+
+	return ok && ra.Cmp(rb) == 0
+}
+
+func jsonString(value any) string {
+	buf, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(buf)
+}
@@ -0,0 +1,324 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func mustSchema(t testing.TB, doc string) *Schema {
+	t.Helper()
+
+	var s Schema
+	require.NoError(t, json.Unmarshal([]byte(doc), &s))
+
+	return &s
+}
+
+func mustJSON(t testing.TB, doc string) any {
+	t.Helper()
+
+	var value any
+	require.NoError(t, json.Unmarshal([]byte(doc), &value))
+
+	return value
+}
+
+// schemaViolations returns the violations of a value, as "instance pointer schema pointer" strings.
+func schemaViolations(t testing.TB, schema *Schema, value any, options *SchemaValidationOptions) []string {
+	t.Helper()
+
+	err := ValidateValue(schema, value, options)
+	if err == nil {
+		return nil
+	}
+	require.ErrorIs(t, err, ErrValidation)
+
+	var errs SchemaValidationErrors
+	require.TrueT(t, errors.As(err, &errs))
+
+	violations := make([]string, 0, len(errs))
+	for _, e := range errs {
+		violations = append(violations, e.InstancePointer+" "+e.SchemaPointer)
+	}
+
+	return violations
+}
+
+func TestValidateValue_Keywords(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		schema     string
+		valid      []string
+		violations map[string][]string
+	}{
+		{
+			name:   "type",
+			schema: `{"type":["integer","null"]}`,
+			valid:  []string{`1`, `1.0`, `null`},
+			violations: map[string][]string{
+				`1.5`:   {" #/type"},
+				`"one"`: {" #/type"},
+			},
+		},
+		{
+			name:   "numbers",
+			schema: `{"type":"number","minimum":0,"exclusiveMinimum":true,"maximum":10,"multipleOf":0.1}`,
+			valid:  []string{`0.1`, `10`, `3.3`},
+			violations: map[string][]string{
+				`0`:    {" #/minimum"},
+				`10.5`: {" #/maximum"},
+				`1.25`: {" #/multipleOf"},
+			},
+		},
+		{
+			name:   "strings",
+			schema: `{"type":"string","minLength":2,"maxLength":3,"pattern":"^[a-zé]+$"}`,
+			valid:  []string{`"ab"`, `"été"`},
+			violations: map[string][]string{
+				`"a"`:    {" #/minLength"},
+				`"abcd"`: {" #/maxLength"},
+				`"A1"`:   {" #/pattern"},
+			},
+		},
+		{
+			name:   "enum",
+			schema: `{"enum":["a",1,{"b":[]},null]}`,
+			valid:  []string{`"a"`, `1.0`, `{"b":[]}`, `null`},
+			violations: map[string][]string{
+				`{"b":null}`: {" #/enum"},
+				`{}`:         {" #/enum"},
+			},
+		},
+		{
+			name:   "arrays",
+			schema: `{"type":"array","minItems":1,"maxItems":3,"uniqueItems":true,"items":[{"type":"string"}],"additionalItems":{"type":"integer"}}`,
+			valid:  []string{`["a"]`, `["a",1,2]`},
+			violations: map[string][]string{
+				`[]`:          {" #/minItems"},
+				`["a",1,2,3]`: {" #/maxItems"},
+				`["a",1,1]`:   {"/2 #/uniqueItems"},
+				`[1,"b"]`:     {"/0 #/items/0/type", "/1 #/additionalItems/type"},
+			},
+		},
+		{
+			name:   "array of items",
+			schema: `{"items":{"type":"integer"}}`,
+			valid:  []string{`[]`, `[1,2]`, `"not an array"`},
+			violations: map[string][]string{
+				`[1,"a",true]`: {"/1 #/items/type", "/2 #/items/type"},
+			},
+		},
+		{
+			name: "objects",
+			schema: `{
+  "type": "object",
+  "required": ["id"],
+  "minProperties": 1,
+  "maxProperties": 3,
+  "properties": { "id": { "type": "integer" } },
+  "patternProperties": { "^x-": { "type": "string" } },
+  "additionalProperties": false,
+  "dependencies": { "a": ["id"], "x-b": { "required": ["x-c"] } }
+}`,
+			valid: []string{`{"id":1}`, `{"id":1,"x-a":"b"}`},
+			violations: map[string][]string{
+				`{}`:                             {" #/minProperties", " #/required"},
+				`{"id":1,"other":true}`:          {"/other #/additionalProperties"},
+				`{"id":1,"x-a":1}`:               {"/x-a #/patternProperties/^x-/type"},
+				`{"id":1,"x-b":"b"}`:             {" #/dependencies/x-b/required"},
+				`{"id":1,"a":1,"b":2,"c":3}`:     {" #/maxProperties", "/a #/additionalProperties", "/b #/additionalProperties", "/c #/additionalProperties"},
+				`{"id":"1","x-b":"b","x-c":"c"}`: {"/id #/properties/id/type"},
+			},
+		},
+		{
+			name:   "composition",
+			schema: `{"allOf":[{"minimum":0}],"anyOf":[{"type":"integer"},{"maximum":1}],"oneOf":[{"maximum":10},{"minimum":5}],"not":{"enum":[3]}}`,
+			valid:  []string{`0.5`, `2`, `11`},
+			violations: map[string][]string{
+				`-1`:  {" #/allOf/0/minimum"},
+				`1.5`: {" #/anyOf"},
+				`7`:   {" #/oneOf"},
+				`3`:   {" #/not"},
+			},
+		},
+		{
+			name:   "nullable",
+			schema: `{"type":"object","x-nullable":true,"required":["a"]}`,
+			valid:  []string{`null`, `{"a":1}`},
+			violations: map[string][]string{
+				`{}`: {" #/required"},
+			},
+		},
+		{
+			name:   "formats",
+			schema: `{"properties":{"date":{"format":"date"},"dt":{"format":"date-time"},"email":{"format":"email"},"host":{"format":"hostname"},"ip":{"format":"ipv4"},"ip6":{"format":"ipv6"},"uri":{"format":"uri"},"uuid":{"format":"uuid"},"byte":{"format":"byte"},"i32":{"format":"int32"},"custom":{"format":"custom"}}}`,
+			valid: []string{
+				`{"date":"2024-02-29","dt":"2024-02-29T12:00:00.5z","email":"a@example.com","host":"api.example.com","ip":"10.0.0.1","ip6":"::1","uri":"https://example.com/a","uuid":"0f8fad5b-d9cb-469f-a165-70867728950e","byte":"aGVsbG8=","i32":2147483647,"custom":"anything"}`,
+			},
+			violations: map[string][]string{
+				`{"date":"2023-02-29","dt":"2024-02-29 12:00:00","email":"A <a@example.com>","host":"-api.example.com","ip":"10.0.0.01","ip6":"10.0.0.1","uri":"/a","uuid":"0f8fad5b","byte":"@","i32":2147483648}`: {
+					"/byte #/properties/byte/format",
+					"/date #/properties/date/format",
+					"/dt #/properties/dt/format",
+					"/email #/properties/email/format",
+					"/host #/properties/host/format",
+					"/i32 #/properties/i32/format",
+					"/ip #/properties/ip/format",
+					"/ip6 #/properties/ip6/format",
+					"/uri #/properties/uri/format",
+					"/uuid #/properties/uuid/format",
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			schema := mustSchema(t, tc.schema)
+			for _, doc := range tc.valid {
+				assert.Nilf(t, schemaViolations(t, schema, mustJSON(t, doc), nil), "expected %s to be valid", doc)
+			}
+			for doc, expected := range tc.violations {
+				assert.Equalf(t, expected, schemaViolations(t, schema, mustJSON(t, doc), nil), "unexpected violations for %s", doc)
+			}
+		})
+	}
+}
+
+const validatorSpec = `{
+  "swagger": "2.0",
+  "info": { "title": "pets", "version": "1.0" },
+  "paths": {},
+  "definitions": {
+    "Pet": {
+      "type": "object",
+      "discriminator": "kind",
+      "required": ["id", "kind", "name"],
+      "properties": {
+        "id": { "$ref": "#/definitions/ID" },
+        "kind": { "type": "string" },
+        "name": { "type": "string", "minLength": 1 }
+      }
+    },
+    "ID": { "type": "integer", "readOnly": true },
+    "Cat": {
+      "allOf": [
+        { "$ref": "#/definitions/Pet" },
+        { "type": "object", "required": ["lives"], "properties": { "lives": { "type": "integer", "maximum": 9 } } }
+      ]
+    },
+    "Rock": { "type": "object" },
+    "Tree": {
+      "type": "object",
+      "properties": { "children": { "type": "array", "items": { "$ref": "#/definitions/Tree" } } }
+    }
+  }
+}`
+
+func TestValidateValue_Swagger(t *testing.T) {
+	sp := mustSpec(t, validatorSpec)
+	pet := RefSchema("#/definitions/Pet")
+	options := &SchemaValidationOptions{Root: sp}
+
+	t.Run("$ref's should be resolved", func(t *testing.T) {
+		assert.Equal(t,
+			[]string{"/id #/definitions/ID/type", "/name #/definitions/Pet/properties/name/minLength"},
+			schemaViolations(t, pet, mustJSON(t, `{"id":"1","kind":"Pet","name":""}`), options),
+		)
+
+		tree := RefSchema("#/definitions/Tree")
+		assert.Nil(t, schemaViolations(t, tree, mustJSON(t, `{"children":[{"children":[{}]}]}`), options))
+		assert.Equal(t,
+			[]string{"/children/0/children #/definitions/Tree/properties/children/type"},
+			schemaViolations(t, tree, mustJSON(t, `{"children":[{"children":{}}]}`), options),
+		)
+	})
+
+	t.Run("discriminators should select the type", func(t *testing.T) {
+		assert.Nil(t, schemaViolations(t, pet, mustJSON(t, `{"id":1,"kind":"Cat","name":"tom","lives":9}`), options))
+		assert.Equal(t,
+			[]string{"/lives #/definitions/Cat/allOf/1/properties/lives/maximum"},
+			schemaViolations(t, pet, mustJSON(t, `{"id":1,"kind":"Cat","name":"tom","lives":10}`), options),
+		)
+		assert.Equal(t,
+			[]string{" #/definitions/Pet/required", " #/definitions/Cat/allOf/1/required"},
+			schemaViolations(t, pet, mustJSON(t, `{"id":1,"kind":"Cat"}`), options),
+		)
+		assert.Equal(t,
+			[]string{"/kind #/definitions/Pet/discriminator"},
+			schemaViolations(t, pet, mustJSON(t, `{"id":1,"kind":"Rock","name":"rock"}`), options),
+		)
+		assert.Equal(t,
+			[]string{"/kind #/definitions/Pet/discriminator"},
+			schemaViolations(t, pet, mustJSON(t, `{"id":1,"kind":"Dog","name":"rex"}`), options),
+		)
+	})
+
+	t.Run("readOnly properties should depend on the direction", func(t *testing.T) {
+		request := &SchemaValidationOptions{Root: sp, Direction: DirectionRequest}
+		response := &SchemaValidationOptions{Root: sp, Direction: DirectionResponse}
+
+		assert.Nil(t, schemaViolations(t, pet, mustJSON(t, `{"kind":"Pet","name":"rex"}`), request))
+		assert.Equal(t,
+			[]string{"/id #/definitions/Pet/properties/id/readOnly"},
+			schemaViolations(t, pet, mustJSON(t, `{"id":1,"kind":"Pet","name":"rex"}`), request),
+		)
+		assert.Equal(t,
+			[]string{" #/definitions/Pet/required"},
+			schemaViolations(t, pet, mustJSON(t, `{"kind":"Pet","name":"rex"}`), response),
+		)
+	})
+
+	t.Run("typed values should be validated as JSON", func(t *testing.T) {
+		type cat struct {
+			ID    int    `json:"id"`
+			Kind  string `json:"kind"`
+			Name  string `json:"name"`
+			Lives int    `json:"lives"`
+		}
+		assert.Nil(t, schemaViolations(t, pet, cat{ID: 1, Kind: "Cat", Name: "tom", Lives: 3}, options))
+		assert.Equal(t,
+			[]string{"/lives #/definitions/Cat/allOf/1/properties/lives/maximum"},
+			schemaViolations(t, pet, cat{ID: 1, Kind: "Cat", Name: "tom", Lives: 12}, options),
+		)
+	})
+
+	t.Run("unresolved $ref's should be reported", func(t *testing.T) {
+		assert.Equal(t,
+			[]string{" #/$ref"},
+			schemaViolations(t, RefSchema("#/definitions/Missing"), mustJSON(t, `{}`), options),
+		)
+	})
+}
+
+func TestValidateValue_Options(t *testing.T) {
+	t.Run("custom formats should be checked", func(t *testing.T) {
+		schema := StrFmtProperty("color")
+		options := &SchemaValidationOptions{Formats: map[string]func(string) bool{
+			"color": func(s string) bool { return strings.HasPrefix(s, "#") },
+		}}
+		require.NoError(t, ValidateValue(schema, "#fff", options))
+		require.ErrorIs(t, ValidateValue(schema, "white", options), ErrValidation)
+	})
+
+	t.Run("the draft 4 meta-schema should validate schemas", func(t *testing.T) {
+		meta := MustLoadJSONSchemaDraft04()
+		validator := NewSchemaValidator(meta, nil)
+
+		require.NoError(t, validator.Validate(mustJSON(t, validatorSpec).(map[string]any)["definitions"].(map[string]any)["Pet"]))
+		err := validator.Validate(mustJSON(t, `{"type":"color","minLength":-1,"required":[]}`))
+		var errs SchemaValidationErrors
+		require.TrueT(t, errors.As(err, &errs))
+		pointers := make([]string, 0, len(errs))
+		for _, e := range errs {
+			pointers = append(pointers, e.InstancePointer)
+		}
+		assert.Equal(t, []string{"/minLength", "/required", "/type"}, pointers)
+	})
+}
@@ -107,6 +107,13 @@ func (s SecurityScheme) JSONLookup(token string) (any, error) {
 	return r, err
 }
 
+// JSONSet sets a value by the json property name.
+//
+// Missing containers, such as extensions, are created.
+func (s *SecurityScheme) JSONSet(token string, value any) error {
+	return jsonSetToken(s, token, value)
+}
+
 // MarshalJSON marshal this to JSON.
 func (s SecurityScheme) MarshalJSON() ([]byte, error) {
 	var (
@@ -8,8 +8,10 @@ import (
 	"encoding/gob"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/go-openapi/jsonpointer"
 	"github.com/go-openapi/swag/jsonutils"
@@ -34,6 +36,215 @@ func (s Swagger) JSONLookup(token string) (any, error) {
 	return r, err
 }
 
+// JSONSet sets a value by the json property name.
+//
+// Missing containers, such as extensions, are created.
+func (s *Swagger) JSONSet(token string, value any) error {
+	return jsonSetToken(s, token, value)
+}
+
+// NewSwagger creates a new Swagger 2.0 document, with no paths.
+func NewSwagger() *Swagger {
+	sp := new(Swagger)
+	sp.Swagger = "2.0"
+	sp.Paths = new(Paths)
+
+	return sp
+}
+
+// WithInfo sets the info for this document.
+func (s *Swagger) WithInfo(info *Info) *Swagger {
+	s.Info = info
+	return s
+}
+
+// WithHost sets the host serving the API.
+func (s *Swagger) WithHost(host string) *Swagger {
+	s.Host = host
+	return s
+}
+
+// WithBasePath sets the base path of the API, relative to the host.
+func (s *Swagger) WithBasePath(basePath string) *Swagger {
+	s.BasePath = basePath
+	return s
+}
+
+// WithSchemes adds transfer protocols for the API.
+func (s *Swagger) WithSchemes(schemes ...string) *Swagger {
+	s.Schemes = append(s.Schemes, schemes...)
+	return s
+}
+
+// WithConsumes adds media types for incoming body values.
+func (s *Swagger) WithConsumes(mediaTypes ...string) *Swagger {
+	s.Consumes = append(s.Consumes, mediaTypes...)
+	return s
+}
+
+// WithProduces adds media types for outgoing body values.
+func (s *Swagger) WithProduces(mediaTypes ...string) *Swagger {
+	s.Produces = append(s.Produces, mediaTypes...)
+	return s
+}
+
+// WithExternalDocs sets/removes the external docs for this document.
+// When you specify empty strings both the description and url will be removed.
+func (s *Swagger) WithExternalDocs(description, url string) *Swagger {
+	if description == "" && url == "" {
+		s.ExternalDocs = nil
+		return s
+	}
+
+	if s.ExternalDocs == nil {
+		s.ExternalDocs = &ExternalDocumentation{}
+	}
+	s.ExternalDocs.Description = description
+	s.ExternalDocs.URL = url
+	return s
+}
+
+// SecuredWith adds a security requirement for all the operations of this document.
+func (s *Swagger) SecuredWith(name string, scopes ...string) *Swagger {
+	if scopes == nil {
+		// a requirement without scopes is an empty array, not null
+		scopes = []string{}
+	}
+	s.Security = append(s.Security, map[string][]string{name: scopes})
+	return s
+}
+
+// AddPath adds an operation for a path and a method.
+//
+// It fails when the path is not a valid path template, when it is equivalent to another path
+// of the document (e.g. "/pets/{id}" and "/pets/{petId}"), when the method is not supported
+// by path items, or when an operation is already defined for this path and method.
+func (s *Swagger) AddPath(path, method string, operation *Operation) error {
+	if operation == nil {
+		return fmt.Errorf("cannot add a nil operation for %s %s: %w", strings.ToUpper(method), path, ErrSpec)
+	}
+	template, err := ParsePathTemplate(path)
+	if err != nil {
+		return err
+	}
+	if err := s.checkEquivalentPath(template); err != nil {
+		return err
+	}
+
+	if s.Paths == nil {
+		s.Paths = new(Paths)
+	}
+	if s.Paths.Paths == nil {
+		s.Paths.Paths = make(map[string]PathItem)
+	}
+
+	item := s.Paths.Paths[path]
+	field := item.operationField(method)
+	switch {
+	case field == nil:
+		return fmt.Errorf("unsupported method %q for path %s: %w", method, path, ErrSpec)
+	case *field != nil:
+		return fmt.Errorf("an operation is already defined for %s %s: %w", strings.ToUpper(method), path, ErrSpec)
+	}
+
+	*field = operation
+	s.Paths.Paths[path] = item
+
+	return nil
+}
+
+// checkEquivalentPath fails when another path of the document is equivalent to a path template.
+func (s *Swagger) checkEquivalentPath(template *PathTemplate) error {
+	if s.Paths == nil {
+		return nil
+	}
+	if _, exists := s.Paths.Paths[template.String()]; exists {
+		return nil
+	}
+
+	normalized := template.normalized()
+	for _, path := range mapKeysSorted(s.Paths.Paths) {
+		other, err := ParsePathTemplate(path)
+		if err != nil {
+			continue
+		}
+		if other.normalized() == normalized {
+			return fmt.Errorf("path %q is equivalent to %q: %w", template, path, ErrSpec)
+		}
+	}
+
+	return nil
+}
+
+// AddDefinition adds a schema to the definitions of this document,
+// replacing the definition with that name if it already exists.
+func (s *Swagger) AddDefinition(name string, schema *Schema) *Swagger {
+	if schema == nil {
+		return s
+	}
+
+	if s.Definitions == nil {
+		s.Definitions = make(Definitions)
+	}
+	s.Definitions[name] = *schema
+	return s
+}
+
+// AddParameter adds a parameter to the parameters of this document,
+// replacing the parameter with that name if it already exists.
+func (s *Swagger) AddParameter(name string, param *Parameter) *Swagger {
+	if param == nil {
+		return s
+	}
+
+	if s.Parameters == nil {
+		s.Parameters = make(map[string]Parameter)
+	}
+	s.Parameters[name] = *param
+	return s
+}
+
+// AddResponse adds a response to the responses of this document,
+// replacing the response with that name if it already exists.
+func (s *Swagger) AddResponse(name string, response *Response) *Swagger {
+	if response == nil {
+		return s
+	}
+
+	if s.Responses == nil {
+		s.Responses = make(map[string]Response)
+	}
+	s.Responses[name] = *response
+	return s
+}
+
+// AddSecurityDefinition adds a security scheme to the security definitions of this document,
+// replacing the security scheme with that name if it already exists.
+func (s *Swagger) AddSecurityDefinition(name string, scheme *SecurityScheme) *Swagger {
+	if scheme == nil {
+		return s
+	}
+
+	if s.SecurityDefinitions == nil {
+		s.SecurityDefinitions = make(SecurityDefinitions)
+	}
+	s.SecurityDefinitions[name] = scheme
+	return s
+}
+
+// AddTag adds a tag to this document, replacing the tag with that name if it already exists.
+func (s *Swagger) AddTag(tag Tag) *Swagger {
+	for i, t := range s.Tags {
+		if t.Name == tag.Name {
+			s.Tags[i] = tag
+			return s
+		}
+	}
+
+	s.Tags = append(s.Tags, tag)
+	return s
+}
+
 // MarshalJSON marshals this swagger structure to json.
 func (s Swagger) MarshalJSON() ([]byte, error) {
 	b1, err := json.Marshal(s.SwaggerProps)
@@ -60,6 +271,75 @@ func (s *Swagger) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// UnmarshalOptions configures the unmarshaling of a specification.
+//
+// The zero value unmarshals like [json.Unmarshal].
+type UnmarshalOptions struct {
+	// ResponseCodeRanges keeps response code ranges, such as "2XX", in the responses of operations.
+	//
+	// Ranges are not part of the Swagger 2.0 specification, but are commonly carried over from OpenAPI 3.
+	// When disabled, such keys are dropped.
+	ResponseCodeRanges bool
+
+	// StrictResponses makes unmarshaling fail on response keys which are neither "default", a status code,
+	// a vendor extension or, when ResponseCodeRanges is enabled, a response code range.
+	//
+	// When disabled, unknown keys are dropped.
+	StrictResponses bool
+}
+
+// UnmarshalSwagger unmarshals a specification from JSON, with options.
+func UnmarshalSwagger(data []byte, options *UnmarshalOptions) (*Swagger, error) {
+	var sw Swagger
+	if err := json.Unmarshal(data, &sw); err != nil {
+		return nil, err
+	}
+	if options == nil || *options == (UnmarshalOptions{}) || sw.Paths == nil {
+		return &sw, nil
+	}
+
+	// responses are unmarshaled again with the options, since json.Unmarshal cannot pass them down
+	var raw struct {
+		Paths map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	for path, rawItem := range raw.Paths {
+		item, isPath := sw.Paths.Paths[path]
+		if isExtensionKey(path) || !isPath {
+			continue
+		}
+
+		var rawOperations map[string]json.RawMessage
+		if err := json.Unmarshal(rawItem, &rawOperations); err != nil {
+			return nil, err
+		}
+
+		for _, op := range item.operations() {
+			if op.operation.Responses == nil {
+				continue
+			}
+
+			var rawOperation struct {
+				Responses json.RawMessage `json:"responses"`
+			}
+			if err := json.Unmarshal(rawOperations[op.method], &rawOperation); err != nil {
+				return nil, err
+			}
+
+			var responses Responses
+			if err := responses.UnmarshalJSONWithOptions(rawOperation.Responses, options); err != nil {
+				return nil, fmt.Errorf("%s: %w", joinPointer("/paths", path, op.method, "responses"), err)
+			}
+			*op.operation.Responses = responses
+		}
+	}
+
+	return &sw, nil
+}
+
 // GobEncode provides a safe gob encoder for Swagger, including extensions.
 func (s Swagger) GobEncode() ([]byte, error) {
 	var b bytes.Buffer
@@ -305,6 +585,40 @@ func (s *SchemaOrStringArray) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Operations iterates over the operations of this spec, by path, then by method.
+//
+// See [PathItem.Operations] for the order of methods.
+func (s *Swagger) Operations() iter.Seq[IndexedOperation] {
+	return func(yield func(IndexedOperation) bool) {
+		if s.Paths == nil {
+			return
+		}
+
+		for path, item := range mapEntriesSorted(s.Paths.Paths) {
+			for method, op := range item.Operations() {
+				if !yield(IndexedOperation{Path: path, Method: method, Operation: op}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// SortedDefinitions iterates over the definitions of this spec, by name.
+func (s *Swagger) SortedDefinitions() iter.Seq2[string, Schema] {
+	return mapEntriesSorted(s.Definitions)
+}
+
+// SortedParameters iterates over the parameters defined by this spec, by name.
+func (s *Swagger) SortedParameters() iter.Seq2[string, Parameter] {
+	return mapEntriesSorted(s.Parameters)
+}
+
+// SortedResponses iterates over the responses defined by this spec, by name.
+func (s *Swagger) SortedResponses() iter.Seq2[string, Response] {
+	return mapEntriesSorted(s.Responses)
+}
+
 // Definitions contains the models explicitly defined in this spec
 // An object to hold data types that can be consumed and produced by operations.
 // These data types can be primitives, arrays or models.
@@ -312,6 +626,11 @@ func (s *SchemaOrStringArray) UnmarshalJSON(data []byte) error {
 // For more information: http://goo.gl/8us55a#definitionsObject
 type Definitions map[string]Schema
 
+// JSONSet sets a definition by name.
+func (d Definitions) JSONSet(token string, value any) error {
+	return setMapEntry(d, token, value)
+}
+
 // SecurityDefinitions a declaration of the security schemes available to be used in the specification.
 // This does not enforce the security schemes on the operations and only serves to provide
 // the relevant details for each scheme.
@@ -222,6 +222,48 @@ func TestSwaggerSpec_Deserialize(t *testing.T) {
 	assert.Equal(t, actual, spec)
 }
 
+func TestUnmarshalSwagger(t *testing.T) {
+	const doc = `{
+  "swagger": "2.0",
+  "paths": {
+    "x-owner": "team-a",
+    "/pets": {
+      "x-internal": true,
+      "parameters": [ { "name": "limit", "in": "query", "type": "integer" } ],
+      "get": { "responses": { "200": { "description": "ok" }, "4XX": { "description": "client error" } } },
+      "post": { "responses": { "201": { "description": "created" }, "ok": { "description": "unknown" } } }
+    }
+  }
+}`
+
+	t.Run("options should be optional", func(t *testing.T) {
+		sp, err := UnmarshalSwagger([]byte(doc), nil)
+		require.NoError(t, err)
+
+		var expected Swagger
+		require.NoError(t, json.Unmarshal([]byte(doc), &expected))
+		assert.Equal(t, &expected, sp)
+		assert.Empty(t, sp.Paths.Paths["/pets"].Get.Responses.StatusCodeRanges)
+	})
+
+	t.Run("response code ranges should be kept", func(t *testing.T) {
+		sp, err := UnmarshalSwagger([]byte(doc), &UnmarshalOptions{ResponseCodeRanges: true})
+		require.NoError(t, err)
+
+		responses := sp.Paths.Paths["/pets"].Get.Responses
+		assert.EqualT(t, "ok", responses.StatusCodeResponses[200].Description)
+		assert.EqualT(t, "client error", responses.StatusCodeRanges["4XX"].Description)
+		require.Len(t, sp.Paths.Paths["/pets"].Parameters, 1)
+		assert.Equal(t, "team-a", sp.Paths.Extensions["x-owner"])
+	})
+
+	t.Run("unknown response keys should fail in strict mode", func(t *testing.T) {
+		_, err := UnmarshalSwagger([]byte(doc), &UnmarshalOptions{ResponseCodeRanges: true, StrictResponses: true})
+		require.ErrorIs(t, err, ErrSpec)
+		assert.StringContainsT(t, err.Error(), "/paths/~1pets/post/responses")
+	})
+}
+
 func TestVendorExtensionStringSlice(t *testing.T) {
 	var actual Swagger
 	require.NoError(t, json.Unmarshal(specJSON, &actual))
@@ -298,3 +340,122 @@ func doTestSwaggerGobEncoding(t *testing.T, fixture []byte) {
 
 	doTestAnyGobEncoding(t, &src, &dst)
 }
+
+func TestSwagger_Iterators(t *testing.T) {
+	sp := mustSpec(t, `{
+  "swagger": "2.0",
+  "paths": {
+    "/stores": { "get": { "operationId": "listStores" } },
+    "/pets": { "post": { "operationId": "createPet" }, "get": { "operationId": "listPets" } }
+  },
+  "definitions": { "Pet": { "type": "object" }, "Error": { "type": "string" } },
+  "parameters": { "limit": { "name": "limit", "in": "query" }, "id": { "name": "id", "in": "path" } },
+  "responses": { "NotFound": { "description": "not found" }, "Error": { "description": "error" } }
+}`)
+
+	var operations []string
+	for op := range sp.Operations() {
+		operations = append(operations, op.Method+" "+op.Path+" "+op.Operation.ID)
+	}
+	assert.Equal(t, []string{"get /pets listPets", "post /pets createPet", "get /stores listStores"}, operations)
+
+	var names []string
+	for name, schema := range sp.SortedDefinitions() {
+		names = append(names, name+":"+schema.Type[0])
+	}
+	for name, param := range sp.SortedParameters() {
+		names = append(names, name+":"+param.In)
+	}
+	for name, response := range sp.SortedResponses() {
+		names = append(names, name+":"+response.Description)
+	}
+	assert.Equal(t, []string{
+		"Error:string", "Pet:object",
+		"id:path", "limit:query",
+		"Error:error", "NotFound:not found",
+	}, names)
+
+	for range sp.Operations() {
+		break
+	}
+	for range (&Swagger{}).Operations() {
+		t.Fail()
+	}
+}
+
+func TestSwaggerBuilder(t *testing.T) {
+	sp := NewSwagger().
+		WithInfo(&Info{InfoProps: InfoProps{Title: "pets", Version: "1.0"}}).
+		WithHost("api.example.com").
+		WithBasePath("/v1").
+		WithSchemes("https").
+		WithConsumes("application/json").
+		WithProduces("application/json").
+		WithExternalDocs("docs", "https://example.com/docs").
+		SecuredWith("key").
+		AddDefinition("Pet", new(Schema).Typed("object", "")).
+		AddParameter("limit", QueryParam("limit").Typed("integer", "int32")).
+		AddResponse("NotFound", NewResponse().WithDescription("not found")).
+		AddSecurityDefinition("key", APIKeyAuth("X-Key", "header")).
+		AddTag(NewTag("pets", "about pets", nil)).
+		AddTag(NewTag("pets", "all about pets", nil))
+
+	require.NoError(t, sp.AddPath("/pets", "get", NewOperation("listPets").RespondsWith(200, NewResponse().WithDescription("ok"))))
+	require.NoError(t, sp.AddPath("/pets", "POST", NewOperation("createPet").WithDefaultResponse(NewResponse().WithDescription("error"))))
+
+	require.NoError(t, sp.Validate())
+	assert.JSONMarshalAsT(t, `{
+  "swagger": "2.0",
+  "info": { "title": "pets", "version": "1.0" },
+  "host": "api.example.com",
+  "basePath": "/v1",
+  "schemes": [ "https" ],
+  "consumes": [ "application/json" ],
+  "produces": [ "application/json" ],
+  "externalDocs": { "description": "docs", "url": "https://example.com/docs" },
+  "security": [ { "key": [] } ],
+  "securityDefinitions": { "key": { "type": "apiKey", "name": "X-Key", "in": "header" } },
+  "tags": [ { "name": "pets", "description": "all about pets" } ],
+  "definitions": { "Pet": { "type": "object" } },
+  "parameters": { "limit": { "name": "limit", "in": "query", "type": "integer", "format": "int32" } },
+  "responses": { "NotFound": { "description": "not found" } },
+  "paths": {
+    "/pets": {
+      "get": { "operationId": "listPets", "responses": { "200": { "description": "ok" } } },
+      "post": { "operationId": "createPet", "responses": { "default": { "description": "error" } } }
+    }
+  }
+}`, sp)
+
+	t.Run("invalid paths should be rejected", func(t *testing.T) {
+		require.ErrorIs(t, sp.AddPath("/pets", "GET", NewOperation("other")), ErrSpec)
+		require.ErrorIs(t, sp.AddPath("/pets", "trace", NewOperation("trace")), ErrSpec)
+		require.ErrorIs(t, sp.AddPath("pets", "get", NewOperation("relative")), ErrSpec)
+		require.ErrorIs(t, sp.AddPath("/stores", "get", nil), ErrSpec)
+		assert.EqualT(t, "listPets", sp.Paths.Paths["/pets"].Get.ID)
+		assert.Len(t, sp.Paths.Paths, 1)
+	})
+
+	t.Run("equivalent paths should be rejected", func(t *testing.T) {
+		sp := &Swagger{}
+		require.NoError(t, sp.AddPath("/pets/{id}", "get", NewOperation("getPet")))
+		require.NoError(t, sp.AddPath("/pets/{id}", "delete", NewOperation("deletePet")))
+		require.NoError(t, sp.AddPath("/pets/{id}.json", "get", NewOperation("getPetJSON")))
+
+		err := sp.AddPath("/pets/{petId}", "put", NewOperation("updatePet"))
+		require.ErrorIs(t, err, ErrSpec)
+		assert.StringContainsT(t, err.Error(), `path "/pets/{petId}" is equivalent to "/pets/{id}"`)
+		require.ErrorIs(t, sp.AddPath("/pets/{petId}.json", "put", NewOperation("updatePetJSON")), ErrSpec)
+		assert.Len(t, sp.Paths.Paths, 2)
+	})
+
+	t.Run("nil maps should be created on demand", func(t *testing.T) {
+		sp := &Swagger{}
+		require.NoError(t, sp.AddPath("/pets", "get", NewOperation("listPets")))
+		sp.AddDefinition("Pet", nil).AddParameter("limit", nil).AddResponse("NotFound", nil).AddSecurityDefinition("key", nil)
+		assert.Nil(t, sp.Definitions)
+		assert.Nil(t, sp.SecurityDefinitions)
+		assert.NotNil(t, sp.Paths.Paths["/pets"].Get)
+		assert.Nil(t, sp.WithExternalDocs("", "").ExternalDocs)
+	})
+}
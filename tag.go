@@ -42,6 +42,13 @@ func (t Tag) JSONLookup(token string) (any, error) {
 	return r, err
 }
 
+// JSONSet sets a value by the json property name.
+//
+// Missing containers, such as extensions, are created.
+func (t *Tag) JSONSet(token string, value any) error {
+	return jsonSetToken(t, token, value)
+}
+
 // MarshalJSON marshal this to JSON.
 func (t Tag) MarshalJSON() ([]byte, error) {
 	b1, err := json.Marshal(t.TagProps)
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"fmt"
+)
+
+// Action tells [Transform] what to do with a node.
+type Action int
+
+// Actions returned by a [TransformFunc].
+const (
+	// Continue keeps the node, with the changes made to it in place, and transforms its children
+	Continue Action = iota
+
+	// SkipChildren keeps the node, with the changes made to it in place, but does not transform its children
+	SkipChildren
+
+	// Replace replaces the node with the returned value. The replacement is not transformed.
+	//
+	// The value must be of the same type as the node, either as a value or as a pointer.
+	// Entries of maps may be renamed by returning an [Entry]: when its Value is nil, the node is only renamed.
+	Replace
+
+	// Delete removes the node from its container
+	Delete
+
+	// InsertAfter keeps the node and inserts the returned value after it. The inserted value is not transformed.
+	//
+	// In arrays, the value must be of the same type as the node. In maps, it must be an [Entry] with a new key.
+	// Nodes held by other fields do not support insertions.
+	InsertAfter
+)
+
+// Entry is a key and a value in a map-backed container, such as definitions, paths, responses or properties.
+//
+// It is used with [Transform] to rename or insert map entries.
+type Entry struct {
+	Key   string
+	Value any
+}
+
+// TransformFunc is called by [Transform] for each node of a specification.
+//
+// It receives the JSON pointer to the node and a pointer to the node (e.g. *Operation, *Schema).
+// The node may be modified in place. It returns a value, used by the Replace and InsertAfter actions,
+// and an action.
+type TransformFunc func(ptr string, node any) (any, Action)
+
+// Transform rewrites a specification, by calling a function on each of its nodes.
+//
+// Nodes are visited like with [Walk], without following $ref's. Pointers are those of the specification
+// before it is transformed: removals and insertions in arrays are applied once all the elements of an
+// array have been transformed, and renamed map entries are reported with their original key.
+//
+// Map-backed containers (definitions, paths, responses, properties, ...) are safely updated during the
+// traversal: entries are transformed in the order of their keys, and inserted entries are not visited.
+//
+// The transformation is atomic: if it fails, the specification is left unchanged.
+func Transform(spec *Swagger, fn TransformFunc) error {
+	if spec == nil {
+		return nil
+	}
+
+	transformed := spec.DeepCopy()
+	value, action := fn("", transformed)
+	switch action {
+	case Delete, InsertAfter:
+		return fmt.Errorf("cannot delete the root of a spec or insert after it: %w", ErrSpec)
+	case Replace:
+		replacement, err := transformValue[Swagger](value)
+		if err != nil {
+			return err
+		}
+		*spec = *replacement
+
+		return nil
+	case SkipChildren:
+	case Continue:
+		if err := transformChildren("", transformed, fn); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown action %d: %w", action, ErrSpec)
+	}
+
+	*spec = *transformed
+
+	return nil
+}
+
+func transformChildren(ptr string, node any, fn TransformFunc) error {
+	children := walkChildrenList(node)
+
+	for _, child := range children.children {
+		childPtr := joinPointer(ptr, child.tokens...)
+		value, action := fn(childPtr, child.node)
+
+		switch action {
+		case Delete:
+			child.remove()
+
+			continue
+		case Replace:
+			if err := child.replace(value); err != nil {
+				return fmt.Errorf("cannot replace %q: %w", childPtr, err)
+			}
+		case InsertAfter:
+			if err := child.insert(value); err != nil {
+				return fmt.Errorf("cannot insert after %q: %w", childPtr, err)
+			}
+			if err := transformChildren(childPtr, child.node, fn); err != nil {
+				return err
+			}
+		case Continue:
+			if err := transformChildren(childPtr, child.node, fn); err != nil {
+				return err
+			}
+		case SkipChildren:
+		default:
+			return fmt.Errorf("unknown action %d at %q: %w", action, childPtr, ErrSpec)
+		}
+
+		child.store()
+	}
+
+	for _, commit := range children.commits {
+		commit()
+	}
+
+	return nil
+}
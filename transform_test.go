@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+const transformSpec = `{
+  "swagger": "2.0",
+  "info": { "title": "pets", "version": "1.0" },
+  "paths": {
+    "/pets": {
+      "get": {
+        "x-internal-id": "p1",
+        "parameters": [
+          { "name": "since", "in": "query", "type": "string", "format": "date-time" },
+          { "name": "debug", "in": "query", "type": "boolean" },
+          { "name": "limit", "in": "query", "type": "integer" }
+        ],
+        "responses": {
+          "200": {
+            "description": "ok",
+            "examples": { "application/json": [] },
+            "schema": { "type": "array", "items": { "$ref": "#/definitions/Pet" } }
+          }
+        }
+      }
+    },
+    "/internal/stats": {
+      "get": { "responses": { "200": { "description": "ok" } } }
+    }
+  },
+  "definitions": {
+    "Pet": {
+      "type": "object",
+      "x-internal-id": "d1",
+      "example": { "name": "rex" },
+      "properties": {
+        "name": { "type": "string", "example": "rex" },
+        "born": { "type": "string", "format": "date-time" }
+      }
+    },
+    "Legacy": { "type": "object" }
+  }
+}`
+
+func TestTransform(t *testing.T) {
+	sp := mustSpec(t, transformSpec)
+
+	require.NoError(t, Transform(sp, func(ptr string, node any) (any, Action) {
+		switch n := node.(type) {
+		case *PathItem:
+			if strings.HasPrefix(ptr, "/paths/~1internal") {
+				return nil, Delete
+			}
+		case *Operation:
+			renameExtension(n.Extensions, "x-internal-id", "x-id")
+		case *Response:
+			n.Examples = nil
+		case *Parameter:
+			switch n.Name {
+			case "debug":
+				return nil, Delete
+			case "limit":
+				return QueryParam("offset").Typed("integer", ""), InsertAfter
+			}
+		case *Schema:
+			renameExtension(n.Extensions, "x-internal-id", "x-id")
+			n.Example = nil
+
+			switch {
+			case ptr == "/definitions/Legacy":
+				return Entry{Key: "Modern"}, Replace
+			case ptr == "/definitions/Pet":
+				return Entry{Key: "PetList", Value: ArrayProperty(RefSchema("#/definitions/Pet"))}, InsertAfter
+			case n.Format == "date-time":
+				return RefSchema("#/definitions/DateTime"), Replace
+			}
+		}
+
+		return nil, Continue
+	}))
+
+	t.Run("paths should be deleted", func(t *testing.T) {
+		assert.NotContains(t, sp.Paths.Paths, "/internal/stats")
+		assert.Contains(t, sp.Paths.Paths, "/pets")
+	})
+
+	t.Run("extensions should be renamed", func(t *testing.T) {
+		get := sp.Paths.Paths["/pets"].Get
+		assert.Equal(t, Extensions{"x-id": "p1"}, get.Extensions)
+		assert.Equal(t, Extensions{"x-id": "d1"}, sp.Definitions["Pet"].Extensions)
+	})
+
+	t.Run("examples should be stripped", func(t *testing.T) {
+		assert.Nil(t, sp.Paths.Paths["/pets"].Get.Responses.StatusCodeResponses[200].Examples)
+		assert.Nil(t, sp.Definitions["Pet"].Example)
+		assert.Nil(t, sp.Definitions["Pet"].Properties["name"].Example)
+	})
+
+	t.Run("parameters should be deleted, replaced and inserted", func(t *testing.T) {
+		params := sp.Paths.Paths["/pets"].Get.Parameters
+		require.Len(t, params, 3)
+		assert.EqualT(t, "since", params[0].Name)
+		assert.EqualT(t, "limit", params[1].Name)
+		assert.EqualT(t, "offset", params[2].Name)
+	})
+
+	t.Run("schemas should be replaced", func(t *testing.T) {
+		born := sp.Definitions["Pet"].Properties["born"]
+		assert.EqualT(t, "#/definitions/DateTime", born.Ref.String())
+		assert.Equal(t, *StringProperty(), sp.Definitions["Pet"].Properties["name"])
+	})
+
+	t.Run("definitions should be renamed and inserted", func(t *testing.T) {
+		assert.NotContains(t, sp.Definitions, "Legacy")
+		assert.Contains(t, sp.Definitions, "Modern")
+		require.Contains(t, sp.Definitions, "PetList")
+		assert.EqualT(t, "#/definitions/Pet", sp.Definitions["PetList"].Items.Schema.Ref.String())
+	})
+}
+
+func TestTransform_Pointers(t *testing.T) {
+	sp := mustSpec(t, transformSpec)
+
+	var pointers []string
+	require.NoError(t, Transform(sp, func(ptr string, node any) (any, Action) {
+		pointers = append(pointers, ptr)
+		if _, ok := node.(*Paths); ok {
+			return nil, SkipChildren
+		}
+
+		return nil, Continue
+	}))
+
+	assert.Equal(t, []string{
+		"",
+		"/info",
+		"/paths",
+		"/definitions/Legacy",
+		"/definitions/Pet",
+		"/definitions/Pet/properties/born",
+		"/definitions/Pet/properties/name",
+	}, pointers)
+	assert.TrueT(t, mustSpec(t, transformSpec).Equal(sp))
+}
+
+func TestTransform_Errors(t *testing.T) {
+	original := mustSpec(t, transformSpec)
+
+	for _, tc := range []struct {
+		name string
+		fn   TransformFunc
+	}{
+		{"replace with another type", func(_ string, node any) (any, Action) {
+			if _, ok := node.(*Schema); ok {
+				return NewOperation("x"), Replace
+			}
+
+			return nil, Continue
+		}},
+		{"insert after a field", func(_ string, node any) (any, Action) {
+			if _, ok := node.(*Info); ok {
+				return &Info{}, InsertAfter
+			}
+
+			return nil, Continue
+		}},
+		{"insert a value in a map", func(_ string, node any) (any, Action) {
+			if _, ok := node.(*Schema); ok {
+				return StringProperty(), InsertAfter
+			}
+
+			return nil, Continue
+		}},
+		{"rename to an existing key", func(ptr string, _ any) (any, Action) {
+			if ptr == "/definitions/Legacy" {
+				return Entry{Key: "Pet"}, Replace
+			}
+
+			return nil, Continue
+		}},
+		{"delete the root", func(string, any) (any, Action) {
+			return nil, Delete
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sp := original.DeepCopy()
+			// changes made before the failure should not be kept
+			err := Transform(sp, func(ptr string, node any) (any, Action) {
+				if info, ok := node.(*Info); ok {
+					info.Title = "changed"
+				}
+
+				return tc.fn(ptr, node)
+			})
+			require.ErrorIs(t, err, ErrSpec)
+			assert.TrueT(t, original.Equal(sp))
+		})
+	}
+}
+
+func renameExtension(ext Extensions, from, to string) {
+	if v, ok := ext[from]; ok {
+		delete(ext, from)
+		ext[to] = v
+	}
+}
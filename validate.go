@@ -0,0 +1,469 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Parameter locations.
+const (
+	paramInQuery    = "query"
+	paramInHeader   = "header"
+	paramInPath     = "path"
+	paramInFormData = "formData"
+	paramInBody     = "body"
+)
+
+// ValidationError is a violation of the Swagger 2.0 specification, found by [Swagger.Validate].
+type ValidationError struct {
+	// Pointer is the JSON pointer to the invalid node, e.g. "/paths/~1pets/get/parameters/0"
+	Pointer string
+
+	// Message describes the violation
+	Message string
+}
+
+// Error returns the message of the violation, prefixed by its location.
+func (e *ValidationError) Error() string {
+	ptr := e.Pointer
+	if ptr == "" {
+		ptr = "/"
+	}
+
+	return ptr + ": " + e.Message
+}
+
+// Unwrap allows to check that the error is a validation error with errors.Is(err, ErrValidation).
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// ValidationErrors is the list of violations found by [Swagger.Validate].
+type ValidationErrors []*ValidationError
+
+// Error returns all the violations, one per line.
+func (e ValidationErrors) Error() string {
+	lines := make([]string, 0, len(e))
+	for _, err := range e {
+		lines = append(lines, err.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns the individual violations.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// Validate checks the structural rules of the Swagger 2.0 specification which can be verified on the object model.
+//
+// It is a lightweight alternative to a full validation with github.com/go-openapi/validate: $ref's to other
+// documents are not followed, and schemas are not checked against the JSON schema of the specification.
+//
+// The rules checked are:
+//   - the swagger version is "2.0", info has a title and a version
+//   - schemes are from [http, https, ws, wss], the base path starts with "/", the host has no scheme nor path
+//   - paths are present, are valid path templates starting with "/", and no two of them are equivalent
+//   - operation IDs are unique
+//   - parameters are unique by name and location, and have a valid location
+//   - every segment of a path template is declared by a path parameter, and path parameters are required
+//   - an operation has at most one body parameter, and does not mix body and formData parameters
+//   - an operation has at least one response
+//   - security schemes are complete, and security requirements refer to defined schemes
+//
+// It returns nil when the spec is valid, or a [ValidationErrors] with all the violations found.
+func (s *Swagger) Validate() error {
+	v := &validator{spec: s}
+	v.swagger()
+
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return v.errs
+}
+
+// ValidateDocument validates a raw JSON document against the JSON schema of the Swagger 2.0 specification,
+// before it is unmarshaled into a [Swagger].
+//
+// Unmarshaling ignores the keys which are not part of the object model, e.g. misplaced keys,
+// vendor extensions which are not allowed at their location or paths which do not start with "/".
+// Validating the document first reports such keys, as well as values of the wrong type.
+//
+// It returns nil when the document is valid, an error wrapping [ErrSpec] when it is not a valid JSON document,
+// or a [SchemaValidationErrors] with the most specific violations found. Violations are reported with
+// the JSON pointer of the invalid value in the document, and the location of the violated keyword in the schema.
+func ValidateDocument(raw []byte) error {
+	var document any
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&document); err != nil {
+		return fmt.Errorf("invalid JSON document: %w: %w", err, ErrSpec)
+	}
+
+	schema, err := Swagger20Schema()
+	if err != nil {
+		return err
+	}
+
+	err = NewSchemaValidator(schema, nil).Validate(document)
+	if errs, ok := err.(SchemaValidationErrors); ok { //nolint:errorlint // the validator returns this type, unwrapped
+		return errs.Leaves()
+	}
+
+	return err
+}
+
+// validator collects the violations found in a spec.
+//
+// With templatesOnly, only the consistency of path templates with path parameters is checked.
+type validator struct {
+	spec          *Swagger
+	errs          ValidationErrors
+	templatesOnly bool
+}
+
+func (v *validator) add(ptr string, format string, args ...any) {
+	v.errs = append(v.errs, &ValidationError{Pointer: ptr, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) swagger() {
+	s := v.spec
+
+	if s.Swagger != "2.0" {
+		v.add("/swagger", "the swagger version must be %q, got %q", "2.0", s.Swagger)
+	}
+
+	switch {
+	case s.Info == nil:
+		v.add("/info", "info is required")
+	default:
+		if s.Info.Title == "" {
+			v.add("/info/title", "a title is required")
+		}
+		if s.Info.Version == "" {
+			v.add("/info/version", "a version is required")
+		}
+	}
+
+	v.schemes("/schemes", s.Schemes)
+
+	if s.BasePath != "" && !strings.HasPrefix(s.BasePath, "/") {
+		v.add("/basePath", "the base path %q must start with %q", s.BasePath, "/")
+	}
+	if strings.Contains(s.Host, "://") || strings.Contains(s.Host, "/") {
+		v.add("/host", "the host %q must not include a scheme or a path", s.Host)
+	}
+
+	for _, name := range mapKeysSorted(s.Parameters) {
+		param := s.Parameters[name]
+		v.parameter(joinPointer("/parameters", name), &param)
+	}
+
+	for _, name := range mapKeysSorted(s.SecurityDefinitions) {
+		v.securityScheme(joinPointer("/securityDefinitions", name), s.SecurityDefinitions[name])
+	}
+	v.security("/security", s.Security)
+
+	v.paths()
+}
+
+func (v *validator) schemes(ptr string, schemes []string) {
+	for i, scheme := range schemes {
+		switch scheme {
+		case "http", "https", "ws", "wss":
+		default:
+			v.add(joinPointer(ptr, strconv.Itoa(i)), "the scheme %q must be one of http, https, ws or wss", scheme)
+		}
+	}
+}
+
+func (v *validator) paths() {
+	if v.spec.Paths == nil {
+		v.add("/paths", "paths are required")
+
+		return
+	}
+
+	operationIDs := make(map[string]string)
+	templates := make(map[string]string)
+
+	for _, path := range mapKeysSorted(v.spec.Paths.Paths) {
+		ptr := joinPointer("/paths", path)
+		template, err := ParsePathTemplate(path)
+		normalized := path
+		switch {
+		case !strings.HasPrefix(path, "/"):
+			v.add(ptr, "the path %q must start with %q", path, "/")
+		case err != nil:
+			v.add(ptr, "the path template %q is invalid", path)
+		default:
+			normalized = template.normalized()
+		}
+
+		if other, ok := templates[normalized]; ok {
+			v.add(ptr, "the path %q is equivalent to %q", path, other)
+		} else {
+			templates[normalized] = path
+		}
+
+		item := v.spec.Paths.Paths[path]
+		v.pathItem(ptr, template, &item, operationIDs)
+	}
+}
+
+// pathItem checks a path item. The template is nil when it could not be parsed.
+func (v *validator) pathItem(ptr string, template *PathTemplate, item *PathItem, operationIDs map[string]string) {
+	pathParams := v.parameterList(joinPointer(ptr, "parameters"), item.Parameters)
+
+	for _, op := range item.operations() {
+		opPtr := joinPointer(ptr, op.method)
+		if !v.templatesOnly {
+			v.operation(opPtr, op.operation, operationIDs)
+		}
+
+		opParams := v.parameterList(joinPointer(opPtr, "parameters"), op.operation.Parameters)
+		v.operationParameters(opPtr, template, mergeValidatedParameters(pathParams, opParams))
+	}
+
+	if template == nil {
+		return
+	}
+
+	// path parameters declared on the path item must match the template, even if no operation uses them
+	templateParams := template.Params()
+	for _, param := range pathParams {
+		if param.In == paramInPath && !slices.Contains(templateParams, param.Name) {
+			v.add(param.ptr, "the path parameter %q does not appear in the path template", param.Name)
+		}
+	}
+}
+
+// operation checks the properties of an operation other than its parameters.
+func (v *validator) operation(ptr string, op *Operation, operationIDs map[string]string) {
+	if id := op.ID; id != "" {
+		if other, ok := operationIDs[id]; ok {
+			v.add(joinPointer(ptr, "operationId"), "the operation ID %q is already used by %s", id, other)
+		} else {
+			operationIDs[id] = ptr
+		}
+	}
+
+	v.schemes(joinPointer(ptr, "schemes"), op.Schemes)
+	v.security(joinPointer(ptr, "security"), op.Security)
+
+	if op.Responses == nil ||
+		(op.Responses.Default == nil && len(op.Responses.StatusCodeResponses) == 0 &&
+			len(op.Responses.StatusCodeRanges) == 0) {
+		v.add(joinPointer(ptr, "responses"), "an operation must have at least one response")
+	}
+}
+
+// operationParameters checks the parameters of an operation, including those inherited from its path item.
+//
+// The template is nil when it could not be parsed.
+func (v *validator) operationParameters(ptr string, template *PathTemplate, params []validatedParameter) {
+	declared := make(map[string]bool)
+	var body, formData []validatedParameter
+
+	var templateParams []string
+	if template != nil {
+		templateParams = template.Params()
+	}
+
+	for _, param := range params {
+		switch param.In {
+		case paramInPath:
+			declared[param.Name] = true
+			// parameters inherited from the path item are reported once, on the path item
+			if template != nil && !slices.Contains(templateParams, param.Name) && strings.HasPrefix(param.ptr, ptr+"/") {
+				v.add(param.ptr, "the path parameter %q does not appear in the path template", param.Name)
+			}
+		case paramInBody:
+			body = append(body, param)
+		case paramInFormData:
+			formData = append(formData, param)
+		}
+	}
+
+	for _, name := range templateParams {
+		if !declared[name] {
+			v.add(ptr, "the path parameter %q is not declared", name)
+		}
+	}
+
+	if v.templatesOnly {
+		return
+	}
+
+	if len(body) > 1 {
+		v.add(body[1].ptr, "an operation must have at most one body parameter, %q is also declared", body[0].Name)
+	}
+	if len(body) > 0 && len(formData) > 0 {
+		v.add(ptr, "an operation cannot have both body and formData parameters")
+	}
+}
+
+// validatedParameter is a parameter, resolved when it is a local $ref, with its location in the spec.
+type validatedParameter struct {
+	*Parameter
+
+	ptr string
+}
+
+// parameterList checks a list of parameters and returns them, once resolved.
+//
+// Parameters which cannot be resolved, e.g. $ref's to other documents, are skipped.
+func (v *validator) parameterList(ptr string, params []Parameter) []validatedParameter {
+	validated := make([]validatedParameter, 0, len(params))
+	seen := make(map[string]string)
+
+	for i := range params {
+		paramPtr := joinPointer(ptr, strconv.Itoa(i))
+		param := &params[i]
+
+		if ref := param.Ref.String(); ref != "" {
+			resolved, ok := v.localParameter(paramPtr, param.Ref)
+			if !ok {
+				continue
+			}
+			param = resolved
+		} else if !v.templatesOnly {
+			v.parameter(paramPtr, param)
+		}
+
+		key := param.In + ":" + param.Name
+		if other, ok := seen[key]; ok {
+			if !v.templatesOnly {
+				v.add(paramPtr, "the %s parameter %q is already declared by %s", param.In, param.Name, other)
+			}
+
+			continue
+		}
+		seen[key] = paramPtr
+
+		validated = append(validated, validatedParameter{Parameter: param, ptr: paramPtr})
+	}
+
+	return validated
+}
+
+// localParameter resolves a $ref to a parameter defined in the spec.
+func (v *validator) localParameter(ptr string, ref Ref) (*Parameter, bool) {
+	const prefix = "#/parameters/"
+
+	str := ref.String()
+	if !strings.HasPrefix(str, prefix) {
+		return nil, false
+	}
+
+	name := strings.ReplaceAll(strings.ReplaceAll(strings.TrimPrefix(str, prefix), "~1", "/"), "~0", "~")
+	param, ok := v.spec.Parameters[name]
+	if !ok {
+		v.add(joinPointer(ptr, jsonRef), "the parameter %q is not defined", str)
+
+		return nil, false
+	}
+
+	return &param, true
+}
+
+// mergeValidatedParameters merges the parameters of a path item with those of an operation,
+// which override them by name and location.
+func mergeValidatedParameters(pathParams, opParams []validatedParameter) []validatedParameter {
+	merged := make([]validatedParameter, 0, len(pathParams)+len(opParams))
+	for _, param := range pathParams {
+		overridden := slices.ContainsFunc(opParams, func(p validatedParameter) bool {
+			return p.Name == param.Name && p.In == param.In
+		})
+		if !overridden {
+			merged = append(merged, param)
+		}
+	}
+
+	return append(merged, opParams...)
+}
+
+func (v *validator) parameter(ptr string, param *Parameter) {
+	if param.Ref.String() != "" {
+		return
+	}
+
+	if param.Name == "" {
+		v.add(joinPointer(ptr, "name"), "a parameter must have a name")
+	}
+
+	switch param.In {
+	case paramInBody:
+		if param.Schema == nil {
+			v.add(joinPointer(ptr, "schema"), "the body parameter %q must have a schema", param.Name)
+		}
+	case paramInQuery, paramInHeader, paramInPath, paramInFormData:
+		if param.Type == "" {
+			v.add(joinPointer(ptr, "type"), "the %s parameter %q must have a type", param.In, param.Name)
+		}
+		if param.Type == "file" && param.In != paramInFormData {
+			v.add(joinPointer(ptr, "type"), "the file parameter %q must be in formData", param.Name)
+		}
+	default:
+		v.add(joinPointer(ptr, "in"), "the parameter %q has an invalid location %q", param.Name, param.In)
+	}
+
+	if param.In == paramInPath && !param.Required {
+		v.add(joinPointer(ptr, "required"), "the path parameter %q must be required", param.Name)
+	}
+}
+
+func (v *validator) securityScheme(ptr string, scheme *SecurityScheme) {
+	if scheme == nil {
+		v.add(ptr, "a security scheme cannot be null")
+
+		return
+	}
+
+	switch scheme.Type {
+	case basic:
+	case apiKey:
+		if scheme.Name == "" {
+			v.add(joinPointer(ptr, "name"), "an apiKey security scheme must have a name")
+		}
+		if scheme.In != paramInQuery && scheme.In != paramInHeader {
+			v.add(joinPointer(ptr, "in"), "an apiKey security scheme must be in query or header, got %q", scheme.In)
+		}
+	case oauth2:
+		if !slices.Contains([]string{implicit, password, application, accessCode}, scheme.Flow) {
+			v.add(joinPointer(ptr, "flow"), "the oauth2 flow %q must be one of implicit, password, application or accessCode", scheme.Flow)
+		}
+		if (scheme.Flow == implicit || scheme.Flow == accessCode) && scheme.AuthorizationURL == "" {
+			v.add(joinPointer(ptr, "authorizationUrl"), "the %s oauth2 flow requires an authorization URL", scheme.Flow)
+		}
+		if (scheme.Flow == password || scheme.Flow == application || scheme.Flow == accessCode) && scheme.TokenURL == "" {
+			v.add(joinPointer(ptr, "tokenUrl"), "the %s oauth2 flow requires a token URL", scheme.Flow)
+		}
+	default:
+		v.add(joinPointer(ptr, "type"), "the security scheme type %q must be one of basic, apiKey or oauth2", scheme.Type)
+	}
+}
+
+func (v *validator) security(ptr string, requirements []map[string][]string) {
+	for i, requirement := range requirements {
+		for _, name := range mapKeysSorted(requirement) {
+			if _, ok := v.spec.SecurityDefinitions[name]; !ok {
+				v.add(joinPointer(ptr, strconv.Itoa(i), name), "the security scheme %q is not defined", name)
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+const validSpec = `{
+  "swagger": "2.0",
+  "info": { "title": "pets", "version": "1.0" },
+  "host": "api.example.com",
+  "basePath": "/v1",
+  "schemes": [ "https" ],
+  "securityDefinitions": {
+    "key": { "type": "apiKey", "name": "X-Key", "in": "header" },
+    "oauth": { "type": "oauth2", "flow": "accessCode", "authorizationUrl": "https://example.com/auth", "tokenUrl": "https://example.com/token" }
+  },
+  "security": [ { "key": [] } ],
+  "parameters": {
+    "petId": { "name": "id", "in": "path", "type": "string", "required": true }
+  },
+  "paths": {
+    "/pets": {
+      "get": { "operationId": "listPets", "responses": { "200": { "description": "ok" } } },
+      "post": {
+        "operationId": "createPet",
+        "parameters": [ { "name": "pet", "in": "body", "schema": { "type": "object" } } ],
+        "responses": { "default": { "description": "error" } }
+      }
+    },
+    "/pets/{id}": {
+      "parameters": [ { "$ref": "#/parameters/petId" } ],
+      "get": { "operationId": "getPet", "security": [ { "oauth": [ "read" ] } ], "responses": { "200": { "description": "ok" } } },
+      "put": {
+        "parameters": [
+          { "name": "id", "in": "path", "type": "integer", "required": true },
+          { "name": "name", "in": "formData", "type": "string" },
+          { "name": "photo", "in": "formData", "type": "file" }
+        ],
+        "responses": { "204": { "description": "updated" } }
+      }
+    }
+  }
+}`
+
+func TestSwagger_Validate(t *testing.T) {
+	t.Run("a valid spec should pass", func(t *testing.T) {
+		require.NoError(t, mustSpec(t, validSpec).Validate())
+	})
+
+	t.Run("all violations should be reported", func(t *testing.T) {
+		sp := mustSpec(t, `{
+  "swagger": "3.0",
+  "info": { "title": "pets" },
+  "host": "https://api.example.com",
+  "basePath": "v1",
+  "schemes": [ "https", "ftp" ],
+  "securityDefinitions": {
+    "key": { "type": "apiKey", "in": "cookie" },
+    "oauth": { "type": "oauth2", "flow": "password" },
+    "other": { "type": "digest" }
+  },
+  "security": [ { "missing": [] } ],
+  "paths": {
+    "/pets/{id}": {
+      "parameters": [ { "name": "petId", "in": "path", "type": "string", "required": true } ],
+      "get": {
+        "operationId": "listPets",
+        "parameters": [
+          { "name": "id", "in": "path", "type": "string" },
+          { "name": "limit", "in": "query", "type": "integer" },
+          { "name": "limit", "in": "query", "type": "integer" },
+          { "name": "data", "in": "body" },
+          { "name": "extra", "in": "body", "schema": { "type": "object" } },
+          { "name": "form", "in": "formData", "type": "string" },
+          { "name": "file", "in": "query", "type": "file" },
+          { "name": "where", "in": "cookie", "type": "string" },
+          { "$ref": "#/parameters/missing" }
+        ],
+        "responses": { "200": { "description": "ok" } }
+      }
+    },
+    "/pets/{petId}/{kind}": {
+      "post": { "responses": { "200": { "description": "ok" } } }
+    },
+    "/pets/{name}": {}
+  }
+}`)
+		// paths without a leading "/" are ignored when unmarshaling a spec
+		op := NewOperation("listPets")
+		op.Responses = &Responses{}
+		sp.Paths.Paths["pets"] = PathItem{PathItemProps: PathItemProps{Get: op}}
+
+		err := sp.Validate()
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrValidation)
+
+		var errs ValidationErrors
+		require.TrueT(t, errors.As(err, &errs))
+
+		messages := make([]string, 0, len(errs))
+		for _, e := range errs {
+			messages = append(messages, e.Error())
+		}
+		assert.Equal(t, []string{
+			`/swagger: the swagger version must be "2.0", got "3.0"`,
+			`/info/version: a version is required`,
+			`/schemes/1: the scheme "ftp" must be one of http, https, ws or wss`,
+			`/basePath: the base path "v1" must start with "/"`,
+			`/host: the host "https://api.example.com" must not include a scheme or a path`,
+			`/securityDefinitions/key/name: an apiKey security scheme must have a name`,
+			`/securityDefinitions/key/in: an apiKey security scheme must be in query or header, got "cookie"`,
+			`/securityDefinitions/oauth/tokenUrl: the password oauth2 flow requires a token URL`,
+			`/securityDefinitions/other/type: the security scheme type "digest" must be one of basic, apiKey or oauth2`,
+			`/security/0/missing: the security scheme "missing" is not defined`,
+			`/paths/~1pets~1{id}/get/parameters/0/required: the path parameter "id" must be required`,
+			`/paths/~1pets~1{id}/get/parameters/2: the query parameter "limit" is already declared by /paths/~1pets~1{id}/get/parameters/1`,
+			`/paths/~1pets~1{id}/get/parameters/3/schema: the body parameter "data" must have a schema`,
+			`/paths/~1pets~1{id}/get/parameters/6/type: the file parameter "file" must be in formData`,
+			`/paths/~1pets~1{id}/get/parameters/7/in: the parameter "where" has an invalid location "cookie"`,
+			`/paths/~1pets~1{id}/get/parameters/8/$ref: the parameter "#/parameters/missing" is not defined`,
+			`/paths/~1pets~1{id}/get/parameters/4: an operation must have at most one body parameter, "data" is also declared`,
+			`/paths/~1pets~1{id}/get: an operation cannot have both body and formData parameters`,
+			`/paths/~1pets~1{id}/parameters/0: the path parameter "petId" does not appear in the path template`,
+			`/paths/~1pets~1{name}: the path "/pets/{name}" is equivalent to "/pets/{id}"`,
+			`/paths/~1pets~1{petId}~1{kind}/post: the path parameter "petId" is not declared`,
+			`/paths/~1pets~1{petId}~1{kind}/post: the path parameter "kind" is not declared`,
+			`/paths/pets: the path "pets" must start with "/"`,
+			`/paths/pets/get/operationId: the operation ID "listPets" is already used by /paths/~1pets~1{id}/get`,
+			`/paths/pets/get/responses: an operation must have at least one response`,
+		}, messages)
+	})
+
+	t.Run("paths should be required", func(t *testing.T) {
+		err := mustSpec(t, `{"swagger":"2.0","info":{"title":"t","version":"1"}}`).Validate()
+		var errs ValidationErrors
+		require.TrueT(t, errors.As(err, &errs))
+		require.Len(t, errs, 1)
+		assert.EqualT(t, "/paths", errs[0].Pointer)
+	})
+}
+
+func TestValidateDocument(t *testing.T) {
+	t.Run("a valid document should pass", func(t *testing.T) {
+		require.NoError(t, ValidateDocument([]byte(validSpec)))
+	})
+
+	t.Run("invalid JSON should be reported", func(t *testing.T) {
+		err := ValidateDocument([]byte(`{"swagger":`))
+		require.ErrorIs(t, err, ErrSpec)
+		require.NotErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("violations should be reported at their location", func(t *testing.T) {
+		err := ValidateDocument([]byte(`{
+  "swagger": "2.0",
+  "info": { "title": "pets", "version": 1, "x-audience": "public" },
+  "paths": {
+    "pets": {},
+    "/pets": {
+      "get": {
+        "operationID": "listPets",
+        "parameters": [ { "name": "limit", "in": "query", "type": "integer", "maximun": 10 } ],
+        "responses": { "200": { "description": "ok", "schema": { "type": "array", "items": "Pet" } } }
+      }
+    }
+  },
+  "definitions": { "Pet": { "type": "object", "required": "name" } },
+  "x-generator": "hand"
+}`))
+		require.ErrorIs(t, err, ErrValidation)
+
+		var errs SchemaValidationErrors
+		require.TrueT(t, errors.As(err, &errs))
+
+		messages := make([]string, 0, len(errs))
+		for _, e := range errs {
+			messages = append(messages, e.Error())
+		}
+		assert.Equal(t, []string{
+			`/definitions/Pet/required: expected a value of type array, got string`,
+			`/info/version: expected a value of type string, got integer`,
+			`/paths/~1pets/get/operationID: the property "operationID" is not allowed`,
+			`/paths/~1pets/get/parameters/0/maximun: the property "maximun" is not allowed`,
+			`/paths/~1pets/get/responses/200/schema/items: expected a value of type object, got string`,
+			`/paths/pets: the property "pets" is not allowed`,
+		}, messages)
+	})
+}
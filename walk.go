@@ -0,0 +1,524 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SkipSubtree is used as a return value from a [Visitor] to indicate that
+// the children of the visited node are to be skipped. It is not returned as an error by any function.
+var SkipSubtree = errors.New("skip this subtree") //nolint:errname,gochecknoglobals // sentinel value, like fs.SkipDir
+
+// WalkNode is a node of a specification visited by [Walk].
+type WalkNode struct {
+	// Pointer is the JSON pointer to the node.
+	//
+	// Nodes reached by following a $ref are located at the pointer of the referring node.
+	Pointer string
+
+	// Value is a pointer to the node, e.g. *Swagger, *PathItem, *Operation, *Parameter or *Schema.
+	//
+	// Values found in maps (e.g. definitions, properties or paths) and values resolved from a $ref
+	// are copies: changes made to them are not reflected in the specification.
+	Value any
+
+	// Parent is the node which contains this one. It is nil for the root of the specification.
+	Parent *WalkNode
+
+	// Ref is the $ref which has been followed to reach this node, if any.
+	//
+	// Its parent is then the node holding this $ref.
+	Ref string
+
+	base    string
+	visited *visitedRef
+}
+
+// Visitor visits the nodes of a specification.
+//
+// When Visit returns [SkipSubtree], the children of the node are not visited.
+// When it returns any other error, the walk is interrupted and this error is returned.
+type Visitor interface {
+	Visit(node *WalkNode) error
+}
+
+// VisitorFunc is a function which implements [Visitor].
+type VisitorFunc func(node *WalkNode) error
+
+// Visit a node.
+func (f VisitorFunc) Visit(node *WalkNode) error {
+	return f(node)
+}
+
+// WalkOptions provides options to walk a specification.
+type WalkOptions struct {
+	// FollowRefs tells the walker to resolve $ref's in schemas, parameters, responses and path items,
+	// and to visit the resolved nodes. Cyclic $ref's are followed only once on a given branch.
+	FollowRefs bool
+
+	// ExpandOptions are used to resolve $ref's, e.g. to set the location of the specification
+	ExpandOptions *ExpandOptions
+}
+
+// Walk visits all the objects of a specification, depth-first, with their JSON pointer.
+//
+// The visited objects are: *Swagger, *Info, *ContactInfo, *License, *ExternalDocumentation, *Tag,
+// *SecurityScheme, *Paths, *PathItem, *Operation, *Parameter, *Items, *Responses, *Response, *Header,
+// *Schema and *XMLObject.
+//
+// Children are visited in a deterministic order: fields in the order of their JSON representation,
+// map entries sorted by key.
+//
+// Schemas are visited through allOf, anyOf, oneOf, not, items, additionalItems, properties,
+// additionalProperties, patternProperties, dependencies and definitions.
+func Walk(spec *Swagger, visitor Visitor) error {
+	return WalkWithOptions(spec, visitor, nil)
+}
+
+// WalkWithOptions visits all the objects of a specification, with options to follow $ref's.
+func WalkWithOptions(spec *Swagger, visitor Visitor, options *WalkOptions) error {
+	if spec == nil {
+		return nil
+	}
+	if options == nil {
+		options = &WalkOptions{}
+	}
+
+	w := &walker{visitor: visitor}
+	root := &WalkNode{Value: spec}
+	if options.FollowRefs {
+		w.resolver = newRefResolver(spec, options.ExpandOptions)
+		root.base = w.resolver.rootBase
+	}
+
+	return w.walk(root)
+}
+
+type walker struct {
+	visitor  Visitor
+	resolver *refResolver
+}
+
+func (w *walker) walk(n *WalkNode) error {
+	if err := w.visitor.Visit(n); err != nil {
+		if errors.Is(err, SkipSubtree) {
+			return nil
+		}
+
+		return err
+	}
+
+	if w.resolver != nil {
+		if ref := walkRefOf(n.Value); ref.String() != "" {
+			return w.follow(n, ref)
+		}
+	}
+
+	for _, c := range walkChildren(n.Value) {
+		child := &WalkNode{
+			Pointer: joinPointer(n.Pointer, c.tokens...),
+			Value:   c.node,
+			Parent:  n,
+			base:    n.base,
+			visited: n.visited,
+		}
+		if err := w.walk(child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// follow visits the node resolved from a $ref, unless it has already been followed on this branch.
+func (w *walker) follow(n *WalkNode, ref Ref) error {
+	key := w.resolver.key(ref, n.base)
+	if n.visited.contains(key) {
+		return nil
+	}
+
+	var target any
+	switch n.Value.(type) {
+	case *Schema:
+		target = new(Schema)
+	case *Parameter:
+		target = new(Parameter)
+	case *Response:
+		target = new(Response)
+	case *PathItem:
+		target = new(PathItem)
+	default:
+		return nil
+	}
+
+	base, err := w.resolver.resolve(ref, n.base, target)
+	if err != nil {
+		return err
+	}
+
+	return w.walk(&WalkNode{
+		Pointer: n.Pointer,
+		Value:   target,
+		Parent:  n,
+		Ref:     ref.String(),
+		base:    base,
+		visited: &visitedRef{key: key, parent: n.visited},
+	})
+}
+
+// walkRefOf returns the $ref of a node which may be followed.
+func walkRefOf(node any) Ref {
+	switch n := node.(type) {
+	case *Schema:
+		return n.Ref
+	case *Parameter:
+		return n.Ref
+	case *Response:
+		return n.Ref
+	case *PathItem:
+		return n.Ref
+	default:
+		return Ref{}
+	}
+}
+
+// walkChild is a child node of an object of the specification.
+//
+// Besides locating the child, it carries the operations used by [Transform] to edit its container.
+type walkChild struct {
+	// tokens locate the child relative to its parent
+	tokens []string
+
+	// node is a pointer to the child
+	node any
+
+	// replace replaces the child with a value of the same type, or with an [Entry] for map entries
+	replace func(value any) error
+
+	// remove removes the child from its container
+	remove func()
+
+	// insert inserts a value after the child, in arrays, or a new [Entry], in maps
+	insert func(value any) error
+
+	// store writes back the child into its container, once transformed, when node is a copy
+	store func()
+}
+
+// walkChildList collects the children of an object.
+type walkChildList struct {
+	children []walkChild
+
+	// commits are edits applied once all children have been transformed,
+	// so that array indices remain stable while transforming
+	commits []func()
+}
+
+// walkChildren lists the children of an object of the specification, in a deterministic order.
+func walkChildren(node any) []walkChild {
+	return walkChildrenList(node).children
+}
+
+func walkChildrenList(node any) *walkChildList {
+	c := &walkChildList{}
+
+	switch n := node.(type) {
+	case *Swagger:
+		walkPtr(c, &n.Info, "info")
+		walkPtr(c, &n.ExternalDocs, "externalDocs")
+		walkMap(c, &n.SecurityDefinitions, pointerEntries[SecurityScheme](), "securityDefinitions")
+		walkPtr(c, &n.Paths, "paths")
+		walkMap(c, &n.Definitions, valueEntries[Schema](), "definitions")
+		walkMap(c, &n.Parameters, valueEntries[Parameter](), "parameters")
+		walkMap(c, &n.Responses, valueEntries[Response](), "responses")
+		walkSlice(c, &n.Tags, "tags")
+	case *Info:
+		walkPtr(c, &n.Contact, "contact")
+		walkPtr(c, &n.License, "license")
+	case *Tag:
+		walkPtr(c, &n.ExternalDocs, "externalDocs")
+	case *Paths:
+		walkMap(c, &n.Paths, valueEntries[PathItem]())
+	case *PathItem:
+		for _, method := range pathItemMethods {
+			walkPtr(c, n.operationField(method), method)
+		}
+		walkSlice(c, &n.Parameters, "parameters")
+	case *Operation:
+		walkPtr(c, &n.ExternalDocs, "externalDocs")
+		walkSlice(c, &n.Parameters, "parameters")
+		walkPtr(c, &n.Responses, "responses")
+	case *Parameter:
+		walkPtr(c, &n.Schema, "schema")
+		walkPtr(c, &n.Items, "items")
+	case *Items:
+		walkPtr(c, &n.Items, "items")
+	case *Header:
+		walkPtr(c, &n.Items, "items")
+	case *Responses:
+		walkPtr(c, &n.Default, "default")
+		walkMap(c, &n.StatusCodeResponses, valueEntries[Response]())
+		walkMap(c, &n.StatusCodeRanges, valueEntries[Response]())
+	case *Response:
+		walkPtr(c, &n.Schema, "schema")
+		walkMap(c, &n.Headers, valueEntries[Header](), "headers")
+	case *Schema:
+		walkSchemaChildren(c, n)
+	}
+
+	return c
+}
+
+func walkSchemaChildren(c *walkChildList, s *Schema) {
+	if s.Items != nil {
+		walkPtr(c, &s.Items.Schema, "items")
+		walkSlice(c, &s.Items.Schemas, "items")
+	}
+	walkSlice(c, &s.AllOf, "allOf")
+	walkSlice(c, &s.OneOf, "oneOf")
+	walkSlice(c, &s.AnyOf, "anyOf")
+	walkPtr(c, &s.Not, "not")
+	walkMap(c, &s.Properties, valueEntries[Schema](), "properties")
+	if s.AdditionalProperties != nil {
+		walkPtr(c, &s.AdditionalProperties.Schema, "additionalProperties")
+	}
+	walkMap(c, &s.PatternProperties, valueEntries[Schema](), "patternProperties")
+	walkMap(c, &s.Dependencies, entryAccessor[SchemaOrStringArray, Schema]{
+		get: func(v *SchemaOrStringArray) *Schema { return v.Schema },
+		set: func(v *SchemaOrStringArray, schema *Schema) { v.Schema = schema },
+	}, "dependencies")
+	if s.AdditionalItems != nil {
+		walkPtr(c, &s.AdditionalItems.Schema, "additionalItems")
+	}
+	walkMap(c, &s.Definitions, valueEntries[Schema](), "definitions")
+	walkPtr(c, &s.XML, "xml")
+	walkPtr(c, &s.ExternalDocs, "externalDocs")
+}
+
+// walkPtr adds a child held by a pointer field, if not nil.
+func walkPtr[T any](c *walkChildList, field **T, tokens ...string) {
+	if *field == nil {
+		return
+	}
+
+	c.children = append(c.children, walkChild{
+		tokens: tokens,
+		node:   *field,
+		replace: func(value any) error {
+			v, err := transformValue[T](value)
+			if err != nil {
+				return err
+			}
+			*field = v
+
+			return nil
+		},
+		remove: func() { *field = nil },
+		insert: func(any) error {
+			return fmt.Errorf("cannot insert a value after %q, which is not in an array or a map: %w", strings.Join(tokens, "/"), ErrSpec)
+		},
+		store: func() {},
+	})
+}
+
+// walkMap adds the entries of a map as children, sorted by key.
+//
+// The child of an entry is located in its value by an accessor. Entries are copied,
+// then stored back into the map when transformed.
+func walkMap[M ~map[K]V, K ~string | ~int, V, T any](c *walkChildList, m *M, accessor entryAccessor[V, T], token ...string) {
+	for _, key := range mapKeysSorted(*m) {
+		value := (*m)[key]
+		node := accessor.get(&value)
+		if node == nil {
+			continue
+		}
+
+		newKey := key
+		c.children = append(c.children, walkChild{
+			tokens: append(slices.Clip(token), mapKeyToken(key)),
+			node:   node,
+			replace: func(replacement any) error {
+				if entry, isEntry := replacement.(Entry); isEntry {
+					k, err := parseMapKey[K](entry.Key)
+					if err != nil {
+						return err
+					}
+					if _, exists := (*m)[k]; exists && k != key {
+						return fmt.Errorf("cannot rename %q to %q: key already exists: %w", mapKeyToken(key), entry.Key, ErrSpec)
+					}
+					newKey = k
+					if entry.Value == nil {
+						return nil
+					}
+					replacement = entry.Value
+				}
+
+				v, err := transformValue[T](replacement)
+				if err != nil {
+					return err
+				}
+				accessor.set(&value, v)
+
+				return nil
+			},
+			remove: func() { delete(*m, key) },
+			insert: func(inserted any) error {
+				entry, isEntry := inserted.(Entry)
+				if !isEntry {
+					return fmt.Errorf("values inserted in a map must be an Entry, got %T: %w", inserted, ErrSpec)
+				}
+				k, err := parseMapKey[K](entry.Key)
+				if err != nil {
+					return err
+				}
+				if _, exists := (*m)[k]; exists {
+					return fmt.Errorf("cannot insert %q: key already exists: %w", entry.Key, ErrSpec)
+				}
+				v, err := transformValue[T](entry.Value)
+				if err != nil {
+					return err
+				}
+				var newValue V
+				accessor.set(&newValue, v)
+				if *m == nil {
+					*m = make(M)
+				}
+				(*m)[k] = newValue
+
+				return nil
+			},
+			store: func() {
+				if newKey != key {
+					delete(*m, key)
+				}
+				(*m)[newKey] = value
+			},
+		})
+	}
+}
+
+// walkSlice adds the elements of a slice as children.
+//
+// Removals and insertions are committed once all elements have been transformed.
+func walkSlice[S ~[]V, V any](c *walkChildList, s *S, token string) {
+	if len(*s) == 0 {
+		return
+	}
+
+	removed := make([]bool, len(*s))
+	inserted := make([][]V, len(*s))
+
+	for i := range *s {
+		c.children = append(c.children, walkChild{
+			tokens: []string{token, strconv.Itoa(i)},
+			node:   &(*s)[i],
+			replace: func(value any) error {
+				v, err := transformValue[V](value)
+				if err != nil {
+					return err
+				}
+				(*s)[i] = *v
+
+				return nil
+			},
+			remove: func() { removed[i] = true },
+			insert: func(value any) error {
+				v, err := transformValue[V](value)
+				if err != nil {
+					return err
+				}
+				inserted[i] = append(inserted[i], *v)
+
+				return nil
+			},
+			store: func() {},
+		})
+	}
+
+	c.commits = append(c.commits, func() {
+		if !slices.Contains(removed, true) && !slices.ContainsFunc(inserted, func(v []V) bool { return len(v) > 0 }) {
+			return
+		}
+
+		result := make(S, 0, len(*s))
+		for i, v := range *s {
+			if !removed[i] {
+				result = append(result, v)
+			}
+			result = append(result, inserted[i]...)
+		}
+		*s = result
+	})
+}
+
+// entryAccessor locates a child node in the value of a map entry.
+type entryAccessor[V, T any] struct {
+	get func(*V) *T
+	set func(*V, *T)
+}
+
+// valueEntries accesses nodes stored as map values.
+func valueEntries[T any]() entryAccessor[T, T] {
+	return entryAccessor[T, T]{
+		get: func(v *T) *T { return v },
+		set: func(v *T, node *T) { *v = *node },
+	}
+}
+
+// pointerEntries accesses nodes stored as pointers in map values.
+func pointerEntries[T any]() entryAccessor[*T, T] {
+	return entryAccessor[*T, T]{
+		get: func(v **T) *T { return *v },
+		set: func(v **T, node *T) { *v = node },
+	}
+}
+
+// transformValue converts a value to a pointer to a node of type T. The value may be either a T or a *T.
+func transformValue[T any](value any) (*T, error) {
+	switch v := value.(type) {
+	case *T:
+		if v == nil {
+			return nil, fmt.Errorf("expected a %T, got nil: %w", v, ErrSpec)
+		}
+
+		return v, nil
+	case T:
+		return &v, nil
+	default:
+		return nil, fmt.Errorf("expected a %T, got %T: %w", new(T), value, ErrSpec)
+	}
+}
+
+func mapKeyToken[K ~string | ~int](key K) string {
+	switch k := any(key).(type) {
+	case int:
+		return strconv.Itoa(k)
+	default:
+		return reflect.ValueOf(key).String()
+	}
+}
+
+func parseMapKey[K ~string | ~int](token string) (K, error) {
+	var key K
+	v := reflect.ValueOf(&key).Elem()
+	if v.Kind() == reflect.String {
+		v.SetString(token)
+
+		return key, nil
+	}
+
+	i, err := strconv.Atoi(token)
+	if err != nil {
+		return key, fmt.Errorf("invalid key %q: %w", token, ErrSpec)
+	}
+	v.SetInt(int64(i))
+
+	return key, nil
+}
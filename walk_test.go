@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+const walkSpec = `{
+  "swagger": "2.0",
+  "info": { "title": "nodes", "version": "1.0", "license": { "name": "MIT" } },
+  "tags": [ { "name": "nodes", "externalDocs": { "url": "https://example.com" } } ],
+  "paths": {
+    "/nodes": {
+      "parameters": [ { "name": "limit", "in": "query", "type": "array", "items": { "type": "integer" } } ],
+      "get": {
+        "responses": {
+          "200": {
+            "description": "ok",
+            "schema": { "type": "array", "items": { "$ref": "#/definitions/Node" } },
+            "headers": { "X-Total": { "type": "integer" } }
+          },
+          "default": { "$ref": "#/responses/error" }
+        }
+      },
+      "post": {
+        "parameters": [ { "name": "body", "in": "body", "schema": { "$ref": "#/definitions/Node" } } ],
+        "responses": { "201": { "description": "created" } }
+      }
+    }
+  },
+  "responses": {
+    "error": { "description": "error", "schema": { "type": "object", "additionalProperties": { "type": "string" } } }
+  },
+  "definitions": {
+    "Node": {
+      "type": "object",
+      "properties": {
+        "value": { "type": "string" },
+        "children": { "type": "array", "items": { "$ref": "#/definitions/Node" } },
+        "meta": { "allOf": [ { "type": "object" }, { "patternProperties": { "^x-": { "type": "string" } } } ] }
+      }
+    }
+  }
+}`
+
+func walkPointers(t *testing.T, sp *Swagger, options *WalkOptions) []string {
+	t.Helper()
+
+	var pointers []string
+	require.NoError(t, WalkWithOptions(sp, VisitorFunc(func(node *WalkNode) error {
+		pointers = append(pointers, fmt.Sprintf("%s %T", node.Pointer, node.Value))
+
+		return nil
+	}), options))
+
+	return pointers
+}
+
+func TestWalk(t *testing.T) {
+	sp := mustSpec(t, walkSpec)
+
+	pointers := walkPointers(t, sp, nil)
+	assert.Equal(t, []string{
+		" *spec.Swagger",
+		"/info *spec.Info",
+		"/info/license *spec.License",
+		"/paths *spec.Paths",
+		"/paths/~1nodes *spec.PathItem",
+		"/paths/~1nodes/get *spec.Operation",
+		"/paths/~1nodes/get/responses *spec.Responses",
+		"/paths/~1nodes/get/responses/default *spec.Response",
+		"/paths/~1nodes/get/responses/200 *spec.Response",
+		"/paths/~1nodes/get/responses/200/schema *spec.Schema",
+		"/paths/~1nodes/get/responses/200/schema/items *spec.Schema",
+		"/paths/~1nodes/get/responses/200/headers/X-Total *spec.Header",
+		"/paths/~1nodes/post *spec.Operation",
+		"/paths/~1nodes/post/parameters/0 *spec.Parameter",
+		"/paths/~1nodes/post/parameters/0/schema *spec.Schema",
+		"/paths/~1nodes/post/responses *spec.Responses",
+		"/paths/~1nodes/post/responses/201 *spec.Response",
+		"/paths/~1nodes/parameters/0 *spec.Parameter",
+		"/paths/~1nodes/parameters/0/items *spec.Items",
+		"/definitions/Node *spec.Schema",
+		"/definitions/Node/properties/children *spec.Schema",
+		"/definitions/Node/properties/children/items *spec.Schema",
+		"/definitions/Node/properties/meta *spec.Schema",
+		"/definitions/Node/properties/meta/allOf/0 *spec.Schema",
+		"/definitions/Node/properties/meta/allOf/1 *spec.Schema",
+		"/definitions/Node/properties/meta/allOf/1/patternProperties/^x- *spec.Schema",
+		"/definitions/Node/properties/value *spec.Schema",
+		"/responses/error *spec.Response",
+		"/responses/error/schema *spec.Schema",
+		"/responses/error/schema/additionalProperties *spec.Schema",
+		"/tags/0 *spec.Tag",
+		"/tags/0/externalDocs *spec.ExternalDocumentation",
+	}, pointers)
+
+	t.Run("parent chain", func(t *testing.T) {
+		require.NoError(t, Walk(sp, VisitorFunc(func(node *WalkNode) error {
+			if node.Pointer != "/paths/~1nodes/post/parameters/0/schema" {
+				return nil
+			}
+
+			var chain []string
+			for p := node.Parent; p != nil; p = p.Parent {
+				chain = append(chain, p.Pointer)
+			}
+			assert.Equal(t, []string{"/paths/~1nodes/post/parameters/0", "/paths/~1nodes/post", "/paths/~1nodes", "/paths", ""}, chain)
+			param, ok := node.Parent.Value.(*Parameter)
+			require.TrueT(t, ok)
+			assert.EqualT(t, "body", param.Name)
+
+			return nil
+		})))
+	})
+
+	t.Run("skipping subtrees", func(t *testing.T) {
+		var visited int
+		require.NoError(t, Walk(sp, VisitorFunc(func(node *WalkNode) error {
+			visited++
+			if _, ok := node.Value.(*Schema); ok {
+				return SkipSubtree
+			}
+			if _, ok := node.Value.(*Paths); ok {
+				return SkipSubtree
+			}
+
+			return nil
+		})))
+		// root, info, license, paths, 1 definition, 1 response, its schema, 1 tag and its external docs
+		assert.EqualT(t, 9, visited)
+	})
+
+	t.Run("errors should interrupt the walk", func(t *testing.T) {
+		errStop := errors.New("stop")
+		var visited int
+		err := Walk(sp, VisitorFunc(func(*WalkNode) error {
+			visited++
+			if visited == 3 {
+				return errStop
+			}
+
+			return nil
+		}))
+		require.ErrorIs(t, err, errStop)
+		assert.EqualT(t, 3, visited)
+	})
+}
+
+func TestWalk_FollowRefs(t *testing.T) {
+	sp := mustSpec(t, walkSpec)
+
+	var followed []string
+	require.NoError(t, WalkWithOptions(sp, VisitorFunc(func(node *WalkNode) error {
+		if node.Ref != "" {
+			followed = append(followed, node.Pointer+" "+node.Ref)
+			require.NotNil(t, node.Parent)
+			assert.EqualT(t, node.Pointer, node.Parent.Pointer)
+		}
+
+		return nil
+	}), &WalkOptions{FollowRefs: true}))
+
+	// the recursive Node definition is followed once on each branch
+	assert.Equal(t, []string{
+		"/paths/~1nodes/get/responses/default #/responses/error",
+		"/paths/~1nodes/get/responses/200/schema/items #/definitions/Node",
+		"/paths/~1nodes/post/parameters/0/schema #/definitions/Node",
+		"/definitions/Node/properties/children/items #/definitions/Node",
+	}, followed)
+
+	pointers := walkPointers(t, sp, &WalkOptions{FollowRefs: true})
+	assert.Contains(t, pointers, "/paths/~1nodes/get/responses/default/schema/additionalProperties *spec.Schema")
+	assert.Contains(t, pointers, "/paths/~1nodes/get/responses/200/schema/items/properties/children/items *spec.Schema")
+	assert.NotContains(t, pointers, "/paths/~1nodes/get/responses/200/schema/items/properties/children/items/properties/value *spec.Schema")
+
+	t.Run("unresolved $ref should be reported", func(t *testing.T) {
+		broken := mustSpec(t, `{"definitions":{"a":{"$ref":"#/definitions/missing"}}}`)
+		require.Error(t, WalkWithOptions(broken, VisitorFunc(func(*WalkNode) error { return nil }), &WalkOptions{FollowRefs: true}))
+		require.NoError(t, Walk(broken, VisitorFunc(func(*WalkNode) error { return nil })))
+	})
+}